@@ -0,0 +1,191 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	rdfsNamespace = "http://www.w3.org/2000/01/rdf-schema#"
+	// max length of the lines of the generated doc comments
+	commentWidth = 100
+)
+
+var (
+	rdfType     = rdf.NewURI("http://www.w3.org/1999/02/22-rdf-syntax-ns#type")
+	rdfsLabel   = rdf.NewURI(rdfsNamespace + "label")
+	rdfsComment = rdf.NewURI(rdfsNamespace + "comment")
+	owlOntology = rdf.NewURI("http://www.w3.org/2002/07/owl#Ontology")
+	dcTitle     = rdf.NewURI("http://purl.org/dc/terms/title")
+	// identifiers which cannot be used for the terms of a vocabulary
+	reservedNames = map[string]bool{"Namespace": true}
+)
+
+// term is a term of a vocabulary, with the Go identifier used to name it.
+type term struct {
+	name    string
+	local   string
+	label   string
+	comment string
+}
+
+// vocabulary holds the informations needed to generate the Go package of a vocabulary.
+type vocabulary struct {
+	pkg       string
+	namespace string
+	source    string
+	title     string
+	terms     []term
+}
+
+// goName converts the local name of a term into an exported Go identifier, e.g. subClassOf into SubClassOf.
+func goName(local string) string {
+	name := ""
+	upper := true
+	for _, r := range local {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			upper = true
+		case upper:
+			name += string(unicode.ToUpper(r))
+			upper = false
+		default:
+			name += string(r)
+		}
+	}
+	if name == "" {
+		return ""
+	}
+	if !unicode.IsLetter([]rune(name)[0]) {
+		name = "N" + name
+	}
+	return name
+}
+
+// literalValue returns the value of the literal which describes a term with a predicate, preferably in english.
+func literalValue(g graph.Graph, subject, predicate rdf.URI) string {
+	values := make([]rdf.Literal, 0)
+	for triple := range g.Filter(subject, predicate, rdf.NewVariable("o")) {
+		if literal, isLiteral := triple.Object.(rdf.Literal); isLiteral {
+			values = append(values, literal)
+		}
+	}
+	best, found := rdf.BestLiteral(values, "en")
+	if !found {
+		return ""
+	}
+	return strings.Join(strings.Fields(best.Value), " ")
+}
+
+// readVocabulary reads the terms of a vocabulary from a graph, i.e. the IRIs of the namespace which are described by the graph.
+func readVocabulary(g graph.Graph, pkg, namespace, source string) (*vocabulary, error) {
+	if namespace == "" {
+		return nil, errors.New("Error : the namespace of the vocabulary cannot be empty")
+	}
+	v := &vocabulary{pkg, namespace, source, "", make([]term, 0)}
+	locals := make(map[string]bool)
+	for triple := range g.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		if subject, isURI := triple.Subject.(rdf.URI); isURI && strings.HasPrefix(subject.Value, namespace) {
+			if local := subject.Value[len(namespace):]; local != "" {
+				locals[local] = true
+			}
+		}
+	}
+	// the title of the vocabulary is the title of the ontology, if the graph describes it
+	for triple := range g.Filter(rdf.NewVariable("s"), rdfType, owlOntology) {
+		if ontology, isURI := triple.Subject.(rdf.URI); isURI && v.title == "" {
+			if v.title = literalValue(g, ontology, dcTitle); v.title == "" {
+				v.title = literalValue(g, ontology, rdfsLabel)
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(locals))
+	for local := range locals {
+		sorted = append(sorted, local)
+	}
+	sort.Strings(sorted)
+	names := make(map[string]bool)
+	for _, local := range sorted {
+		name := goName(local)
+		if name == "" {
+			continue
+		}
+		// terms which only differ by their case or punctuation, e.g. foaf:Image & foaf:image, are disambiguated with a suffix
+		for reservedNames[name] || names[name] {
+			name += "_"
+		}
+		names[name] = true
+		subject := rdf.NewURI(namespace + local)
+		v.terms = append(v.terms, term{name, local, literalValue(g, subject, rdfsLabel), literalValue(g, subject, rdfsComment)})
+	}
+	if len(v.terms) == 0 {
+		return nil, errors.New("Error : no term of the namespace " + namespace + " has been found")
+	}
+	return v, nil
+}
+
+// writeComment writes a doc comment, wrapped to commentWidth characters, with an indentation.
+func writeComment(buf *bytes.Buffer, text, indent string) {
+	line := indent + "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+len(word)+1 > commentWidth && line != indent+"//" {
+			buf.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\n")
+}
+
+// sentence returns a text ending with a dot.
+func sentence(text string) string {
+	if text == "" || strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!") {
+		return text
+	}
+	return text + "."
+}
+
+// generate generates the formatted source code of the Go package of a vocabulary.
+func (v *vocabulary) generate() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Copyright (c) 2016 Thomas Minier. All rights reserved.\n")
+	buf.WriteString("// Use of this source code is governed by a MIT License\n")
+	buf.WriteString("// license that can be found in the LICENSE file.\n\n")
+	buf.WriteString("// Code generated by joseki-vocab from " + v.source + ". DO NOT EDIT.\n\n")
+	title := v.title
+	if title == "" {
+		title = v.pkg
+	}
+	writeComment(&buf, "Package "+v.pkg+" provides the IRIs of the terms of the "+title+" vocabulary.", "")
+	buf.WriteString("//\n// Namespace : " + v.namespace + "\n")
+	buf.WriteString("package " + v.pkg + "\n\n")
+	buf.WriteString("import \"github.com/Callidon/joseki/rdf\"\n\n")
+	buf.WriteString("// Namespace is the namespace of the vocabulary\n")
+	buf.WriteString("const Namespace = " + strconv.Quote(v.namespace) + "\n\n")
+	buf.WriteString("var (\n")
+	for i, t := range v.terms {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		doc := t.name + " is the IRI of " + v.pkg + ":" + t.local + "."
+		if t.label != "" && t.label != t.local {
+			doc = t.name + " is the IRI of " + v.pkg + ":" + t.local + " (" + t.label + ")."
+		}
+		writeComment(&buf, strings.TrimSpace(doc+" "+sentence(t.comment)), "\t")
+		buf.WriteString("\t" + t.name + " = rdf.NewURI(Namespace + " + strconv.Quote(t.local) + ")\n")
+	}
+	buf.WriteString(")\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	datas := [][]string{
+		[]string{"type", "Type"},
+		[]string{"subClassOf", "SubClassOf"},
+		[]string{"NCName", "NCName"},
+		[]string{"mbox_sha1sum", "MboxSha1sum"},
+		[]string{"first-name", "FirstName"},
+		[]string{"_1", "N1"},
+		[]string{"-", ""},
+	}
+	for _, data := range datas {
+		if name := goName(data[0]); name != data[1] {
+			t.Error("expected the Go name of", data[0], "to be", data[1], "but instead got", name)
+		}
+	}
+}
+
+func TestGenerateVocabulary(t *testing.T) {
+	namespace := "http://example.org/vocab#"
+	g := graph.NewTreeGraph()
+	g.Add(rdf.NewTriple(rdf.NewURI(namespace+"Image"), rdfsLabel, rdf.NewLangLiteral("Bild", "de")))
+	g.Add(rdf.NewTriple(rdf.NewURI(namespace+"Image"), rdfsLabel, rdf.NewLangLiteral("Picture", "en")))
+	g.Add(rdf.NewTriple(rdf.NewURI(namespace+"image"), rdfsComment, rdf.NewLiteral("An image of   a thing")))
+	g.Add(rdf.NewTriple(rdf.NewURI(namespace+"namespace"), rdfType, rdf.NewURI("http://example.org/Property")))
+	g.Add(rdf.NewTriple(rdf.NewURI("http://example.org/other#term"), rdfsLabel, rdf.NewLiteral("Other")))
+
+	v, err := readVocabulary(g, "example", namespace, "example.ttl")
+	if err != nil {
+		t.Fatal("reading the vocabulary shouldn't produce the error :", err)
+	}
+	source, err := v.generate()
+	if err != nil {
+		t.Fatal("generating the vocabulary shouldn't produce the error :", err)
+	}
+	expected := []string{
+		"// Code generated by joseki-vocab from example.ttl. DO NOT EDIT.",
+		"package example",
+		"const Namespace = \"http://example.org/vocab#\"",
+		"// Image is the IRI of example:Image (Picture).",
+		"Image = rdf.NewURI(Namespace + \"Image\")",
+		"// Image_ is the IRI of example:image. An image of a thing.",
+		"Image_ = rdf.NewURI(Namespace + \"image\")",
+		"Namespace_ = rdf.NewURI(Namespace + \"namespace\")",
+	}
+	for _, line := range expected {
+		if !strings.Contains(string(source), line) {
+			t.Error("expected the generated package to contain", line, "but instead got", string(source))
+		}
+	}
+	if strings.Contains(string(source), "Other") {
+		t.Error("the terms of other namespaces shouldn't be generated")
+	}
+
+	if _, err := readVocabulary(g, "example", "http://example.org/unknown#", "example.ttl"); err == nil {
+		t.Error("reading a vocabulary without terms should produce an error")
+	}
+}
+
+func TestGeneratedVocabularies(t *testing.T) {
+	vocabularies := [][]string{
+		[]string{"rdf", "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+		[]string{"rdfs", "http://www.w3.org/2000/01/rdf-schema#"},
+		[]string{"xsd", "http://www.w3.org/2001/XMLSchema#"},
+		[]string{"owl", "http://www.w3.org/2002/07/owl#"},
+		[]string{"skos", "http://www.w3.org/2004/02/skos/core#"},
+		[]string{"foaf", "http://xmlns.com/foaf/0.1/"},
+	}
+	// the generated packages must be up to date with their vocabularies
+	for _, vocab := range vocabularies {
+		dir := "../../vocab/" + vocab[0] + "/"
+		g := graph.NewTreeGraph()
+		if err := g.LoadFromFile(dir+vocab[0]+".ttl", ""); err != nil {
+			t.Fatal("loading the vocabulary", vocab[0], "shouldn't produce the error :", err)
+		}
+		v, err := readVocabulary(g, vocab[0], vocab[1], vocab[0]+".ttl")
+		if err != nil {
+			t.Fatal("reading the vocabulary", vocab[0], "shouldn't produce the error :", err)
+		}
+		source, err := v.generate()
+		if err != nil {
+			t.Fatal("generating the vocabulary", vocab[0], "shouldn't produce the error :", err)
+		}
+		current, err := ioutil.ReadFile(dir + vocab[0] + ".go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(current) != string(source) {
+			t.Error("the package", vocab[0], "is outdated, it must be generated again with go generate")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Command joseki-vocab generates a Go package from a RDFS or OWL vocabulary,
+// with a variable holding the IRI of each term of the vocabulary.
+//
+// The vocabulary is loaded with the parsers of joseki, so it can be written in any supported format.
+// The terms are the IRIs of the namespace which are the subject of at least one triple,
+// and their doc comments are built from their rdfs:label & rdfs:comment.
+//
+// Usage :
+//
+//  joseki-vocab -namespace http://xmlns.com/foaf/0.1/ -package foaf -out foaf.go foaf.ttl
+//
+// It is designed to be used with go generate, e.g. :
+//
+//  //go:generate joseki-vocab -namespace http://xmlns.com/foaf/0.1/ -package foaf -out foaf.go foaf.ttl
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Callidon/joseki/graph"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// run loads a vocabulary & writes the generated package into a file, or on the standard output if the file is empty.
+func run(filename, format, namespace, pkg, output string) error {
+	if pkg == "" {
+		return errors.New("Error : the name of the generated package cannot be empty")
+	}
+	g := graph.NewTreeGraph()
+	if err := g.LoadFromFile(filename, format); err != nil {
+		return err
+	}
+	v, err := readVocabulary(g, pkg, namespace, filepath.Base(filename))
+	if err != nil {
+		return err
+	}
+	source, err := v.generate()
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return ioutil.WriteFile(output, source, 0644)
+}
+
+func main() {
+	namespace := flag.String("namespace", "", "namespace of the terms of the vocabulary")
+	pkg := flag.String("package", "", "name of the generated package")
+	format := flag.String("format", "", "format of the vocabulary file, inferred from its extension if omitted")
+	output := flag.String("out", "", "file where the package is written, or the standard output if omitted")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage : joseki-vocab -namespace <IRI> -package <name> [-format <format>] [-out <file>] <vocabulary file>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *format, *namespace, *pkg, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
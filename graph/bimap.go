@@ -8,11 +8,29 @@ import "github.com/Callidon/joseki/rdf"
 
 // Data structure that represents bidirectional relations between elements of two collections.
 // Used as a dictionnary in the HDT-MR Graph implementation
+//
+// The nodes are located by their canonical form, so the literals which are equals are associated to the same key.
 type bimap struct {
 	keyToValue map[int]rdf.Node
 	valueToKey map[rdf.Node]int
 }
 
+// canonicalNode returns the canonical form of a node, used to locate it in a bimap.
+// The datatypes of the literals are written without angle brackets & without the implicit xsd:string,
+// and their languages are normalized.
+func canonicalNode(node rdf.Node) rdf.Node {
+	switch n := node.(type) {
+	case rdf.Literal:
+		if n.Lang != "" {
+			return rdf.NewDirLangLiteral(n.Value, n.Lang, n.Dir)
+		}
+		return rdf.NewTypedLiteral(n.Value, n.Type)
+	case rdf.QuotedTriple:
+		return rdf.QuotedTriple{Subject: canonicalNode(n.Subject), Predicate: canonicalNode(n.Predicate), Object: canonicalNode(n.Object)}
+	}
+	return node
+}
+
 // Return a new empty Bimap.
 func newBimap() *bimap {
 	return &bimap{make(map[int]rdf.Node), make(map[rdf.Node]int)}
@@ -26,24 +44,25 @@ func (b *bimap) push(key int, value rdf.Node) {
 	if inMap {
 		b.keyToValue[key] = value
 		// remove association in other map before updating it
-		delete(b.valueToKey, previousValue)
+		delete(b.valueToKey, canonicalNode(previousValue))
 	} else {
 		b.keyToValue[key] = value
 	}
 	// same thing for the key
-	previousKey, inMap := b.valueToKey[value]
+	canonical := canonicalNode(value)
+	previousKey, inMap := b.valueToKey[canonical]
 	if inMap {
-		b.valueToKey[value] = key
+		b.valueToKey[canonical] = key
 		// remove association in other map before updating it
 		delete(b.keyToValue, previousKey)
 	} else {
-		b.valueToKey[value] = key
+		b.valueToKey[canonical] = key
 	}
 }
 
 // Return the key associated to a value in the Bimap.
 func (b *bimap) locate(value rdf.Node) (key int, inMap bool) {
-	key, inMap = b.valueToKey[value]
+	key, inMap = b.valueToKey[canonicalNode(value)]
 	return
 }
 
@@ -12,8 +12,10 @@ import (
 
 // Node represented in the Bitmap standard, following the HDT-MR model.
 type bitmapNode struct {
-	id   int
-	sons map[int]*bitmapNode
+	id int
+	// version of the tree in which the node has been created
+	version int64
+	sons    map[int]*bitmapNode
 }
 
 // Triple represented in the Bitmap standard, following the HDT-MR model.
@@ -25,7 +27,7 @@ type bitmapTriple struct {
 
 // newBitmapNode creates a new Bitmap Node without any son.
 func newBitmapNode(id int) *bitmapNode {
-	return &bitmapNode{id, make(map[int]*bitmapNode)}
+	return &bitmapNode{id, 0, make(map[int]*bitmapNode)}
 }
 
 // depth calculates the number of nodes in the tree, starting from this node.
@@ -0,0 +1,191 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bufio"
+	"github.com/Callidon/joseki/parser"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// Default number of lines parsed together by a worker of a bulk load
+	defaultChunkSize = 10000
+)
+
+// BulkOptions are the options of a bulk load.
+type BulkOptions struct {
+	// Number of goroutines parsing the input. Defaults to the number of CPUs.
+	Workers int
+	// Number of lines parsed & inserted together. Defaults to 10000.
+	ChunkSize int
+	// Progress is called each time a chunk has been inserted into the graph, and when the load is complete.
+	Progress func(progress BulkProgress)
+}
+
+// BulkProgress reports the progress of a bulk load.
+type BulkProgress struct {
+	// Number of lines read
+	Lines int
+	// Number of triples inserted into the graph, excluding the triples which were already in it
+	Triples int
+	// Time elapsed since the start of the load
+	Elapsed time.Duration
+	// True if the load is complete
+	Done bool
+}
+
+// Throughput returns the number of triples inserted per second.
+func (p BulkProgress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Triples) / p.Elapsed.Seconds()
+}
+
+// bulkChunk is a chunk of lines read from the input, and the triples parsed from them.
+type bulkChunk struct {
+	index     int
+	firstLine int
+	lines     []string
+	triples   []rdf.Triple
+	err       error
+}
+
+// BulkLoad loads a file in N-Triples format into a graph, using several goroutines to parse it.
+// Compressed files are decompressed on the fly.
+//
+// See BulkLoadReader for more details.
+func BulkLoad(g Graph, filename string, options *BulkOptions) (BulkProgress, error) {
+	f, err := parser.Open(filename)
+	if err != nil {
+		return BulkProgress{}, err
+	}
+	defer f.Close()
+	return BulkLoadReader(g, f, options)
+}
+
+// BulkLoadReader loads a document in N-Triples format into a graph, using several goroutines to parse it.
+//
+// The input is split into chunks of lines, which are parsed by a pool of workers, then inserted
+// into the graph in the order of the input, one chunk at a time using Graph.AddAll.
+// Each triple must be written on a single line, as recommended by the N-Triples format.
+//
+// If a line cannot be parsed, the load stops & the error is returned.
+// The triples of the chunks preceding the invalid line are still inserted into the graph.
+func BulkLoadReader(g Graph, r io.Reader, options *BulkOptions) (BulkProgress, error) {
+	if options == nil {
+		options = &BulkOptions{}
+	}
+	workers, chunkSize := options.Workers, options.ChunkSize
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	start := time.Now()
+	chunks := make(chan *bulkChunk, workers)
+	parsed := make(chan *bulkChunk, workers)
+	done := make(chan struct{})
+	var readErr error
+
+	// split the input into chunks
+	go func() {
+		defer close(chunks)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		chunk := &bulkChunk{index: 0, firstLine: 1, lines: make([]string, 0, chunkSize)}
+		lineNumber := 1
+		for scanner.Scan() {
+			chunk.lines = append(chunk.lines, scanner.Text())
+			lineNumber++
+			if len(chunk.lines) == chunkSize {
+				select {
+				case chunks <- chunk:
+				case <-done:
+					return
+				}
+				chunk = &bulkChunk{index: chunk.index + 1, firstLine: lineNumber, lines: make([]string, 0, chunkSize)}
+			}
+		}
+		readErr = scanner.Err()
+		if len(chunk.lines) > 0 {
+			select {
+			case chunks <- chunk:
+			case <-done:
+			}
+		}
+	}()
+
+	// parse the chunks using a pool of workers
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			p := parser.NewNTParser()
+			for chunk := range chunks {
+				chunk.triples = make([]rdf.Triple, 0, len(chunk.lines))
+				for i, line := range chunk.lines {
+					triple, found, err := p.ParseLine(line, chunk.firstLine+i)
+					if err != nil {
+						chunk.err = err
+						break
+					} else if found {
+						chunk.triples = append(chunk.triples, triple)
+					}
+				}
+				select {
+				case parsed <- chunk:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(parsed)
+	}()
+
+	// insert the chunks in the order of the input
+	progress := BulkProgress{}
+	pending := make(map[int]*bulkChunk)
+	next := 0
+	var err error
+	for chunk := range parsed {
+		pending[chunk.index] = chunk
+		for pending[next] != nil && err == nil {
+			current := pending[next]
+			delete(pending, next)
+			next++
+			if current.err != nil {
+				err = current.err
+				close(done)
+				break
+			}
+			progress.Lines += len(current.lines)
+			progress.Triples += g.AddAll(current.triples)
+			progress.Elapsed = time.Since(start)
+			if options.Progress != nil {
+				options.Progress(progress)
+			}
+		}
+	}
+	if err == nil {
+		err = readErr
+	}
+	progress.Elapsed = time.Since(start)
+	progress.Done = true
+	if options.Progress != nil {
+		options.Progress(progress)
+	}
+	return progress, err
+}
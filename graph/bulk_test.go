@@ -0,0 +1,130 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateNTriples generates a document in N-Triples format with distinct triples, for testing purpose
+func generateNTriples(nbTriples int) string {
+	var buf bytes.Buffer
+	buf.WriteString("# generated datas\n")
+	for i := 0; i < nbTriples; i++ {
+		fmt.Fprintf(&buf, "<http://example.org/s%d> <http://example.org/p%d> \"value %d\"@en .\n", i/10, i%10, i)
+		if i%100 == 0 {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+func TestBulkLoadReader(t *testing.T) {
+	nbTriples := 2500
+	graphs := []Graph{NewListGraph(), NewTreeGraph()}
+	for _, g := range graphs {
+		updates := make([]BulkProgress, 0)
+		options := &BulkOptions{Workers: 4, ChunkSize: 100, Progress: func(p BulkProgress) {
+			updates = append(updates, p)
+		}}
+		progress, err := BulkLoadReader(g, strings.NewReader(generateNTriples(nbTriples)), options)
+		if err != nil {
+			t.Fatal("bulk loading valid triples shouldn't produce the error :", err)
+		}
+		if !progress.Done || progress.Triples != nbTriples {
+			t.Error("expected", nbTriples, "triples to be loaded but instead got", progress.Triples)
+		}
+		cpt := 0
+		for _ = range g.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+			cpt++
+		}
+		if cpt != nbTriples {
+			t.Error("the graph should contains", nbTriples, "triples, but it contains", cpt, "triples")
+		}
+
+		// check that the progress is reported after each chunk, then at the end of the load
+		if len(updates) < 2 || !updates[len(updates)-1].Done {
+			t.Fatal("expected the progress to be reported several times, but instead got", updates)
+		}
+		for i := 1; i < len(updates); i++ {
+			if updates[i].Triples < updates[i-1].Triples || updates[i].Lines < updates[i-1].Lines {
+				t.Error("the progress of a bulk load should always increase, but instead got", updates[i-1], "then", updates[i])
+			}
+		}
+	}
+}
+
+func TestBulkLoadOrder(t *testing.T) {
+	g := NewListGraph()
+	if _, err := BulkLoadReader(g, strings.NewReader(generateNTriples(1000)), &BulkOptions{Workers: 8, ChunkSize: 7}); err != nil {
+		t.Fatal("bulk loading valid triples shouldn't produce the error :", err)
+	}
+	// the triples are inserted in the order of the input
+	i := 0
+	for triple := range g.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		expected := rdf.NewLangLiteral(fmt.Sprintf("value %d", i), "en")
+		if test, err := triple.Object.Equals(expected); !test || err != nil {
+			t.Error("expected the triple n°", i, "to have", expected, "as object but instead got", triple.Object)
+		}
+		i++
+	}
+}
+
+func TestBulkLoadErrors(t *testing.T) {
+	input := generateNTriples(300)
+	lines := strings.Split(input, "\n")
+	lines[250] = "<http://example.org/s> <http://example.org/p> ."
+	g := NewListGraph()
+	progress, err := BulkLoadReader(g, strings.NewReader(strings.Join(lines, "\n")), &BulkOptions{Workers: 2, ChunkSize: 100})
+	if err == nil {
+		t.Fatal("bulk loading a malformed triple should produce an error")
+	}
+	// the chunks preceding the malformed triple are inserted
+	if progress.Lines != 200 {
+		t.Error("expected 200 lines to be loaded before the error but instead got", progress.Lines)
+	}
+
+	multiline := "<http://example.org/s>\n<http://example.org/p> <http://example.org/o> .\n"
+	if _, err := BulkLoadReader(NewListGraph(), strings.NewReader(multiline), nil); err == nil {
+		t.Error("bulk loading a triple written on several lines should produce an error")
+	}
+}
+
+func TestBulkLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "datas.nt")
+	ioutil.WriteFile(filename, []byte(generateNTriples(500)), 0644)
+
+	g := NewTreeGraph()
+	progress, err := BulkLoad(g, filename, nil)
+	if err != nil {
+		t.Fatal("bulk loading a valid file shouldn't produce the error :", err)
+	}
+	if progress.Triples != 500 {
+		t.Error("expected 500 triples to be loaded but instead got", progress.Triples)
+	}
+	if _, err := BulkLoad(g, filepath.Join(dir, "missing.nt"), nil); err == nil {
+		t.Error("bulk loading a missing file should produce an error")
+	}
+}
+
+func BenchmarkBulkLoadListGraph(b *testing.B) {
+	input := generateNTriples(30000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BulkLoadReader(NewListGraph(), strings.NewReader(input), nil)
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/Callidon/joseki/rdf"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// prefix of the canonical blank node identifiers
+	canonicalPrefix = "c14n"
+	// max number of calls to the Hash N-Degree Quads algorithm, per blank node, before giving up
+	maxDegreeCalls = 1000
+)
+
+// identifierIssuer issues new blank node identifiers, made of a prefix & a counter.
+type identifierIssuer struct {
+	prefix  string
+	counter int
+	issued  map[string]string
+	order   []string
+}
+
+// newIdentifierIssuer creates a new identifierIssuer, which hasn't issued any identifier.
+func newIdentifierIssuer(prefix string) *identifierIssuer {
+	return &identifierIssuer{prefix, 0, make(map[string]string), make([]string, 0)}
+}
+
+// issue returns the identifier issued for a blank node, and issues a new one if needed.
+func (i *identifierIssuer) issue(label string) string {
+	if id, issued := i.issued[label]; issued {
+		return id
+	}
+	id := i.prefix + strconv.Itoa(i.counter)
+	i.counter++
+	i.issued[label] = id
+	i.order = append(i.order, label)
+	return id
+}
+
+// copy returns a copy of the issuer.
+func (i *identifierIssuer) copy() *identifierIssuer {
+	issued := make(map[string]string, len(i.issued))
+	for label, id := range i.issued {
+		issued[label] = id
+	}
+	return &identifierIssuer{i.prefix, i.counter, issued, append([]string{}, i.order...)}
+}
+
+// canonicalEscaper escapes the characters of a literal, as in canonical N-Triples
+var canonicalEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t", "\b", "\\b", "\f", "\\f")
+
+// canonicalTerm serializes a RDF node in canonical N-Quads, with a function which gives the labels of blank nodes.
+func canonicalTerm(node rdf.Node, label func(string) string) string {
+	switch n := node.(type) {
+	case rdf.URI:
+		return "<" + n.Value + ">"
+	case rdf.BlankNode:
+		return "_:" + label(n.Value)
+	case rdf.Literal:
+		var value string
+		for _, c := range canonicalEscaper.Replace(n.Value) {
+			if (c < 0x20 && c != '\n' && c != '\r' && c != '\t' && c != '\b' && c != '\f') || c == 0x7f {
+				value += fmt.Sprintf("\\u%04X", c)
+			} else {
+				value += string(c)
+			}
+		}
+		term := "\"" + value + "\""
+		if n.Lang != "" {
+			term += "@" + n.Lang
+			if n.Dir != "" {
+				term += "--" + n.Dir
+			}
+		} else if n.Datatype() != rdf.XSDString {
+			term += "^^<" + n.Datatype() + ">"
+		}
+		return term
+	}
+	return node.String()
+}
+
+// canonicalQuad serializes a triple of the default graph in canonical N-Quads.
+func canonicalQuad(triple rdf.Triple, label func(string) string) string {
+	return canonicalTerm(triple.Subject, label) + " " + canonicalTerm(triple.Predicate, label) + " " + canonicalTerm(triple.Object, label) + " .\n"
+}
+
+// hashString returns the SHA-256 hash of a string, in hexadecimal.
+func hashString(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// permutations calls a function with each permutation of a list of labels, until it returns False.
+func permutations(labels []string, f func([]string) bool) bool {
+	var permute func(k int) bool
+	permute = func(k int) bool {
+		if k == len(labels) {
+			return f(labels)
+		}
+		for i := k; i < len(labels); i++ {
+			labels[k], labels[i] = labels[i], labels[k]
+			if !permute(k + 1) {
+				return false
+			}
+			labels[k], labels[i] = labels[i], labels[k]
+		}
+		return true
+	}
+	return permute(0)
+}
+
+// canonicalizer holds the state of the RDF Dataset Canonicalization algorithm.
+type canonicalizer struct {
+	quads     map[string][]rdf.Triple
+	canonical *identifierIssuer
+	calls     int
+	maxCalls  int
+}
+
+// hashFirstDegree hashes the triples of a blank node, where the blank node is labelled _:a and the other ones _:z.
+func (c *canonicalizer) hashFirstDegree(label string) string {
+	lines := make([]string, 0, len(c.quads[label]))
+	for _, triple := range c.quads[label] {
+		lines = append(lines, canonicalQuad(triple, func(other string) string {
+			if other == label {
+				return "a"
+			}
+			return "z"
+		}))
+	}
+	sort.Strings(lines)
+	return hashString(strings.Join(lines, ""))
+}
+
+// hashRelated hashes a blank node related to another one by a triple, at a given position in the triple.
+func (c *canonicalizer) hashRelated(related string, triple rdf.Triple, issuer *identifierIssuer, position string) string {
+	input := position
+	if position != "g" {
+		input += canonicalTerm(triple.Predicate, func(other string) string { return other })
+	}
+	if id, issued := c.canonical.issued[related]; issued {
+		input += "_:" + id
+	} else if id, issued := issuer.issued[related]; issued {
+		input += "_:" + id
+	} else {
+		input += c.hashFirstDegree(related)
+	}
+	return hashString(input)
+}
+
+// hashNDegree hashes a blank node according to the blank nodes related to it, and returns the issuer
+// which holds the identifiers given to the related blank nodes.
+func (c *canonicalizer) hashNDegree(label string, issuer *identifierIssuer) (string, *identifierIssuer, error) {
+	c.calls++
+	if c.calls > c.maxCalls {
+		return "", nil, errors.New("Error : the graph is too complex to be canonicalized")
+	}
+	related := make(map[string][]string)
+	for _, triple := range c.quads[label] {
+		positions := []struct {
+			node     rdf.Node
+			position string
+		}{{triple.Subject, "s"}, {triple.Object, "o"}}
+		for _, component := range positions {
+			if blank, isBlank := component.node.(rdf.BlankNode); isBlank && blank.Value != label {
+				hash := c.hashRelated(blank.Value, triple, issuer, component.position)
+				related[hash] = append(related[hash], blank.Value)
+			}
+		}
+	}
+	hashes := make([]string, 0, len(related))
+	for hash := range related {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	data := ""
+	for _, hash := range hashes {
+		data += hash
+		chosenPath := ""
+		var chosenIssuer *identifierIssuer
+		var err error
+		permutations(related[hash], func(permutation []string) bool {
+			issuerCopy := issuer.copy()
+			path := ""
+			recursion := make([]string, 0)
+			for _, other := range permutation {
+				if id, issued := c.canonical.issued[other]; issued {
+					path += "_:" + id
+				} else {
+					if _, issued := issuerCopy.issued[other]; !issued {
+						recursion = append(recursion, other)
+					}
+					path += "_:" + issuerCopy.issue(other)
+				}
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return true
+				}
+			}
+			for _, other := range recursion {
+				var result string
+				result, issuerCopy, err = c.hashNDegree(other, issuerCopy)
+				if err != nil {
+					return false
+				}
+				path += "_:" + issuerCopy.issue(other) + "<" + result + ">"
+				if chosenPath != "" && len(path) >= len(chosenPath) && path > chosenPath {
+					return true
+				}
+			}
+			if chosenPath == "" || path < chosenPath {
+				chosenPath = path
+				chosenIssuer = issuerCopy
+			}
+			return true
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		data += chosenPath
+		issuer = chosenIssuer
+	}
+	return hashString(data), issuer, nil
+}
+
+// CanonicalForm is the canonical form of a graph, where blank nodes have deterministic labels.
+type CanonicalForm struct {
+	// Labels maps the labels of the blank nodes of the graph to their canonical labels
+	Labels map[string]string
+	// NQuads is the graph serialized in canonical N-Quads, with one line per triple in code point order
+	NQuads string
+}
+
+// Hash returns the SHA-256 hash of the canonical N-Quads of the graph, in hexadecimal.
+func (c *CanonicalForm) Hash() string {
+	return hashString(c.NQuads)
+}
+
+// Canonicalize computes the canonical form of a graph, using the RDF Dataset Canonicalization algorithm (RDFC-1.0).
+//
+// Two graphs have the same canonical form if and only if they are isomorphic, so it can be used to sign graphs
+// or to identify them by their content. Since graphs have no named graphs, all triples belong to the default graph.
+// An error is returned if the graph is too complex to be canonicalized in a reasonable time.
+// RDFC-1.0 reference : https://www.w3.org/TR/rdf-canon/
+func Canonicalize(g Graph) (*CanonicalForm, error) {
+	triples := readTriples(g).sorted()
+	c := &canonicalizer{make(map[string][]rdf.Triple), newIdentifierIssuer(canonicalPrefix), 0, 0}
+	for _, triple := range triples {
+		for _, node := range []rdf.Node{triple.Subject, triple.Object} {
+			if blank, isBlank := node.(rdf.BlankNode); isBlank {
+				// a triple is only added once, even if the blank node is both its subject and object
+				quads := c.quads[blank.Value]
+				if len(quads) == 0 || tripleKey(quads[len(quads)-1]) != tripleKey(triple) {
+					c.quads[blank.Value] = append(quads, triple)
+				}
+			}
+		}
+	}
+	c.maxCalls = maxDegreeCalls * (len(c.quads) + 1)
+
+	// blank nodes with a unique first degree hash are labelled first
+	byHash := make(map[string][]string)
+	for label := range c.quads {
+		hash := c.hashFirstDegree(label)
+		byHash[hash] = append(byHash[hash], label)
+	}
+	hashes := make([]string, 0, len(byHash))
+	for hash := range byHash {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		if len(byHash[hash]) == 1 {
+			c.canonical.issue(byHash[hash][0])
+		}
+	}
+
+	// then, the other ones are distinguished by the blank nodes related to them
+	for _, hash := range hashes {
+		if len(byHash[hash]) == 1 {
+			continue
+		}
+		type pathResult struct {
+			hash   string
+			issuer *identifierIssuer
+		}
+		results := make([]pathResult, 0)
+		sort.Strings(byHash[hash])
+		for _, label := range byHash[hash] {
+			if _, issued := c.canonical.issued[label]; issued {
+				continue
+			}
+			issuer := newIdentifierIssuer("b")
+			issuer.issue(label)
+			result, resultIssuer, err := c.hashNDegree(label, issuer)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, pathResult{result, resultIssuer})
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].hash < results[j].hash
+		})
+		for _, result := range results {
+			for _, label := range result.issuer.order {
+				c.canonical.issue(label)
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(triples))
+	canonicalLabel := func(label string) string {
+		return c.canonical.issued[label]
+	}
+	for _, triple := range triples {
+		lines = append(lines, canonicalQuad(triple, canonicalLabel))
+	}
+	sort.Strings(lines)
+	labels := make(map[string]string, len(c.canonical.issued))
+	for label, id := range c.canonical.issued {
+		labels[label] = id
+	}
+	return &CanonicalForm{labels, strings.Join(lines, "")}, nil
+}
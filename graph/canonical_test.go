@@ -0,0 +1,179 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"encoding/json"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// canonTest is an entry of the manifest of the canonicalization tests.
+type canonTest struct {
+	id, kind, name, action, result, hashAlgorithm string
+}
+
+// readCanonManifest reads the entries of a manifest of the W3C rdf-canon test suite.
+// The list of the entries (mf:entries) is ignored, the tests are read in the order of the file.
+func readCanonManifest(filename string) ([]canonTest, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	entry := regexp.MustCompile(`(?m)^:(\S+)\s+a\s+rdfc:(\w+)`)
+	property := regexp.MustCompile(`(mf:name|mf:action|mf:result|rdfc:hashAlgorithm)\s+("[^"]*"|<[^>]*>)`)
+	dir := filepath.Dir(filename)
+	tests := make([]canonTest, 0)
+	starts := entry.FindAllSubmatchIndex(content, -1)
+	for i, start := range starts {
+		end := len(content)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		test := canonTest{string(content[start[2]:start[3]]), string(content[start[4]:start[5]]), "", "", "", ""}
+		for _, match := range property.FindAllSubmatch(content[start[1]:end], -1) {
+			value := string(match[2][1 : len(match[2])-1])
+			switch string(match[1]) {
+			case "mf:name":
+				test.name = value
+			case "mf:action":
+				test.action = filepath.Join(dir, value)
+			case "mf:result":
+				test.result = filepath.Join(dir, value)
+			case "rdfc:hashAlgorithm":
+				test.hashAlgorithm = value
+			}
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// runCanonManifest runs the evaluation, map & negative tests of a manifest of the W3C rdf-canon test suite.
+func runCanonManifest(t *testing.T, filename string) {
+	tests, err := readCanonManifest(filename)
+	if err != nil || len(tests) == 0 {
+		t.Fatal("cannot read the manifest of the canonicalization tests", err)
+	}
+	term := `(<[^>]*>|_:\S+|"([^"\\]|\\.)*"(@\S+|\^\^<[^>]*>)?)`
+	quad := regexp.MustCompile(`(?m)^\s*` + term + `\s*` + term + `\s*` + term + `\s*` + term + `\s*\.\s*$`)
+	for _, test := range tests {
+		test := test
+		t.Run(test.id, func(t *testing.T) {
+			switch {
+			case test.kind != "RDFC10EvalTest" && test.kind != "RDFC10MapTest" && test.kind != "RDFC10NegativeEvalTest":
+				t.Skip("the tests of type", test.kind, "aren't supported")
+			case test.hashAlgorithm != "" && test.hashAlgorithm != "SHA256":
+				t.Skip("only the SHA-256 hash algorithm is supported, but the test", test.name, "uses", test.hashAlgorithm)
+			}
+			input, err := ioutil.ReadFile(test.action)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the graphs only contain triples, so the inputs with named graphs cannot be loaded
+			if quad.Match(input) {
+				t.Skip("the input of the test", test.name, "contains named graphs")
+			}
+			// the labels of the input are kept, since the map tests refer to them
+			list, tree := NewListGraph(), NewTreeGraph()
+			options := LoadOptions{"nt", "", KeepBlankNodes, "", false}
+			for _, err := range []error{list.LoadWithOptions(test.action, options), tree.LoadWithOptions(test.action, options)} {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, g := range []Graph{list, tree} {
+				canonical, err := Canonicalize(g)
+				if test.kind == "RDFC10NegativeEvalTest" {
+					if err == nil {
+						t.Error("for the test", test.name, "expected the canonicalization to fail")
+					}
+					continue
+				} else if err != nil {
+					t.Fatal(err)
+				}
+				expected, err := ioutil.ReadFile(test.result)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if test.kind == "RDFC10MapTest" {
+					labels := make(map[string]string)
+					if err := json.Unmarshal(expected, &labels); err != nil {
+						t.Fatal(err)
+					}
+					if !reflect.DeepEqual(labels, canonical.Labels) {
+						t.Error("for the test", test.name, "expected the labels", labels, "but instead got", canonical.Labels)
+					}
+				} else if canonical.NQuads != string(expected) {
+					t.Error("for the test", test.name, "expected\n", string(expected), "but instead got\n", canonical.NQuads)
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalizeW3CManifest(t *testing.T) {
+	manifest := "datas/rdfc/manifest.ttl"
+	if _, err := os.Stat(manifest); os.IsNotExist(err) {
+		t.Skip("the W3C rdf-canon test suite isn't vendored, run datas/rdfc/vendor.sh")
+	}
+	runCanonManifest(t, manifest)
+}
+
+func TestCanonicalizeLocalManifest(t *testing.T) {
+	runCanonManifest(t, "datas/rdfc/local/manifest.ttl")
+}
+
+func TestCanonicalize(t *testing.T) {
+	knows := rdf.NewURI("http://example.org/knows")
+	name := rdf.NewURI("http://example.org/name")
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewBlankNode("a"), knows, rdf.NewBlankNode("b")),
+		rdf.NewTriple(rdf.NewBlankNode("b"), knows, rdf.NewBlankNode("c")),
+		rdf.NewTriple(rdf.NewBlankNode("c"), knows, rdf.NewBlankNode("a")),
+		rdf.NewTriple(rdf.NewBlankNode("a"), name, rdf.NewLiteral("a \"quoted\"\nname\\")),
+		rdf.NewTriple(rdf.NewBlankNode("b"), name, rdf.NewTypedLiteral("Arnaud", "<http://www.w3.org/2001/XMLSchema#string>")),
+	}
+	relabeled := []rdf.Triple{
+		rdf.NewTriple(rdf.NewBlankNode("y"), knows, rdf.NewBlankNode("z")),
+		rdf.NewTriple(rdf.NewBlankNode("x"), knows, rdf.NewBlankNode("y")),
+		rdf.NewTriple(rdf.NewBlankNode("z"), knows, rdf.NewBlankNode("x")),
+		rdf.NewTriple(rdf.NewBlankNode("y"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(rdf.NewBlankNode("x"), name, rdf.NewLiteral("a \"quoted\"\nname\\")),
+	}
+
+	a, err := Canonicalize(newGraphs(datas)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Canonicalize(newGraphs(relabeled)[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.NQuads != b.NQuads || a.Hash() != b.Hash() {
+		t.Error("expected isomorphic graphs to have the same canonical form, but instead got\n", a.NQuads, "and\n", b.NQuads)
+	}
+	if a.Labels["a"] != b.Labels["x"] || a.Labels["b"] != b.Labels["y"] || a.Labels["c"] != b.Labels["z"] {
+		t.Error("expected the same blank nodes to have the same canonical labels, but instead got", a.Labels, "and", b.Labels)
+	}
+	if !strings.Contains(a.NQuads, "\"a \\\"quoted\\\"\\nname\\\\\" .\n") || !strings.Contains(a.NQuads, "\"Arnaud\" .\n") {
+		t.Error("expected the literals to be escaped & written without the xsd:string datatype, but instead got\n", a.NQuads)
+	}
+
+	// a graph which isn't isomorphic has another hash
+	c, err := Canonicalize(newGraphs(datas[:4])[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Hash() == a.Hash() {
+		t.Error("expected graphs which aren't isomorphic to have different hashes")
+	}
+}
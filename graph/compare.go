@@ -0,0 +1,365 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Callidon/joseki/rdf"
+	"sort"
+	"strings"
+)
+
+// termKey returns a string which identifies a RDF node.
+// The literals are identified by their datatypes, so the implicit xsd:string is ignored, and the languages are compared case-insensitively.
+func termKey(node rdf.Node) string {
+	switch n := node.(type) {
+	case rdf.URI:
+		return "U" + n.Value
+	case rdf.BlankNode:
+		return "B" + n.Value
+	case rdf.Literal:
+		return "L" + n.Value + "\x00" + n.Datatype() + "\x00" + strings.ToLower(n.Lang) + "\x00" + n.Dir
+	case rdf.QuotedTriple:
+		return "Q\x02" + tripleKey(n.Triple()) + "\x03"
+	}
+	return "V" + node.String()
+}
+
+// tripleKey returns a string which identifies a triple.
+func tripleKey(triple rdf.Triple) string {
+	return termKey(triple.Subject) + "\x01" + termKey(triple.Predicate) + "\x01" + termKey(triple.Object)
+}
+
+// blankLabels returns the labels of the blank nodes of a triple, including the ones nested in quoted triples.
+func blankLabels(triple rdf.Triple) []string {
+	labels := make([]string, 0)
+	for _, node := range []rdf.Node{triple.Subject, triple.Predicate, triple.Object} {
+		switch n := node.(type) {
+		case rdf.BlankNode:
+			labels = append(labels, n.Value)
+		case rdf.QuotedTriple:
+			labels = append(labels, blankLabels(n.Triple())...)
+		}
+	}
+	return labels
+}
+
+// hasBlank returns True if a triple contains a blank node, even nested in a quoted triple.
+func hasBlank(triple rdf.Triple) bool {
+	return len(blankLabels(triple)) > 0
+}
+
+// blankNeighbours returns the triples in which each blank node appears.
+func blankNeighbours(triples []rdf.Triple) map[string][]rdf.Triple {
+	neighbours := make(map[string][]rdf.Triple)
+	for _, triple := range triples {
+		for _, label := range blankLabels(triple) {
+			neighbours[label] = append(neighbours[label], triple)
+		}
+	}
+	return neighbours
+}
+
+// signature describes a node for the refinement of the color of a blank node, without the labels of the other blank nodes.
+func signature(node rdf.Node, label string, colors map[string]string) string {
+	switch n := node.(type) {
+	case rdf.BlankNode:
+		if n.Value == label {
+			return "S"
+		}
+		return "B" + colors[n.Value]
+	case rdf.QuotedTriple:
+		triple := n.Triple()
+		return "Q\x02" + signature(triple.Subject, label, colors) + "\x01" + signature(triple.Predicate, label, colors) + "\x01" + signature(triple.Object, label, colors) + "\x03"
+	}
+	return termKey(node)
+}
+
+// tripleSet is a set of triples, without duplicates.
+type tripleSet map[string]rdf.Triple
+
+// readTriples reads all the triples of a graph, from a snapshot of the graph.
+func readTriples(g Graph) tripleSet {
+	snapshot := g.Snapshot()
+	defer snapshot.Release()
+	all := rdf.NewVariable("v")
+	set := make(tripleSet)
+	for triple := range snapshot.Filter(all, all, all) {
+		set[tripleKey(triple)] = triple
+	}
+	return set
+}
+
+// sorted returns the triples of the set, sorted with rdf.CompareTriples.
+func (set tripleSet) sorted() []rdf.Triple {
+	triples := make([]rdf.Triple, 0, len(set))
+	for _, triple := range set {
+		triples = append(triples, triple)
+	}
+	sort.Slice(triples, func(i, j int) bool {
+		return rdf.CompareTriples(triples[i], triples[j]) < 0
+	})
+	return triples
+}
+
+// blankColors computes a color for each blank node of a set of triples, by iterative refinement.
+//
+// The color of a blank node summarizes its neighbourhood, independently of its label:
+// two blank nodes which can be mapped to each other by an isomorphism always have the same color.
+func blankColors(triples []rdf.Triple) map[string]string {
+	colors := make(map[string]string)
+	neighbours := blankNeighbours(triples)
+	for label := range neighbours {
+		colors[label] = ""
+	}
+	nbColors := 1
+	for round := 0; round <= len(colors); round++ {
+		newColors := make(map[string]string, len(colors))
+		distinct := make(map[string]bool)
+		for label, color := range colors {
+			signatures := make([]string, 0, len(neighbours[label]))
+			for _, triple := range neighbours[label] {
+				signatures = append(signatures, signature(rdf.NewQuotedTriple(triple), label, colors))
+			}
+			sort.Strings(signatures)
+			hash := sha256.Sum256([]byte(color + "\x02" + strings.Join(signatures, "\x02")))
+			newColors[label] = hex.EncodeToString(hash[:])
+			distinct[newColors[label]] = true
+		}
+		colors = newColors
+		// stop when the refinement doesn't distinguish more blank nodes
+		if len(distinct) == nbColors && round > 0 {
+			break
+		}
+		nbColors = len(distinct)
+	}
+	return colors
+}
+
+// blankMatcher searches for a bijection between the blank nodes of two sets of triples.
+type blankMatcher struct {
+	target     tripleSet
+	colors     map[string]string
+	candidates map[string][]string
+	neighbours map[string][]rdf.Triple
+	mapping    map[string]string
+	used       map[string]bool
+}
+
+// mapNode applies the current mapping to a node, including the blank nodes nested in a quoted triple.
+// It returns false if the node contains an unmapped blank node.
+func (m *blankMatcher) mapNode(node rdf.Node) (rdf.Node, bool) {
+	switch n := node.(type) {
+	case rdf.BlankNode:
+		label, mapped := m.mapping[n.Value]
+		return rdf.NewBlankNode(label), mapped
+	case rdf.QuotedTriple:
+		triple, mapped := m.mapTriple(n.Triple())
+		return rdf.NewQuotedTriple(triple), mapped
+	}
+	return node, true
+}
+
+// mapTriple applies the current mapping to a triple. It returns false if the triple contains an unmapped blank node.
+func (m *blankMatcher) mapTriple(triple rdf.Triple) (rdf.Triple, bool) {
+	subject, subjMapped := m.mapNode(triple.Subject)
+	predicate, predMapped := m.mapNode(triple.Predicate)
+	object, objMapped := m.mapNode(triple.Object)
+	return rdf.NewTriple(subject, predicate, object), subjMapped && predMapped && objMapped
+}
+
+// consistent checks that the triples of a blank node are mapped to triples of the target, for the current mapping.
+func (m *blankMatcher) consistent(label string) bool {
+	for _, triple := range m.neighbours[label] {
+		if mapped, isMapped := m.mapTriple(triple); isMapped {
+			if _, inTarget := m.target[tripleKey(mapped)]; !inTarget {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// search maps the blank nodes in order, and backtracks when a mapping is inconsistent.
+func (m *blankMatcher) search(labels []string, index int) bool {
+	if index == len(labels) {
+		return true
+	}
+	label := labels[index]
+	for _, candidate := range m.candidates[m.colors[label]] {
+		if m.used[candidate] {
+			continue
+		}
+		m.mapping[label] = candidate
+		m.used[candidate] = true
+		if m.consistent(label) && m.search(labels, index+1) {
+			return true
+		}
+		delete(m.mapping, label)
+		m.used[candidate] = false
+	}
+	return false
+}
+
+// isomorphic returns True if there is a bijection between the blank nodes of two sets of triples,
+// which maps the first set to the second one.
+func isomorphic(a, b tripleSet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	// the triples without blank nodes must be the same
+	blankA, blankB := make([]rdf.Triple, 0), make([]rdf.Triple, 0)
+	for key, triple := range a {
+		if hasBlank(triple) {
+			blankA = append(blankA, triple)
+		} else if _, inB := b[key]; !inB {
+			return false
+		}
+	}
+	for _, triple := range b {
+		if hasBlank(triple) {
+			blankB = append(blankB, triple)
+		}
+	}
+	if len(blankA) != len(blankB) {
+		return false
+	}
+	if len(blankA) == 0 {
+		return true
+	}
+
+	// the blank nodes can only be mapped to blank nodes of the same color
+	colorsA, colorsB := blankColors(blankA), blankColors(blankB)
+	if len(colorsA) != len(colorsB) {
+		return false
+	}
+	candidates := make(map[string][]string)
+	for label, color := range colorsB {
+		candidates[color] = append(candidates[color], label)
+	}
+	counts := make(map[string]int)
+	for _, color := range colorsA {
+		counts[color]++
+	}
+	for color, count := range counts {
+		if len(candidates[color]) != count {
+			return false
+		}
+		sort.Strings(candidates[color])
+	}
+
+	// map first the blank nodes with the fewest candidates
+	labels := make([]string, 0, len(colorsA))
+	for label := range colorsA {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		ci, cj := counts[colorsA[labels[i]]], counts[colorsA[labels[j]]]
+		if ci != cj {
+			return ci < cj
+		}
+		return labels[i] < labels[j]
+	})
+	m := &blankMatcher{b, colorsA, candidates, blankNeighbours(blankA), make(map[string]string), make(map[string]bool)}
+	return m.search(labels, 0)
+}
+
+// blankComponents splits the triples which contain blank nodes into groups,
+// where two triples are in the same group if they are connected by blank nodes.
+func blankComponents(set tripleSet) []tripleSet {
+	parents := make(map[string]string)
+	var find func(label string) string
+	find = func(label string) string {
+		if parents[label] != label {
+			parents[label] = find(parents[label])
+		}
+		return parents[label]
+	}
+	triples := set.sorted()
+	for _, triple := range triples {
+		labels := blankLabels(triple)
+		for _, label := range labels {
+			if _, known := parents[label]; !known {
+				parents[label] = label
+			}
+			parents[find(label)] = find(labels[0])
+		}
+	}
+	groups := make(map[string]tripleSet)
+	order := make([]string, 0)
+	for _, triple := range triples {
+		if labels := blankLabels(triple); len(labels) > 0 {
+			root := find(labels[0])
+			if _, exists := groups[root]; !exists {
+				groups[root] = make(tripleSet)
+				order = append(order, root)
+			}
+			groups[root][tripleKey(triple)] = triple
+		}
+	}
+	components := make([]tripleSet, 0, len(order))
+	for _, root := range order {
+		components = append(components, groups[root])
+	}
+	return components
+}
+
+// Isomorphic returns True if two graphs contain the same triples, up to the labels of their blank nodes.
+//
+// Two graphs are isomorphic if there is a bijection between their blank nodes which maps the triples
+// of the first graph to the triples of the second one.
+// Duplicated triples are ignored, and the literals are compared as with Literal.Equals.
+// Graph isomorphism reference : https://www.w3.org/TR/rdf11-concepts/#graph-isomorphism
+func Isomorphic(a, b Graph) bool {
+	return isomorphic(readTriples(a), readTriples(b))
+}
+
+// Diff computes the triples which must be added to & removed from the graph a to obtain the graph b.
+//
+// The triples without blank nodes are compared directly. The triples with blank nodes are compared by groups
+// of triples connected by blank nodes, so a group only appears in the diff if it has no isomorphic group in the other graph.
+// The triples are returned in a stable order, and the diff is empty if the graphs are isomorphic.
+func Diff(a, b Graph) (added, removed []rdf.Triple) {
+	setA, setB := readTriples(a), readTriples(b)
+	addedSet, removedSet := make(tripleSet), make(tripleSet)
+	for key, triple := range setA {
+		if _, inB := setB[key]; !inB && !hasBlank(triple) {
+			removedSet[key] = triple
+		}
+	}
+	for key, triple := range setB {
+		if _, inA := setA[key]; !inA && !hasBlank(triple) {
+			addedSet[key] = triple
+		}
+	}
+
+	// match each group of triples with blank nodes with an isomorphic group of the other graph
+	componentsB := blankComponents(setB)
+	matched := make([]bool, len(componentsB))
+	for _, component := range blankComponents(setA) {
+		found := false
+		for i, other := range componentsB {
+			if !matched[i] && len(other) == len(component) && isomorphic(component, other) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			for key, triple := range component {
+				removedSet[key] = triple
+			}
+		}
+	}
+	for i, component := range componentsB {
+		if !matched[i] {
+			for key, triple := range component {
+				addedSet[key] = triple
+			}
+		}
+	}
+	return addedSet.sorted(), removedSet.sorted()
+}
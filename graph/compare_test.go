@@ -0,0 +1,170 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"strconv"
+	"testing"
+)
+
+// cycle creates triples which link blank nodes in a cycle of a given length
+func cycle(prefix string, length int) []rdf.Triple {
+	next := rdf.NewURI("http://example.org/next")
+	triples := make([]rdf.Triple, 0, length)
+	for i := 0; i < length; i++ {
+		triples = append(triples, rdf.NewTriple(rdf.NewBlankNode(prefix+strconv.Itoa(i)), next, rdf.NewBlankNode(prefix+strconv.Itoa((i+1)%length))))
+	}
+	return triples
+}
+
+// newGraphs creates a List Graph & a Tree Graph which contains the same triples
+func newGraphs(triples []rdf.Triple) []Graph {
+	graphs := []Graph{NewListGraph(), NewTreeGraph()}
+	for _, g := range graphs {
+		for _, triple := range triples {
+			g.Add(triple)
+		}
+	}
+	return graphs
+}
+
+func TestIsomorphic(t *testing.T) {
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+	knows := rdf.NewURI("http://example.org/knows")
+	datas := []rdf.Triple{
+		rdf.NewTriple(subj, name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("a")),
+		rdf.NewTriple(rdf.NewBlankNode("a"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(rdf.NewBlankNode("a"), knows, rdf.NewBlankNode("b")),
+		rdf.NewTriple(rdf.NewBlankNode("b"), knows, rdf.NewBlankNode("a")),
+		rdf.NewTriple(subj, rdf.NewURI("http://example.org/age"), rdf.NewTypedLiteral("22", "<http://www.w3.org/2001/XMLSchema#integer>")),
+	}
+	// the same graph, with other labels & a duplicated triple
+	relabeled := []rdf.Triple{
+		rdf.NewTriple(rdf.NewBlankNode("v42"), knows, rdf.NewBlankNode("v17")),
+		rdf.NewTriple(rdf.NewBlankNode("v17"), knows, rdf.NewBlankNode("v42")),
+		rdf.NewTriple(rdf.NewBlankNode("v17"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("v17")),
+		rdf.NewTriple(subj, name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(subj, name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(subj, rdf.NewURI("http://example.org/age"), rdf.NewTypedLiteral("22", "http://www.w3.org/2001/XMLSchema#integer")),
+	}
+	// the blank nodes are swapped
+	swapped := append([]rdf.Triple{}, relabeled...)
+	swapped[2] = rdf.NewTriple(rdf.NewBlankNode("v42"), name, rdf.NewLiteral("Arnaud"))
+
+	for _, a := range newGraphs(datas) {
+		for _, b := range newGraphs(relabeled) {
+			if !Isomorphic(a, b) || !Isomorphic(b, a) {
+				t.Error("expected graphs with different blank nodes labels to be isomorphic")
+			}
+		}
+		for _, b := range newGraphs(swapped) {
+			if Isomorphic(a, b) {
+				t.Error("expected graphs where the blank nodes are not linked in the same way to not be isomorphic")
+			}
+		}
+		for _, b := range newGraphs(datas[1:]) {
+			if Isomorphic(a, b) {
+				t.Error("expected graphs with a different number of triples to not be isomorphic")
+			}
+		}
+	}
+
+	// two cycles of 3 blank nodes cannot be distinguished from a cycle of 6 blank nodes by their neighbourhood
+	triangles := newGraphs(append(cycle("a", 3), cycle("b", 3)...))
+	hexagon := newGraphs(cycle("c", 6))
+	if Isomorphic(triangles[0], hexagon[1]) {
+		t.Error("expected two cycles of 3 blank nodes to not be isomorphic to a cycle of 6 blank nodes")
+	}
+	if !Isomorphic(hexagon[0], newGraphs(cycle("d", 6))[1]) {
+		t.Error("expected two cycles of 6 blank nodes to be isomorphic")
+	}
+}
+
+func TestIsomorphicQuotedTriples(t *testing.T) {
+	name := rdf.NewURI("http://example.org/name")
+	knows := rdf.NewURI("http://example.org/knows")
+	certainty := rdf.NewURI("http://example.org/certainty")
+	quoted := func(subject, object string) rdf.Node {
+		return rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewBlankNode(subject), knows, rdf.NewBlankNode(object)))
+	}
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewBlankNode("a"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(rdf.NewBlankNode("b"), name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(quoted("a", "b"), certainty, rdf.NewLiteral("0.9")),
+	}
+	relabeled := []rdf.Triple{
+		rdf.NewTriple(quoted("x", "y"), certainty, rdf.NewLiteral("0.9")),
+		rdf.NewTriple(rdf.NewBlankNode("y"), name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(rdf.NewBlankNode("x"), name, rdf.NewLiteral("Arnaud")),
+	}
+	// the blank nodes are swapped in the quoted triple
+	swapped := append([]rdf.Triple{}, relabeled...)
+	swapped[0] = rdf.NewTriple(quoted("y", "x"), certainty, rdf.NewLiteral("0.9"))
+
+	for _, a := range newGraphs(datas) {
+		for _, b := range newGraphs(relabeled) {
+			if !Isomorphic(a, b) || !Isomorphic(b, a) {
+				t.Error("expected graphs with different labels in their quoted triples to be isomorphic")
+			}
+			if added, removed := Diff(a, b); len(added) != 0 || len(removed) != 0 {
+				t.Error("expected an empty diff between isomorphic graphs but instead got", added, removed)
+			}
+		}
+		for _, b := range newGraphs(swapped) {
+			if Isomorphic(a, b) {
+				t.Error("expected graphs where the blank nodes of a quoted triple are swapped to not be isomorphic")
+			}
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+	knows := rdf.NewURI("http://example.org/knows")
+	datas := []rdf.Triple{
+		rdf.NewTriple(subj, name, rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("a")),
+		rdf.NewTriple(rdf.NewBlankNode("a"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("b")),
+		rdf.NewTriple(rdf.NewBlankNode("b"), name, rdf.NewLiteral("Pascal")),
+	}
+	modified := []rdf.Triple{
+		rdf.NewTriple(subj, name, rdf.NewLiteral("Minier")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("x")),
+		rdf.NewTriple(rdf.NewBlankNode("x"), name, rdf.NewLiteral("Arnaud")),
+		rdf.NewTriple(subj, knows, rdf.NewBlankNode("y")),
+		rdf.NewTriple(rdf.NewBlankNode("y"), name, rdf.NewLiteral("Hala")),
+	}
+
+	for _, a := range newGraphs(datas) {
+		for _, b := range newGraphs(modified) {
+			added, removed := Diff(a, b)
+			if len(added) != 3 || len(removed) != 3 {
+				t.Fatal("expected 3 triples added & 3 triples removed, but instead got", added, "and", removed)
+			}
+			// the triples are sorted, and those starting with a blank node come first
+			expectedRemoved := []rdf.Triple{datas[4], datas[3], datas[0]}
+			expectedAdded := []rdf.Triple{modified[4], modified[3], modified[0]}
+			for i := range expectedAdded {
+				if test, err := added[i].Equals(expectedAdded[i]); !test || err != nil {
+					t.Error("expected the triple", expectedAdded[i], "to be added, but instead got", added[i])
+				}
+				if test, err := removed[i].Equals(expectedRemoved[i]); !test || err != nil {
+					t.Error("expected the triple", expectedRemoved[i], "to be removed, but instead got", removed[i])
+				}
+			}
+		}
+		for _, b := range newGraphs(datas) {
+			if added, removed := Diff(a, b); len(added) != 0 || len(removed) != 0 {
+				t.Error("expected no difference between isomorphic graphs, but instead got", added, "and", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+#!/bin/bash
+# Vendors the W3C rdf-canon test suite (manifest & rdfc10 test vectors) next to this script,
+# so the canonicalization tests of the graph package run it.
+# Usage: ./vendor.sh [git ref], the commit of the suite is saved in the VERSION file.
+set -e
+REF=${1:-main}
+DIR=$(cd "$(dirname "$0")" && pwd)
+TMP=$(mktemp -d)
+trap 'rm -rf "$TMP"' EXIT
+
+git clone --quiet https://github.com/w3c/rdf-canon.git "$TMP/rdf-canon"
+git -C "$TMP/rdf-canon" checkout --quiet "$REF"
+rm -rf "$DIR/rdfc10"
+cp "$TMP/rdf-canon/tests/manifest.ttl" "$DIR/manifest.ttl"
+cp -r "$TMP/rdf-canon/tests/rdfc10" "$DIR/rdfc10"
+git -C "$TMP/rdf-canon" rev-parse HEAD > "$DIR/VERSION"
+echo "vendored the rdf-canon test suite at $(cat "$DIR/VERSION")"
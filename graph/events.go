@@ -0,0 +1,213 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"sync"
+)
+
+// EventType is the type of a modification of a graph.
+type EventType int
+
+const (
+	// TripleAdded is the type of the events sent when a triple is added to a graph
+	TripleAdded EventType = iota
+	// TripleDeleted is the type of the events sent when a triple is deleted from a graph
+	TripleDeleted
+)
+
+// Event is a modification of a graph, sent to its subscribers.
+type Event struct {
+	Type   EventType
+	Triple rdf.Triple
+	// Transaction identifies the operation which modified the graph: the events produced
+	// by the same call to a method of the graph, or by the commit of the same Tx, share the same Transaction.
+	Transaction uint64
+	// Last is True for the last event of a transaction sent to the subscriber.
+	Last bool
+}
+
+// newEvents creates the events of a modification of the graph.
+func newEvents(eventType EventType, triples ...rdf.Triple) []Event {
+	events := make([]Event, 0, len(triples))
+	for _, triple := range triples {
+		events = append(events, Event{eventType, triple, 0, false})
+	}
+	return events
+}
+
+// Subscription receives the modifications of a graph which match a triple pattern, created with Graph.Subscribe.
+//
+// The events are sent in the order in which the modifications were made.
+// They are queued until the subscriber reads them, so a slow subscriber never blocks the writers of the graph.
+type Subscription struct {
+	pattern  []rdf.Node
+	notifier *notifier
+	events   chan Event
+	queue    []Event
+	closed   bool
+	closing  bool
+	done     chan bool
+	cond     *sync.Cond
+}
+
+// newSubscription creates a new Subscription, and starts sending its events.
+func newSubscription(n *notifier, subject, predicate, object rdf.Node) *Subscription {
+	s := &Subscription{[]rdf.Node{subject, predicate, object}, n, make(chan Event), make([]Event, 0), false, false, make(chan bool), sync.NewCond(&sync.Mutex{})}
+	go s.dispatch()
+	return s
+}
+
+// Events returns the channel in which the events are sent. It is closed when the subscription is cancelled.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Cancel stops the subscription. The events which haven't been read yet are discarded.
+func (s *Subscription) Cancel() {
+	s.notifier.unsubscribe(s)
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	if !s.closed {
+		s.closed = true
+		s.queue = nil
+		close(s.done)
+		s.cond.Signal()
+	}
+}
+
+// Close stops the subscription. Unlike Cancel, the events which haven't been read yet are still sent,
+// then the channel is closed.
+func (s *Subscription) Close() {
+	s.notifier.unsubscribe(s)
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	s.closing = true
+	s.cond.Signal()
+}
+
+// match returns True if a triple matches the pattern of the subscription.
+func (s *Subscription) match(triple rdf.Triple) bool {
+	for i, node := range []rdf.Node{triple.Subject, triple.Predicate, triple.Object} {
+		if _, isVar := s.pattern[i].(rdf.Variable); isVar {
+			continue
+		}
+		if test, err := s.pattern[i].Equals(node); !test || err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// push queues the events of a transaction which match the pattern of the subscription.
+func (s *Subscription) push(transaction uint64, events []Event) {
+	s.cond.L.Lock()
+	defer s.cond.L.Unlock()
+	if s.closed {
+		return
+	}
+	matched := 0
+	for _, event := range events {
+		if s.match(event.Triple) {
+			s.queue = append(s.queue, Event{event.Type, event.Triple, transaction, false})
+			matched++
+		}
+	}
+	if matched > 0 {
+		s.queue[len(s.queue)-1].Last = true
+		s.cond.Signal()
+	}
+}
+
+// dispatch sends the queued events, until the subscription is cancelled.
+func (s *Subscription) dispatch() {
+	defer close(s.events)
+	for {
+		s.cond.L.Lock()
+		for len(s.queue) == 0 && !s.closed && !s.closing {
+			s.cond.Wait()
+		}
+		if s.closed || len(s.queue) == 0 {
+			s.cond.L.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue = s.queue[1:]
+		s.cond.L.Unlock()
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// notifier sends the modifications of a graph to its subscribers.
+//
+// This structure is designed to be embedded into types which implement the Graph interface.
+// Its methods must be called while the graph is locked, so the events are sent in the order of the modifications.
+type notifier struct {
+	subscriptions map[*Subscription]bool
+	transaction   uint64
+	lock          *sync.Mutex
+}
+
+// newNotifier creates a new notifier without any subscriber.
+func newNotifier() *notifier {
+	return &notifier{make(map[*Subscription]bool), 0, &sync.Mutex{}}
+}
+
+// Subscribe registers a new subscriber to the modifications of the graph which match a triple pattern.
+// Use variables to receive the modifications of any subject, predicate or object.
+func (n *notifier) Subscribe(subject, predicate, object rdf.Node) *Subscription {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	s := newSubscription(n, subject, predicate, object)
+	n.subscriptions[s] = true
+	return s
+}
+
+// unsubscribe removes a subscriber.
+func (n *notifier) unsubscribe(s *Subscription) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.subscriptions, s)
+}
+
+// hasSubscribers returns True if someone is listening to the modifications of the graph.
+func (n *notifier) hasSubscribers() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.subscriptions) > 0
+}
+
+// notify sends the events of a modification of the graph to the subscribers.
+func (n *notifier) notify(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.transaction++
+	for s := range n.subscriptions {
+		s.push(n.transaction, events)
+	}
+}
+
+// bitmapEvents creates the events of a modification of the graph, stored as Bitmap Triples.
+// Since they aren't needed, no events are created if nobody is listening to the modifications of the graph.
+func (n *notifier) bitmapEvents(eventType EventType, dict *bimap, bTriples []bitmapTriple) []Event {
+	if len(bTriples) == 0 || !n.hasSubscribers() {
+		return nil
+	}
+	triples := make([]rdf.Triple, 0, len(bTriples))
+	for _, bTriple := range bTriples {
+		triple, err := bTriple.Triple(dict)
+		check(err)
+		triples = append(triples, triple)
+	}
+	return newEvents(eventType, triples...)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// nextEvent reads the next event of a subscription, or fails after a timeout
+func nextEvent(s *Subscription, t *testing.T) Event {
+	select {
+	case event := <-s.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an event to be sent to the subscriber")
+	}
+	return Event{}
+}
+
+// checkEvent checks that an event has the expected type & triple
+func checkEvent(event Event, eventType EventType, triple rdf.Triple, t *testing.T) {
+	if test, err := event.Triple.Equals(triple); event.Type != eventType || !test || err != nil {
+		t.Error("expected an event of type", eventType, "with the triple", triple, "but instead got", event)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+	tripleA := rdf.NewTriple(subj, name, rdf.NewLiteral("Thomas"))
+	tripleB := rdf.NewTriple(subj, rdf.NewURI("http://example.org/age"), rdf.NewLiteral("22"))
+	tripleC := rdf.NewTriple(rdf.NewURI("http://example.org/other"), name, rdf.NewLiteral("Arnaud"))
+
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		everything := g.Subscribe(all[0], all[1], all[2])
+		names := g.Subscribe(all[0], name, all[2])
+
+		g.Add(tripleA)
+		g.AddAll([]rdf.Triple{tripleB, tripleC})
+		g.DeleteTriples([]rdf.Triple{tripleB})
+		g.Delete(subj, all[1], all[2])
+		tx := g.Begin()
+		tx.Add(tripleB)
+		tx.Delete(all[0], name, all[2])
+		tx.Commit()
+
+		// events are sent in the order of the modifications
+		checkEvent(nextEvent(everything, t), TripleAdded, tripleA, t)
+		checkEvent(nextEvent(everything, t), TripleAdded, tripleB, t)
+		checkEvent(nextEvent(everything, t), TripleAdded, tripleC, t)
+		checkEvent(nextEvent(everything, t), TripleDeleted, tripleB, t)
+		checkEvent(nextEvent(everything, t), TripleDeleted, tripleA, t)
+		checkEvent(nextEvent(everything, t), TripleAdded, tripleB, t)
+		checkEvent(nextEvent(everything, t), TripleDeleted, tripleC, t)
+
+		// a subscriber only receives the modifications which match its pattern
+		checkEvent(nextEvent(names, t), TripleAdded, tripleA, t)
+		checkEvent(nextEvent(names, t), TripleAdded, tripleC, t)
+		checkEvent(nextEvent(names, t), TripleDeleted, tripleA, t)
+		checkEvent(nextEvent(names, t), TripleDeleted, tripleC, t)
+
+		// no event is sent once a subscription is cancelled
+		everything.Cancel()
+		names.Cancel()
+		g.Add(tripleA)
+		if _, open := <-everything.Events(); open {
+			t.Error("the channel of a cancelled subscription should be closed")
+		}
+	}
+}
+
+func TestSlowSubscriberDoesntBlockWriters(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+	before := runtime.NumGoroutine()
+
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		s := g.Subscribe(all[0], all[1], all[2])
+		done := make(chan bool)
+		go func() {
+			for i := 0; i < 1000; i++ {
+				g.Add(rdf.NewTriple(subj, subj, rdf.NewLiteral(strconv.Itoa(i))))
+			}
+			done <- true
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("a subscriber which doesn't read its events shouldn't block the writers of the graph")
+		}
+		// the events are kept until the subscriber reads them
+		for i := 0; i < 1000; i++ {
+			checkEvent(nextEvent(s, t), TripleAdded, rdf.NewTriple(subj, subj, rdf.NewLiteral(strconv.Itoa(i))), t)
+		}
+		s.Cancel()
+	}
+
+	if n := waitGoroutines(before); n > before {
+		t.Error("expected the goroutines of the cancelled subscriptions to stop, but", n-before, "goroutines are still running")
+	}
+}
+
+func TestSubscriptionTransactions(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+	triples := []rdf.Triple{
+		rdf.NewTriple(subj, subj, rdf.NewLiteral("1")),
+		rdf.NewTriple(subj, subj, rdf.NewLiteral("2")),
+		rdf.NewTriple(subj, subj, rdf.NewLiteral("3")),
+	}
+
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		s := g.Subscribe(all[0], all[1], all[2])
+		g.Add(triples[0])
+		g.AddAll(triples[1:])
+		tx := g.Begin()
+		tx.Delete(subj, subj, triples[0].Object)
+		tx.Add(triples[0])
+		tx.Commit()
+		// the events queued are still sent after the subscription is closed
+		s.Close()
+		g.Add(rdf.NewTriple(subj, subj, subj))
+
+		events := make([]Event, 0)
+		for event := range s.Events() {
+			events = append(events, event)
+		}
+		if len(events) != 5 {
+			t.Fatal("expected 5 events but instead got", events)
+		}
+		// the events of the same operation share the same transaction, and the last one is marked
+		expectedTx := []int{0, 1, 1, 2, 2}
+		expectedLast := []bool{true, false, true, false, true}
+		for i, event := range events {
+			if (event.Transaction == events[0].Transaction) != (expectedTx[i] == 0) ||
+				(i > 0 && (event.Transaction == events[i-1].Transaction) != (expectedTx[i] == expectedTx[i-1])) {
+				t.Error("the event", event, "doesn't belong to the expected transaction")
+			}
+			if event.Last != expectedLast[i] {
+				t.Error("expected Last to be", expectedLast[i], "for the event", event)
+			}
+		}
+		checkEvent(events[3], TripleDeleted, triples[0], t)
+		checkEvent(events[4], TripleAdded, triples[0], t)
+	}
+}
@@ -6,10 +6,10 @@
 package graph
 
 import (
+	"context"
 	"errors"
 	"github.com/Callidon/joseki/parser"
 	"github.com/Callidon/joseki/rdf"
-	"strings"
 )
 
 const (
@@ -27,9 +27,56 @@ type Graph interface {
 	// Delete triples from the graph that match a BGP given in parameters.
 	Delete(subject, predicate, object rdf.Node)
 	// Fetch triples form the graph that match a BGP given in parameters.
+	//
+	// The channel must be consumed until it is closed, otherwise the goroutines feeding it are leaked.
+	// Use FilterContext to stop a query before its end.
 	Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple
 	// Same as Filter, but with a Limit and an Offset
 	FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple
+	// Same as Filter, but stops sending triples & closes the channel when the context is cancelled.
+	FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple
+	// Same as FilterSubset, but stops sending triples & closes the channel when the context is cancelled.
+	FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple
+	// Add several triples to the graph, and returns the number of triples which weren't already in the graph.
+	AddAll(triples []rdf.Triple) int
+	// Same as AddAll, but reads the triples from a channel until it is closed.
+	AddFrom(triples <-chan rdf.Triple) int
+	// Delete several triples from the graph, and returns the number of triples removed.
+	DeleteTriples(triples []rdf.Triple) int
+	// Begin starts a new transaction on the graph.
+	Begin() *Tx
+	// Snapshot returns an immutable view of the graph in its current state.
+	Snapshot() Snapshot
+	// Subscribe registers a new subscriber to the modifications of the graph which match a triple pattern.
+	Subscribe(subject, predicate, object rdf.Node) *Subscription
+}
+
+// BlankNodePolicy defines how the blank nodes of a file are handled when the file is loaded into a graph.
+type BlankNodePolicy int
+
+const (
+	// ScopeBlankNodes relabels the blank nodes of each file with new labels, so the blank nodes of two files never collide.
+	// This is the default policy.
+	ScopeBlankNodes BlankNodePolicy = iota
+	// KeepBlankNodes keeps the labels of the blank nodes read in the file.
+	KeepBlankNodes
+	// SkolemizeBlankNodes replaces the blank nodes of each file with new skolem IRIs, minted under a /.well-known/genid/ path.
+	SkolemizeBlankNodes
+)
+
+// LoadOptions configures how the triples of a file are loaded into a graph.
+type LoadOptions struct {
+	// Format of the file. If it is empty, it is inferred from the extension of the file, or else from its content.
+	Format string
+	// Base IRI used to resolve the relative IRIs of the file.
+	Base string
+	// BlankNodes defines how the blank nodes of the file are handled.
+	BlankNodes BlankNodePolicy
+	// SkolemAuthority is the authority under which the skolem IRIs are minted, e.g. http://example.org.
+	// It is required by the SkolemizeBlankNodes policy.
+	SkolemAuthority string
+	// Deskolemize replaces the skolem IRIs of the file with blank nodes, before applying the blank node policy.
+	Deskolemize bool
 }
 
 // rdfReader represents a reader capable of reading RDF data encoded in various format.
@@ -39,40 +86,137 @@ type rdfReader struct {
 	graph Graph
 	// list of prefixes used in some RDF formats (Turtle, JSON-LD, ...)
 	prefixes map[string]string
+	// base IRI used to resolve the relative IRIs of the files
+	base string
+	// generator of the labels of the blank nodes read in the files, shared between all the loads
+	bnodes *rdf.BlankNodeGenerator
+}
+
+// dictionaryGraph is a graph which can tell if a node is in its dictionnary.
+type dictionaryGraph interface {
+	hasNode(node rdf.Node) bool
 }
 
-// newRDFReader creates a new rdfReader
+// newRDFReader creates a new rdfReader.
+// The blank nodes read in the files are labelled b0, b1, etc, in the order of the loads,
+// and the labels already in the dictionnary of the graph are skipped, so they don't collide with the blank nodes added directly to the graph.
 func newRDFReader() *rdfReader {
-	return &rdfReader{nil, nil}
+	r := &rdfReader{nil, nil, "", nil}
+	r.bnodes = rdf.NewFreeBlankNodeGenerator("b", func(node rdf.BlankNode) bool {
+		g, isDictionary := r.graph.(dictionaryGraph)
+		return isDictionary && g.hasNode(node)
+	})
+	return r
 }
 
-// LoadFromFile loads triples from a file into a graph, with a given format
+// LoadFromFile loads triples from a file into a graph, with a given format.
+// If the format is empty, it is inferred from the extension of the file, or else from its content.
+// Files compressed with gzip, bzip2 or zlib (e.g. data.nt.gz) are decompressed on the fly.
 // In the desired format isn't supported or doesn't exist, no new triples will
 // be inserted into the graph and an error will be returned.
+//
+// Formats are looked up in the registry of the parser package, so new formats can be registered with parser.RegisterFormat.
+// The blank nodes of the file are relabelled, so they never collide with the blank nodes of the files previously loaded.
 func (r *rdfReader) LoadFromFile(filename string, format string) error {
-	var p parser.Parser
-	hasPrefixes := false
+	return r.LoadWithOptions(filename, LoadOptions{format, r.base, ScopeBlankNodes, "", false})
+}
+
+// LoadWithOptions loads triples from a file into a graph, as LoadFromFile, using a set of options.
+func (r *rdfReader) LoadWithOptions(filename string, options LoadOptions) error {
+	var f parser.Format
+	var err error
 	// determine which parser to use depending on the format
-	switch strings.ToLower(format) {
-	case "nt", "n-triples", "ntriples":
-		p = parser.NewNTParser()
-	case "ttl", "turtle":
-		p = parser.NewTurtleParser()
-		hasPrefixes = true
-	default:
-		return errors.New("Error : " + format + " is not a supported format." +
-			"Please see the documentation at https://godoc.org/github.com/Callidon/joseki/parser to see the available parsers.")
+	if options.Format == "" {
+		if f, err = parser.DetectFormat(filename); err != nil {
+			return err
+		}
+	} else if f, err = formatByName(options.Format); err != nil {
+		return err
 	}
-	// read triples from file, then load prefixes if necessary
+	if options.BlankNodes == SkolemizeBlankNodes && options.SkolemAuthority == "" {
+		return errors.New("Error : an authority is required to skolemize the blank nodes")
+	}
+	p := f.New()
+	if setter, isSetter := p.(parser.BaseSetter); isSetter && options.Base != "" {
+		setter.SetBase(options.Base)
+	}
+	// the labels of the blank nodes are only meaningful inside the file
+	scope := rdf.NewBlankNodeScope(r.bnodes)
+	// read triples from file, then load prefixes
 	for triple := range p.Read(filename) {
+		if options.Deskolemize {
+			triple = rdf.DeskolemizeTriple(triple)
+		}
+		switch options.BlankNodes {
+		case ScopeBlankNodes:
+			triple = scope.Triple(triple)
+		case SkolemizeBlankNodes:
+			triple = rdf.SkolemizeTriple(scope.Triple(triple), options.SkolemAuthority)
+		}
 		r.graph.Add(triple)
 	}
-	if hasPrefixes {
-		r.prefixes = p.Prefixes()
-	}
+	r.prefixes = p.Prefixes()
 	return nil
 }
 
+// formatByName returns the registered format with a given name.
+func formatByName(name string) (parser.Format, error) {
+	f, found := parser.FormatByName(name)
+	if !found {
+		return f, errors.New("Error : " + name + " is not a supported format." +
+			"Please see the documentation at https://godoc.org/github.com/Callidon/joseki/parser to see the available parsers.")
+	}
+	return f, nil
+}
+
+// addFrom reads triples from a channel & inserts them into a graph by batches,
+// so the graph isn't locked while waiting for the next triples.
+func addFrom(g Graph, triples <-chan rdf.Triple) int {
+	added := 0
+	batch := make([]rdf.Triple, 0, bufferSize)
+	for triple := range triples {
+		batch = append(batch, triple)
+		if len(batch) == bufferSize {
+			added += g.AddAll(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		added += g.AddAll(batch)
+	}
+	return added
+}
+
+// quotedPattern prepares a triple pattern whose quoted triples contain variables, e.g. << ?s :knows :bob >> ?p ?o .
+// These quoted triples cannot be located in the dictionnary, so they are replaced by variables in the new pattern,
+// and the triples which match the new pattern must then be checked with the returned function, which is nil if not needed.
+func quotedPattern(subject, predicate, object rdf.Node) ([]rdf.Node, func(rdf.Triple) bool) {
+	pattern := []rdf.Node{subject, predicate, object}
+	found := false
+	for i, node := range pattern {
+		if _, isQuoted := node.(rdf.QuotedTriple); isQuoted && rdf.IsPattern(node) {
+			pattern[i] = rdf.NewVariable("quoted")
+			found = true
+		}
+	}
+	if !found {
+		return pattern, nil
+	}
+	return pattern, func(triple rdf.Triple) bool {
+		return rdf.Matches(subject, triple.Subject) && rdf.Matches(predicate, triple.Predicate) && rdf.Matches(object, triple.Object)
+	}
+}
+
+// Prefixes returns the prefixes read in the last file loaded into the graph, if its format supports them.
+func (r *rdfReader) Prefixes() map[string]string {
+	return r.prefixes
+}
+
+// SetBase sets the base IRI used to resolve the relative IRIs of the files loaded with LoadFromFile.
+func (r *rdfReader) SetBase(base string) {
+	r.base = base
+}
+
 // Utility function for checking errors
 func check(err error) {
 	if err != nil {
@@ -0,0 +1,259 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+	"github.com/Callidon/joseki/rdf"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitGoroutines waits for the number of running goroutines to drop to a given value, then returns it
+func waitGoroutines(expected int) int {
+	for i := 0; i < 200; i++ {
+		if n := runtime.NumGoroutine(); n <= expected {
+			return n
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return runtime.NumGoroutine()
+}
+
+func TestFilterContext(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	graphs := txGraphs(1000)
+	before := runtime.NumGoroutine()
+
+	for _, g := range graphs {
+		// cancel a query after reading a few triples, without consuming the rest of the channel
+		ctx, cancel := context.WithCancel(context.Background())
+		results := g.FilterContext(ctx, all[0], all[1], all[2])
+		for i := 0; i < 5; i++ {
+			<-results
+		}
+		cancel()
+		if n := waitGoroutines(before); n > before {
+			t.Error("expected the goroutines of a cancelled query to stop, but", n-before, "goroutines are still running")
+		}
+
+		// the same with a snapshot & a query with a limit
+		snapshot := g.Snapshot()
+		ctx, cancel = context.WithCancel(context.Background())
+		results = snapshot.FilterSubsetContext(ctx, all[0], all[1], all[2], 500, 10)
+		<-results
+		cancel()
+		snapshot.Release()
+		if n := waitGoroutines(before); n > before {
+			t.Error("expected the goroutines of a cancelled query to stop, but", n-before, "goroutines are still running")
+		}
+
+		// a query with a cancelled context doesn't send all the triples
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		if cpt := countTriples(g.FilterContext(ctx, all[0], all[1], all[2])); cpt >= 1000 {
+			t.Error("a cancelled query shouldn't send all the triples of the graph")
+		}
+
+		// a query which isn't cancelled sends all the triples
+		if cpt := countTriples(g.FilterContext(context.Background(), all[0], all[1], all[2])); cpt != 1000 {
+			t.Error("expected 1000 results but instead got", cpt, "results")
+		}
+	}
+
+	// the tree isn't pinned anymore by the cancelled queries
+	tree := graphs[1].(*TreeGraph)
+	if snapshots := atomic.LoadInt64(&tree.snapshots); snapshots != 0 {
+		t.Error("expected no query to be reading the graph, but instead got", snapshots)
+	}
+}
+
+func TestFilterSubsetStopsAtLimit(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	graphs := txGraphs(1000)
+	before := runtime.NumGoroutine()
+
+	for _, g := range graphs {
+		if cpt := countTriples(g.FilterSubset(all[0], all[1], all[2], 99, 0)); cpt != 99 {
+			t.Error("expected 99 results but instead got", cpt, "results")
+		}
+		if cpt := countTriples(g.FilterSubset(all[0], all[1], all[2], 99, 950)); cpt != 50 {
+			t.Error("expected 50 results after the offset but instead got", cpt, "results")
+		}
+		if cpt := countTriples(g.FilterSubset(all[0], all[1], all[2], 0, 0)); cpt != 0 {
+			t.Error("expected no result with a limit of 0 but instead got", cpt, "results")
+		}
+		if n := waitGoroutines(before); n > before {
+			t.Error("expected the goroutines of a query to stop once the limit is reached, but", n-before, "goroutines are still running")
+		}
+	}
+}
+
+func TestLoadFromFileWithBase(t *testing.T) {
+	list, tree := NewListGraph(), NewTreeGraph()
+	list.SetBase("http://example.com/ignored/")
+	tree.SetBase("http://example.com/ignored/")
+	list.LoadFromFile("../parser/datas/base.ttl", "turtle")
+	tree.LoadFromFile("../parser/datas/base.ttl", "turtle")
+	alice := rdf.NewURI("http://example.org/people/#alice")
+	for _, g := range []Graph{list, tree} {
+		if cpt := countTriples(g.Filter(alice, rdf.NewVariable("p"), rdf.NewVariable("o"))); cpt != 3 {
+			t.Error("expected 3 triples about", alice, "but instead got", cpt)
+		}
+	}
+}
+
+func TestLoadWithOptionsBlankNodes(t *testing.T) {
+	all := rdf.NewVariable("v")
+	datas := []struct {
+		options  LoadOptions
+		expected int
+	}{
+		{LoadOptions{"turtle", "", ScopeBlankNodes, "", false}, 9},
+		{LoadOptions{"turtle", "", KeepBlankNodes, "", false}, 6},
+		{LoadOptions{"turtle", "", SkolemizeBlankNodes, "http://example.org", false}, 9},
+	}
+	for _, data := range datas {
+		g := NewTreeGraph()
+		// the blank nodes of the two loads must only be merged if their labels are kept
+		for i := 0; i < 2; i++ {
+			if err := g.LoadWithOptions("../parser/datas/test.ttl", data.options); err != nil {
+				t.Fatal("loading a file shouldn't produce the error :", err)
+			}
+		}
+		if cpt := countTriples(g.Filter(all, all, all)); cpt != data.expected {
+			t.Error("expected", data.expected, "triples with the policy", data.options.BlankNodes, "but instead got", cpt)
+		}
+		if data.options.BlankNodes == SkolemizeBlankNodes {
+			skolems := 0
+			for triple := range g.Filter(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"), all, all) {
+				if object, isURI := triple.Object.(rdf.URI); isURI && strings.HasPrefix(object.Value, "http://example.org/.well-known/genid/") {
+					skolems++
+				}
+			}
+			// each load mints its own skolem IRIs
+			if skolems != 4 {
+				t.Error("expected the blank nodes to be replaced with 4 skolem IRIs, but instead got", skolems)
+			}
+		}
+	}
+
+	g := NewTreeGraph()
+	if err := g.LoadWithOptions("../parser/datas/test.ttl", LoadOptions{"turtle", "", SkolemizeBlankNodes, "", false}); err == nil {
+		t.Error("skolemizing the blank nodes without an authority should produce an error")
+	}
+	// the skolem IRIs are replaced with blank nodes, which are then scoped
+	if err := g.LoadWithOptions("datas/skolem.nt", LoadOptions{"nt", "", ScopeBlankNodes, "", true}); err != nil {
+		t.Fatal("loading a file shouldn't produce the error :", err)
+	}
+	knows := rdf.NewURI("http://xmlns.com/foaf/0.1/knows")
+	expected := NewListGraph()
+	expected.Add(rdf.NewTriple(rdf.NewBlankNode("a"), knows, rdf.NewBlankNode("b")))
+	if !Isomorphic(g, expected) {
+		t.Error("expected the skolem IRIs to be replaced with two blank nodes")
+	}
+	// the blank nodes read in the files don't collide with the blank nodes already in the graph
+	list, tree := NewListGraph(), NewTreeGraph()
+	list.Add(rdf.NewTriple(rdf.NewBlankNode("b0"), knows, rdf.NewBlankNode("b1")))
+	tree.Add(rdf.NewTriple(rdf.NewBlankNode("b0"), knows, rdf.NewBlankNode("b1")))
+	list.LoadWithOptions("datas/skolem.nt", LoadOptions{"nt", "", ScopeBlankNodes, "", true})
+	tree.LoadWithOptions("datas/skolem.nt", LoadOptions{"nt", "", ScopeBlankNodes, "", true})
+	for _, other := range []Graph{list, tree} {
+		expected := NewListGraph()
+		expected.Add(rdf.NewTriple(rdf.NewBlankNode("a"), knows, rdf.NewBlankNode("b")))
+		expected.Add(rdf.NewTriple(rdf.NewBlankNode("c"), knows, rdf.NewBlankNode("d")))
+		if !Isomorphic(other, expected) {
+			t.Error("expected the blank nodes already in the graph to not be merged with the blank nodes read in the files")
+		}
+	}
+}
+
+func TestLoadFromFileDeterministicLabels(t *testing.T) {
+	// the same document loaded into two graphs gives the same labels
+	all := rdf.NewVariable("v")
+	first, second := NewListGraph(), NewTreeGraph()
+	first.LoadFromFile("../parser/datas/test.ttl", "turtle")
+	second.LoadFromFile("../parser/datas/test.ttl", "turtle")
+	labels := func(g Graph) []string {
+		values := make([]string, 0)
+		for _, triple := range readTriples(g).sorted() {
+			if blank, isBlank := triple.Object.(rdf.BlankNode); isBlank {
+				values = append(values, blank.Value)
+			}
+		}
+		return values
+	}
+	a, b := labels(first), labels(second)
+	if len(a) != 2 || strings.Join(a, " ") != strings.Join(b, " ") {
+		t.Error("expected the same labels in both graphs, but instead got", a, "and", b)
+	}
+	if cpt := countTriples(first.Filter(all, all, rdf.NewBlankNode("b0"))); cpt != 1 {
+		t.Error("expected the first blank node of the document to be labelled b0, but found", cpt, "triples with it")
+	}
+}
+
+func TestFilterEqualLiterals(t *testing.T) {
+	subj, pred, v := rdf.NewURI("http://example.org/s"), rdf.NewURI("http://example.org/p"), rdf.NewVariable("v")
+	list, tree := NewListGraph(), NewTreeGraph()
+	list.LoadFromFile("datas/typed.nt", "nt")
+	tree.LoadFromFile("datas/typed.nt", "nt")
+	for _, g := range []Graph{list, tree} {
+		if cpt := countTriples(g.Filter(v, v, rdf.NewTypedLiteral("1", rdf.XSDInteger))); cpt != 1 {
+			t.Error("expected 1 triple with a xsd:integer read in a file but instead got", cpt)
+		}
+		if cpt := countTriples(g.Filter(v, v, rdf.NewLangLiteral("a", "en"))); cpt != 1 {
+			t.Error("expected the languages of the literals to be normalized but instead got", cpt, "triples")
+		}
+		// the literals which are equals are the same node of the graph, even if they aren't built with the same constructor
+		g.Add(rdf.NewTriple(subj, pred, rdf.Literal{Value: "1", Type: "<" + rdf.XSDInteger + ">"}))
+		g.Add(rdf.NewTriple(subj, pred, rdf.Literal{Value: "a", Lang: "EN"}))
+		g.Add(rdf.NewTriple(subj, pred, rdf.Literal{Value: "b", Type: rdf.XSDString}))
+		g.Add(rdf.NewTriple(subj, pred, rdf.NewLiteral("b")))
+		if cpt := countTriples(g.Filter(v, v, v)); cpt != 3 {
+			t.Error("expected the equal literals to be merged into 3 triples but instead got", cpt)
+		}
+	}
+}
+
+func TestFilterQuotedTriples(t *testing.T) {
+	alice, knows, bob := rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://example.org/knows"), rdf.NewURI("http://example.org/bob")
+	source := rdf.NewURI("http://example.org/source")
+	v := rdf.NewVariable("v")
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		g.Add(rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(alice, knows, bob)), source, rdf.NewLiteral("facebook")))
+		g.Add(rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(bob, knows, alice)), source, rdf.NewLiteral("twitter")))
+		g.Add(rdf.NewTriple(alice, knows, bob))
+
+		datas := []struct {
+			subject  rdf.Node
+			expected int
+		}{
+			{v, 3},
+			{rdf.NewQuotedTriple(rdf.NewTriple(alice, knows, bob)), 1},
+			{rdf.NewQuotedTriple(rdf.NewTriple(v, knows, rdf.NewVariable("w"))), 2},
+			{rdf.NewQuotedTriple(rdf.NewTriple(v, knows, alice)), 1},
+			{rdf.NewQuotedTriple(rdf.NewTriple(v, source, alice)), 0},
+			{rdf.NewQuotedTriple(rdf.NewTriple(bob, source, alice)), 0},
+		}
+		for _, data := range datas {
+			if cpt := countTriples(g.Filter(data.subject, v, v)); cpt != data.expected {
+				t.Error("expected", data.expected, "triples matching", data.subject, "but instead got", cpt)
+			}
+		}
+		// the limit & the offset only apply to the triples matching the quoted triples
+		pattern := rdf.NewQuotedTriple(rdf.NewTriple(v, knows, rdf.NewVariable("w")))
+		for triple := range g.FilterSubset(pattern, v, v, -1, 1) {
+			if !rdf.Matches(pattern, triple.Subject) {
+				t.Error(triple, "doesn't match the pattern", pattern)
+			}
+		}
+		if cpt := countTriples(g.FilterSubset(pattern, v, v, -1, 1)); cpt != 1 {
+			t.Error("expected 1 triple after the offset but instead got", cpt)
+		}
+	}
+}
@@ -5,23 +5,30 @@
 package graph
 
 import (
+	"context"
 	"github.com/Callidon/joseki/rdf"
 	"sync"
 )
 
 // ListGraph is implementation of a RDF Graph, using a slice to store RDF Triples.
+//
+// The slice is never modified in place: triples are appended to it, and deletions create a new slice.
+// So readers can query a version of the slice without blocking the writers.
+// An index of the triples in the slice ensures that a triple is never added twice.
 type ListGraph struct {
 	dictionnary *bimap
 	triples     []bitmapTriple
+	index       map[bitmapTriple]bool
 	nextID      int
 	*sync.RWMutex
 	*rdfReader
+	*notifier
 }
 
 // NewListGraph creates a new List Graph.
 func NewListGraph() *ListGraph {
 	reader := newRDFReader()
-	g := &ListGraph{newBimap(), make([]bitmapTriple, 0), 0, &sync.RWMutex{}, reader}
+	g := &ListGraph{newBimap(), make([]bitmapTriple, 0), make(map[bitmapTriple]bool), 0, &sync.RWMutex{}, reader, newNotifier()}
 	reader.graph = g
 	return g
 }
@@ -47,13 +54,117 @@ func (g *ListGraph) identifyNode(node rdf.Node) (int, bool) {
 	return g.dictionnary.locate(node)
 }
 
+// hasNode returns True if a node is in the graph dictionnary.
+func (g *ListGraph) hasNode(node rdf.Node) bool {
+	g.RLock()
+	defer g.RUnlock()
+	_, inDict := g.dictionnary.locate(node)
+	return inDict
+}
+
+// insert appends a triple to the slice, and returns False if it was already in it.
+// The graph must be locked by the caller.
+func (g *ListGraph) insert(triple rdf.Triple) bool {
+	// add each node of the triple to the dictionnary & then update the slice
+	subjID, predID, objID := g.registerNode(triple.Subject), g.registerNode(triple.Predicate), g.registerNode(triple.Object)
+	bTriple := newBitmapTriple(subjID, predID, objID)
+	if g.index[bTriple] {
+		return false
+	}
+	g.triples = append(g.triples, bTriple)
+	g.index[bTriple] = true
+	return true
+}
+
 // Add a new Triple pattern to the graph.
 func (g *ListGraph) Add(triple rdf.Triple) {
 	g.Lock()
 	defer g.Unlock()
-	// add each node of the triple to the dictionnary & then update the slice
-	subjID, predID, objID := g.registerNode(triple.Subject), g.registerNode(triple.Predicate), g.registerNode(triple.Object)
-	g.triples = append(g.triples, newBitmapTriple(subjID, predID, objID))
+	if g.insert(triple) {
+		g.notify(newEvents(TripleAdded, triple))
+	}
+}
+
+// AddAll adds several triples to the graph, and returns the number of triples which weren't already in the graph.
+func (g *ListGraph) AddAll(triples []rdf.Triple) int {
+	g.Lock()
+	defer g.Unlock()
+	added := make([]rdf.Triple, 0)
+	for _, triple := range triples {
+		if g.insert(triple) {
+			added = append(added, triple)
+		}
+	}
+	g.notify(newEvents(TripleAdded, added...))
+	return len(added)
+}
+
+// AddFrom adds the triples read from a channel to the graph, until the channel is closed.
+// It returns the number of triples which weren't already in the graph.
+func (g *ListGraph) AddFrom(triples <-chan rdf.Triple) int {
+	return addFrom(g, triples)
+}
+
+// remove removes several triples from the slice, and returns the triples removed.
+// The graph must be locked by the caller.
+func (g *ListGraph) remove(triples []rdf.Triple) []bitmapTriple {
+	toDelete := make(map[bitmapTriple]bool, len(triples))
+	for _, triple := range triples {
+		subjID, subjKnown := g.dictionnary.locate(triple.Subject)
+		predID, predKnown := g.dictionnary.locate(triple.Predicate)
+		objID, objKnown := g.dictionnary.locate(triple.Object)
+		if bTriple := newBitmapTriple(subjID, predID, objID); subjKnown && predKnown && objKnown && g.index[bTriple] {
+			toDelete[bTriple] = true
+		}
+	}
+	removed := make([]bitmapTriple, 0)
+	if len(toDelete) == 0 {
+		return removed
+	}
+	newTriples := make([]bitmapTriple, 0, len(g.triples))
+	for _, triple := range g.triples {
+		if toDelete[triple] {
+			removed = append(removed, triple)
+			delete(g.index, triple)
+		} else {
+			newTriples = append(newTriples, triple)
+		}
+	}
+	g.triples = newTriples
+	return removed
+}
+
+// DeleteTriples deletes several triples from the graph, and returns the number of triples removed.
+func (g *ListGraph) DeleteTriples(triples []rdf.Triple) int {
+	g.Lock()
+	defer g.Unlock()
+	removed := g.remove(triples)
+	g.notify(g.bitmapEvents(TripleDeleted, g.dictionnary, removed))
+	return len(removed)
+}
+
+// Begin starts a new transaction on the graph.
+func (g *ListGraph) Begin() *Tx {
+	return newTx(g)
+}
+
+// commit applies the operations of a transaction to the graph.
+func (g *ListGraph) commit(operations []txOperation) {
+	g.Lock()
+	defer g.Unlock()
+	events := make([]Event, 0)
+	for _, op := range operations {
+		if op.deletion {
+			events = append(events, g.bitmapEvents(TripleDeleted, g.dictionnary, g.remove(op.triples))...)
+		} else {
+			for _, triple := range op.triples {
+				if g.insert(triple) {
+					events = append(events, Event{TripleAdded, triple, 0, false})
+				}
+			}
+		}
+	}
+	g.notify(events)
 }
 
 // Delete triples from the graph that match a BGP given in parameters.
@@ -67,56 +178,103 @@ func (g *ListGraph) Delete(subject, predicate, object rdf.Node) {
 	// continue onyl if we know all elements of the pattern
 	if subjKnown && predKnown && objKnown {
 		refTriple := newBitmapTriple(subjID, predID, objID)
+		removed := make([]bitmapTriple, 0)
 		// resinsert into the graph the elements we doesn't want to delete
 		for _, triple := range g.triples {
 			if test := triple.Equals(refTriple); !test {
 				newTriples = append(newTriples, triple)
+			} else {
+				removed = append(removed, triple)
+				delete(g.index, triple)
 			}
 		}
 		// update the slice
 		g.triples = make([]bitmapTriple, len(newTriples))
 		copy(g.triples, newTriples)
+		g.notify(g.bitmapEvents(TripleDeleted, g.dictionnary, removed))
 	}
 }
 
-// FilterSubset fetch triples form the graph that match a BGP given in parameters.
-// It impose a Limit(the max number of results to be send in the output channel)
-// and an Offset (the number of results to skip before sending them in the output channel) to the nodes requested.
-func (g *ListGraph) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+// Snapshot returns an immutable view of the graph in its current state.
+func (g *ListGraph) Snapshot() Snapshot {
+	g.RLock()
+	defer g.RUnlock()
+	return &listSnapshot{g, g.triples, false, &sync.Mutex{}}
+}
+
+// filterTriples fetch triples from a version of the slice that match a BGP given in parameters, with a Limit and an Offset.
+func (g *ListGraph) filterTriples(ctx context.Context, triples []bitmapTriple, subject, predicate, object rdf.Node, limit, offset int) <-chan rdf.Triple {
 	results := make(chan rdf.Triple, bufferSize)
 	// search for matching triple pattern in graph
 	go func() {
-		g.RLock()
-		defer g.RUnlock()
 		defer close(results)
-		subjID, subjKnown := g.identifyNode(subject)
-		predID, predKnown := g.identifyNode(predicate)
-		objID, objKnown := g.identifyNode(object)
+		pattern, matches := quotedPattern(subject, predicate, object)
+		g.RLock()
+		subjID, subjKnown := g.identifyNode(pattern[0])
+		predID, predKnown := g.identifyNode(pattern[1])
+		objID, objKnown := g.identifyNode(pattern[2])
+		g.RUnlock()
 		// continue onyl if we know all elements of the pattern
 		if subjKnown && predKnown && objKnown {
 			refTriple := newBitmapTriple(subjID, predID, objID)
 			cpt := 0
-			for _, triple := range g.triples {
+			if offset < 0 {
+				offset = 0
+			}
+			for _, triple := range triples {
+				// terminate the loop when the limit has been reached
+				if limit != -1 && cpt-offset >= limit {
+					break
+				}
 				if test := refTriple.Equals(triple); test {
+					g.RLock()
+					value, err := triple.Triple(g.dictionnary)
+					g.RUnlock()
+					check(err)
+					// the quoted triples of the pattern can only be matched once the triple is decoded
+					if matches != nil && !matches(value) {
+						continue
+					}
 					// send the result only if the offset has been reached
 					if cpt >= offset {
-						value, err := triple.Triple(g.dictionnary)
-						check(err)
-						results <- value
+						select {
+						case results <- value:
+						case <-ctx.Done():
+							return
+						}
 					}
 					cpt++
 				}
-				// terminate the loop when the limit has been reached
-				if (limit != -1) && (cpt-offset > limit) {
-					break
-				}
 			}
 		}
 	}()
 	return results
 }
 
+// FilterSubset fetch triples form the graph that match a BGP given in parameters.
+// It impose a Limit(the max number of results to be send in the output channel)
+// and an Offset (the number of results to skip before sending them in the output channel) to the nodes requested.
+//
+// The triples are read from the version of the graph at the time of the call,
+// so the graph can be modified while the results are consumed.
+func (g *ListGraph) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	return g.FilterSubsetContext(context.Background(), subject, predicate, object, limit, offset)
+}
+
 // Filter fetch triples form the graph that match a BGP given in parameters.
 func (g *ListGraph) Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple {
 	return g.FilterSubset(subject, predicate, object, -1, 0)
 }
+
+// FilterSubsetContext is the same as FilterSubset, but stops sending triples & closes the channel when the context is cancelled.
+func (g *ListGraph) FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	g.RLock()
+	triples := g.triples
+	g.RUnlock()
+	return g.filterTriples(ctx, triples, subject, predicate, object, limit, offset)
+}
+
+// FilterContext is the same as Filter, but stops sending triples & closes the channel when the context is cancelled.
+func (g *ListGraph) FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return g.FilterSubsetContext(ctx, subject, predicate, object, -1, 0)
+}
@@ -7,6 +7,7 @@ package graph
 import (
 	"github.com/Callidon/joseki/rdf"
 	"math/rand"
+	"strconv"
 	"testing"
 )
 
@@ -21,6 +22,17 @@ func TestAddListGraph(t *testing.T) {
 	if test, err := graphTriple.Equals(triple); !test && (err != nil) {
 		t.Error(triple, "hasn't been inserted into the graph")
 	}
+
+	// a triple is never added twice, as with AddAll, but it can be added again once deleted
+	graph.Add(triple)
+	if len(graph.triples) != 1 {
+		t.Error("expected the graph to contain 1 triple but instead it contains", len(graph.triples))
+	}
+	graph.Delete(subj, pred, obj)
+	graph.Add(triple)
+	if len(graph.triples) != 1 || graph.AddAll([]rdf.Triple{triple}) != 0 {
+		t.Error("expected a deleted triple to be added again, only once")
+	}
 }
 
 func TestFilterListGraph(t *testing.T) {
@@ -139,7 +151,7 @@ func TestDeleteListGraph(t *testing.T) {
 
 	// insert random triples in the graph
 	for i := 0; i < nbDatas; i++ {
-		triple = rdf.NewTriple(subj, rdf.NewURI(string(rand.Intn(nbDatas))), rdf.NewLiteral(string(rand.Intn(nbDatas))))
+		triple = rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i)), rdf.NewLiteral(strconv.Itoa(rand.Intn(nbDatas))))
 		graph.Add(triple)
 	}
 
@@ -167,6 +179,79 @@ func TestDeleteListGraph(t *testing.T) {
 	}
 }
 
+func TestAddAllListGraph(t *testing.T) {
+	graph := NewListGraph()
+	subj := rdf.NewURI("http://example.org/subject")
+	triples := make([]rdf.Triple, 0)
+	for i := 0; i < 100; i++ {
+		triples = append(triples, rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i%10)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+	if added := graph.AddAll(triples); added != 100 {
+		t.Error("expected 100 triples to be added but instead got", added)
+	}
+	// the triples already in the graph aren't inserted twice
+	if added := graph.AddAll(triples[:50]); added != 0 {
+		t.Error("expected no triple to be added but instead got", added)
+	}
+	duplicates := []rdf.Triple{triples[0], rdf.NewTriple(subj, subj, subj), rdf.NewTriple(subj, subj, subj)}
+	if added := graph.AddAll(duplicates); added != 1 {
+		t.Error("expected 1 triple to be added but instead got", added)
+	}
+
+	// add triples from a channel
+	input := make(chan rdf.Triple)
+	go func() {
+		defer close(input)
+		for i := 0; i < 2*bufferSize; i++ {
+			input <- rdf.NewTriple(rdf.NewURI("http://example.org/s"+strconv.Itoa(i)), subj, subj)
+		}
+		input <- triples[0]
+	}()
+	if added := graph.AddFrom(input); added != 2*bufferSize {
+		t.Error("expected", 2*bufferSize, "triples to be added but instead got", added)
+	}
+
+	cpt := 0
+	for _ = range graph.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		cpt++
+	}
+	if cpt != 101+2*bufferSize {
+		t.Error("the graph should contains", 101+2*bufferSize, "triples, but it contains", cpt, "triples")
+	}
+}
+
+func TestDeleteTriplesListGraph(t *testing.T) {
+	graph := NewListGraph()
+	subj := rdf.NewURI("http://example.org/subject")
+	triples := make([]rdf.Triple, 0)
+	for i := 0; i < 100; i++ {
+		triples = append(triples, rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i%10)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+	graph.AddAll(triples)
+
+	// triples which aren't in the graph are ignored
+	toDelete := append(triples[:40:40], rdf.NewTriple(subj, subj, subj), rdf.NewTriple(subj, triples[51].Predicate, triples[60].Object))
+	if removed := graph.DeleteTriples(toDelete); removed != 40 {
+		t.Error("expected 40 triples to be removed but instead got", removed)
+	}
+	if removed := graph.DeleteTriples(toDelete); removed != 0 {
+		t.Error("expected no triple to be removed but instead got", removed)
+	}
+
+	cpt := 0
+	for result := range graph.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		for _, triple := range toDelete {
+			if test, _ := result.Equals(triple); test {
+				t.Error("the graph shouldn't contains the triple", triple)
+			}
+		}
+		cpt++
+	}
+	if cpt != 60 {
+		t.Error("the graph should contains 60 triples, but it contains", cpt, "triples")
+	}
+}
+
 func TestLoadFromFileListGraph(t *testing.T) {
 	graph := NewListGraph()
 	cpt := 0
@@ -177,8 +262,32 @@ func TestLoadFromFileListGraph(t *testing.T) {
 		cpt++
 	}
 
-	if cpt != 5 {
-		t.Error("the graph should contains 5 triples, but it contains", cpt, "triples")
+	// the duplicated triple of the file is only added once
+	if cpt != 4 {
+		t.Error("the graph should contains 4 triples, but it contains", cpt, "triples")
+	}
+}
+
+func TestLoadFromFileDetectFormatListGraph(t *testing.T) {
+	files := []string{"../parser/datas/test.nt", "../parser/datas/test.ttl", "../parser/datas/test.rdf"}
+	expected := []int{4, 6, 20}
+	for i, file := range files {
+		graph := NewListGraph()
+		cpt := 0
+		if err := graph.LoadFromFile(file, ""); err != nil {
+			t.Error("loading", file, "without a format shouldn't produce the error :", err)
+		}
+		for _ = range graph.Filter(rdf.NewVariable("y"), rdf.NewVariable("v"), rdf.NewVariable("w")) {
+			cpt++
+		}
+		if cpt != expected[i] {
+			t.Error("the graph should contains", expected[i], "triples, but it contains", cpt, "triples")
+		}
+	}
+
+	graph := NewListGraph()
+	if err := graph.LoadFromFile("../parser/datas/test.nt", "n3"); err == nil {
+		t.Error("loading a file with an unsupported format should produce an error")
 	}
 }
 
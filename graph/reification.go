@@ -0,0 +1,222 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	rdfvocab "github.com/Callidon/joseki/vocab/rdf"
+	"sort"
+)
+
+// tripleSource is a source of triples which can be queried, e.g. a graph or a transaction.
+type tripleSource interface {
+	Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple
+}
+
+// objectsOf returns the objects of the triples of a source with a given subject & predicate.
+func objectsOf(source tripleSource, subject, predicate rdf.Node) []rdf.Node {
+	objects := make([]rdf.Node, 0)
+	for triple := range source.Filter(subject, predicate, rdf.NewVariable("o")) {
+		objects = append(objects, triple.Object)
+	}
+	return objects
+}
+
+// reification returns the four triples which reify a triple with a statement node.
+func reification(statement rdf.Node, triple rdf.Triple) []rdf.Triple {
+	return []rdf.Triple{
+		rdf.NewTriple(statement, rdfvocab.Type, rdfvocab.Statement),
+		rdf.NewTriple(statement, rdfvocab.Subject, triple.Subject),
+		rdf.NewTriple(statement, rdfvocab.Predicate, triple.Predicate),
+		rdf.NewTriple(statement, rdfvocab.Object, triple.Object),
+	}
+}
+
+// isReification returns True if a triple is one of the triples used to reify a triple with a statement node.
+func isReification(triple rdf.Triple) bool {
+	predicate, isURI := triple.Predicate.(rdf.URI)
+	if !isURI {
+		return false
+	}
+	switch predicate {
+	case rdfvocab.Subject, rdfvocab.Predicate, rdfvocab.Object:
+		return true
+	case rdfvocab.Type:
+		return triple.Object == rdfvocab.Statement
+	}
+	return false
+}
+
+// reifiedTriple returns the triple reified by a statement node of a source.
+func reifiedTriple(source tripleSource, statement rdf.Node) (rdf.Triple, bool) {
+	subjects := objectsOf(source, statement, rdfvocab.Subject)
+	predicates := objectsOf(source, statement, rdfvocab.Predicate)
+	objects := objectsOf(source, statement, rdfvocab.Object)
+	if len(subjects) != 1 || len(predicates) != 1 || len(objects) != 1 {
+		return rdf.Triple{}, false
+	}
+	return rdf.NewTriple(subjects[0], predicates[0], objects[0]), true
+}
+
+// statementsOf returns the statement nodes of a source, i.e. the nodes which have a rdf:subject, sorted with rdf.Compare.
+func statementsOf(source tripleSource) []rdf.Node {
+	nodes := make(map[string]rdf.Node)
+	for triple := range source.Filter(rdf.NewVariable("s"), rdfvocab.Subject, rdf.NewVariable("o")) {
+		nodes[termKey(triple.Subject)] = triple.Subject
+	}
+	statements := make([]rdf.Node, 0, len(nodes))
+	for _, node := range nodes {
+		statements = append(statements, node)
+	}
+	sort.Slice(statements, func(i, j int) bool {
+		return rdf.Compare(statements[i], statements[j]) < 0
+	})
+	return statements
+}
+
+// Reify adds the reification of a triple to a graph, i.e. the statement node with the type rdf:Statement,
+// and the rdf:subject, rdf:predicate & rdf:object of the triple. The triple itself isn't added to the graph.
+//
+// It returns the number of triples which weren't already in the graph.
+// RDF reification reference : https://www.w3.org/TR/rdf11-mt/#reification
+func Reify(g Graph, statement rdf.Node, triple rdf.Triple) int {
+	return g.AddAll(reification(statement, triple))
+}
+
+// ReifiedTriple returns the triple reified by a statement node of a graph,
+// and False if the statement doesn't have exactly one rdf:subject, one rdf:predicate & one rdf:object.
+func ReifiedTriple(g Graph, statement rdf.Node) (rdf.Triple, bool) {
+	return reifiedTriple(g, statement)
+}
+
+// Statements returns the statement nodes which reify a triple in a graph, sorted with rdf.Compare.
+// The type rdf:Statement isn't required, since it can be inferred from the rdf:subject of the statements.
+func Statements(g Graph, triple rdf.Triple) []rdf.Node {
+	statements := make([]rdf.Node, 0)
+	for _, statement := range statementsOf(g) {
+		if reified, found := reifiedTriple(g, statement); found && rdf.CompareTriples(reified, triple) == 0 {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+// Unreify removes the reification triples of a statement node from a graph, and returns the number of triples removed.
+// The other triples about the statement, e.g. its provenance, are kept.
+func Unreify(g Graph, statement rdf.Node) int {
+	removed := make([]rdf.Triple, 0)
+	for triple := range g.Filter(statement, rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		if isReification(triple) {
+			removed = append(removed, triple)
+		}
+	}
+	return g.DeleteTriples(removed)
+}
+
+// ReificationToQuoted replaces the statement nodes of a graph with the quoted triples they reify,
+// e.g. _:s rdf:subject :a ; rdf:predicate :b ; rdf:object :c ; :source :d . becomes << :a :b :c >> :source :d .
+// The reification triples are removed, and the statements which don't reify exactly one triple are ignored.
+// The statements reified by other statements are replaced with nested quoted triples.
+//
+// The modifications are applied in a single transaction, and the number of statements replaced is returned.
+func ReificationToQuoted(g Graph) int {
+	tx := g.Begin()
+	v := rdf.NewVariable("v")
+	quotedTriples := make(map[string]rdf.Node)
+	// quotedOf returns the quoted triple of a statement, where the nested statements are replaced with their quoted triples
+	var quotedOf func(node rdf.Node) rdf.Node
+	quotedOf = func(node rdf.Node) rdf.Node {
+		key := termKey(node)
+		if quoted, exists := quotedTriples[key]; exists {
+			return quoted
+		}
+		triple, found := reifiedTriple(tx, node)
+		if !found {
+			return node
+		}
+		// a statement which reifies itself cannot be replaced
+		quotedTriples[key] = node
+		quoted := rdf.NewQuotedTriple(rdf.NewTriple(quotedOf(triple.Subject), triple.Predicate, quotedOf(triple.Object)))
+		quotedTriples[key] = quoted
+		return quoted
+	}
+
+	cpt := 0
+	for _, statement := range statementsOf(tx) {
+		quoted, isQuoted := quotedOf(statement).(rdf.QuotedTriple)
+		if !isQuoted {
+			continue
+		}
+		removed, added := make([]rdf.Triple, 0), make([]rdf.Triple, 0)
+		for triple := range tx.Filter(statement, v, v) {
+			removed = append(removed, triple)
+			if !isReification(triple) {
+				added = append(added, rdf.NewTriple(quoted, triple.Predicate, triple.Object))
+			}
+		}
+		for triple := range tx.Filter(v, v, statement) {
+			removed = append(removed, triple)
+			added = append(added, rdf.NewTriple(triple.Subject, triple.Predicate, quoted))
+		}
+		for _, triple := range removed {
+			tx.Delete(triple.Subject, triple.Predicate, triple.Object)
+		}
+		for _, triple := range added {
+			tx.Add(triple)
+		}
+		cpt++
+	}
+	tx.Commit()
+	return cpt
+}
+
+// QuotedToReification replaces the quoted triples used as the subject or the object of the triples of a graph
+// with statement nodes reifying them, which are created with a generator.
+// It is the reverse of ReificationToQuoted, so the graph can be written in RDF 1.1 formats, e.g. RDF/XML.
+//
+// The modifications are applied in a single transaction, and the number of statements created is returned.
+func QuotedToReification(g Graph, generator *rdf.BlankNodeGenerator) int {
+	tx := g.Begin()
+	v := rdf.NewVariable("v")
+	statements := make(map[string]rdf.Node)
+	// statementOf returns the statement of a quoted triple, and reifies it the first time
+	var statementOf func(node rdf.Node) rdf.Node
+	statementOf = func(node rdf.Node) rdf.Node {
+		quoted, isQuoted := node.(rdf.QuotedTriple)
+		if !isQuoted {
+			return node
+		}
+		key := termKey(quoted)
+		if statement, exists := statements[key]; exists {
+			return statement
+		}
+		statement := generator.New()
+		statements[key] = statement
+		// the nested quoted triples are reified too
+		triple := rdf.NewTriple(statementOf(quoted.Subject), quoted.Predicate, statementOf(quoted.Object))
+		for _, reified := range reification(statement, triple) {
+			tx.Add(reified)
+		}
+		return statement
+	}
+
+	replaced := make([]rdf.Triple, 0)
+	for triple := range tx.Filter(v, v, v) {
+		_, quotedSubject := triple.Subject.(rdf.QuotedTriple)
+		_, quotedObject := triple.Object.(rdf.QuotedTriple)
+		if quotedSubject || quotedObject {
+			replaced = append(replaced, triple)
+		}
+	}
+	sort.Slice(replaced, func(i, j int) bool {
+		return rdf.CompareTriples(replaced[i], replaced[j]) < 0
+	})
+	for _, triple := range replaced {
+		tx.Delete(triple.Subject, triple.Predicate, triple.Object)
+		tx.Add(rdf.NewTriple(statementOf(triple.Subject), triple.Predicate, statementOf(triple.Object)))
+	}
+	tx.Commit()
+	return len(statements)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	rdfvocab "github.com/Callidon/joseki/vocab/rdf"
+	"testing"
+)
+
+func TestReify(t *testing.T) {
+	alice, knows, bob := rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://xmlns.com/foaf/0.1/knows"), rdf.NewURI("http://example.org/bob")
+	triple := rdf.NewTriple(alice, knows, bob)
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		if added := Reify(g, rdf.NewBlankNode("s1"), triple); added != 4 {
+			t.Error("expected the reification of a triple to add 4 triples but instead got", added)
+		}
+		Reify(g, rdf.NewURI("http://example.org/s0"), triple)
+		Reify(g, rdf.NewBlankNode("s2"), rdf.NewTriple(bob, knows, alice))
+		// a statement without rdf:type is still a statement
+		g.DeleteTriples([]rdf.Triple{rdf.NewTriple(rdf.NewBlankNode("s1"), rdfvocab.Type, rdfvocab.Statement)})
+
+		statements := Statements(g, triple)
+		if len(statements) != 2 || statements[0] != rdf.NewBlankNode("s1") || statements[1] != rdf.NewURI("http://example.org/s0") {
+			t.Error("expected the statements [_:s1 <http://example.org/s0>] but instead got", statements)
+		}
+		if reified, found := ReifiedTriple(g, rdf.NewBlankNode("s2")); !found || rdf.CompareTriples(reified, rdf.NewTriple(bob, knows, alice)) != 0 {
+			t.Error("expected _:s2 to reify the triple", rdf.NewTriple(bob, knows, alice), "but instead got", reified)
+		}
+		if _, found := ReifiedTriple(g, alice); found {
+			t.Error("a node without reification triples shouldn't reify a triple")
+		}
+
+		g.Add(rdf.NewTriple(rdf.NewBlankNode("s2"), rdf.NewURI("http://example.org/source"), rdf.NewLiteral("facebook")))
+		if removed := Unreify(g, rdf.NewBlankNode("s2")); removed != 4 {
+			t.Error("expected 4 reification triples to be removed but instead got", removed)
+		}
+		if cpt := countTriples(g.Filter(rdf.NewBlankNode("s2"), rdf.NewVariable("p"), rdf.NewVariable("o"))); cpt != 1 {
+			t.Error("the triples about a statement shouldn't be removed with its reification")
+		}
+	}
+}
+
+func TestReificationToQuoted(t *testing.T) {
+	alice, knows, bob := rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://xmlns.com/foaf/0.1/knows"), rdf.NewURI("http://example.org/bob")
+	source, says := rdf.NewURI("http://example.org/source"), rdf.NewURI("http://example.org/says")
+	quoted := rdf.NewQuotedTriple(rdf.NewTriple(alice, knows, bob))
+	v := rdf.NewVariable("v")
+	for _, g := range []Graph{NewListGraph(), NewTreeGraph()} {
+		g.Add(rdf.NewTriple(quoted, source, rdf.NewLiteral("facebook")))
+		g.Add(rdf.NewTriple(bob, says, quoted))
+		g.Add(rdf.NewTriple(alice, knows, bob))
+
+		if cpt := QuotedToReification(g, rdf.NewBlankNodeGenerator("s")); cpt != 1 {
+			t.Error("expected 1 statement to be created but instead got", cpt)
+		}
+		statement := rdf.NewBlankNode("s0")
+		expected := []rdf.Triple{
+			rdf.NewTriple(statement, rdfvocab.Type, rdfvocab.Statement),
+			rdf.NewTriple(statement, rdfvocab.Subject, alice),
+			rdf.NewTriple(statement, source, rdf.NewLiteral("facebook")),
+			rdf.NewTriple(bob, says, statement),
+			rdf.NewTriple(alice, knows, bob),
+		}
+		for _, triple := range expected {
+			if countTriples(g.Filter(triple.Subject, triple.Predicate, triple.Object)) != 1 {
+				t.Error("expected the triple", triple, "to be in the graph")
+			}
+		}
+		if cpt := countTriples(g.Filter(rdf.NewQuotedTriple(rdf.NewTriple(v, v, v)), v, v)); cpt != 0 {
+			t.Error("expected the quoted triples to be replaced, but", cpt, "triples still use them")
+		}
+
+		if cpt := ReificationToQuoted(g); cpt != 1 {
+			t.Error("expected 1 statement to be replaced but instead got", cpt)
+		}
+		if cpt := countTriples(g.Filter(v, v, v)); cpt != 3 {
+			t.Error("expected 3 triples after the conversion but instead got", cpt)
+		}
+		for _, triple := range []rdf.Triple{rdf.NewTriple(quoted, source, rdf.NewLiteral("facebook")), rdf.NewTriple(bob, says, quoted)} {
+			if countTriples(g.Filter(triple.Subject, triple.Predicate, triple.Object)) != 1 {
+				t.Error("expected the triple", triple, "to be in the graph")
+			}
+		}
+	}
+
+	// the nested quoted triples are reified with their own statements
+	g := NewTreeGraph()
+	g.Add(rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(bob, says, quoted)), source, rdf.NewLiteral("twitter")))
+	if cpt := QuotedToReification(g, rdf.NewBlankNodeGenerator("s")); cpt != 2 {
+		t.Error("expected 2 statements to be created but instead got", cpt)
+	}
+	if cpt := countTriples(g.Filter(v, v, v)); cpt != 9 {
+		t.Error("expected 9 triples after the reification but instead got", cpt)
+	}
+	if cpt := ReificationToQuoted(g); cpt != 2 {
+		t.Error("expected 2 statements to be replaced but instead got", cpt)
+	}
+	nested := rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(bob, says, quoted)), source, rdf.NewLiteral("twitter"))
+	if cpt := countTriples(g.Filter(v, v, v)); cpt != 1 || countTriples(g.Filter(nested.Subject, nested.Predicate, nested.Object)) != 1 {
+		t.Error("expected the graph to only contain the triple", nested)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+	"github.com/Callidon/joseki/rdf"
+	"sync"
+)
+
+// Snapshot is an immutable view of a graph, as it was when the snapshot was taken.
+//
+// A snapshot can be queried at leisure, without blocking the writers of the graph.
+// It must be released once it isn't needed anymore, so the old versions of the graph can be garbage collected.
+type Snapshot interface {
+	// Fetch triples form the snapshot that match a BGP given in parameters.
+	Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple
+	// Same as Filter, but with a Limit and an Offset
+	FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple
+	// Same as Filter, but stops sending triples when the context is cancelled.
+	FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple
+	// Same as FilterSubset, but stops sending triples when the context is cancelled.
+	FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple
+	// Release the snapshot. The queries already started are completed, but the next ones won't send any triple.
+	Release()
+}
+
+// noResults returns a closed channel, used to answer queries which cannot have any results.
+func noResults() <-chan rdf.Triple {
+	results := make(chan rdf.Triple)
+	close(results)
+	return results
+}
+
+// treeSnapshot is a snapshot of a TreeGraph, which holds a version of its tree.
+type treeSnapshot struct {
+	graph *TreeGraph
+	root  *bitmapNode
+	*sync.Mutex
+}
+
+// Filter fetch triples form the snapshot that match a BGP given in parameters.
+func (s *treeSnapshot) Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return s.FilterSubset(subject, predicate, object, -1, 0)
+}
+
+// FilterSubset fetch triples form the snapshot that match a BGP given in parameters, with a Limit and an Offset.
+func (s *treeSnapshot) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	return s.FilterSubsetContext(context.Background(), subject, predicate, object, limit, offset)
+}
+
+// FilterContext is the same as Filter, but stops sending triples & closes the channel when the context is cancelled.
+func (s *treeSnapshot) FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return s.FilterSubsetContext(ctx, subject, predicate, object, -1, 0)
+}
+
+// FilterSubsetContext is the same as FilterSubset, but stops sending triples & closes the channel when the context is cancelled.
+func (s *treeSnapshot) FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	s.Lock()
+	defer s.Unlock()
+	if s.root == nil {
+		return noResults()
+	}
+	// the tree must stay unmodified until the end of the query, even if the snapshot is released before
+	s.graph.pin()
+	return s.graph.filterTree(ctx, s.root, subject, predicate, object, limit, offset, s.graph.release)
+}
+
+// Release the snapshot.
+func (s *treeSnapshot) Release() {
+	s.Lock()
+	defer s.Unlock()
+	if s.root != nil {
+		s.root = nil
+		s.graph.release()
+	}
+}
+
+// listSnapshot is a snapshot of a ListGraph, which holds a version of its slice of triples.
+type listSnapshot struct {
+	graph    *ListGraph
+	triples  []bitmapTriple
+	released bool
+	*sync.Mutex
+}
+
+// Filter fetch triples form the snapshot that match a BGP given in parameters.
+func (s *listSnapshot) Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return s.FilterSubset(subject, predicate, object, -1, 0)
+}
+
+// FilterSubset fetch triples form the snapshot that match a BGP given in parameters, with a Limit and an Offset.
+func (s *listSnapshot) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	return s.FilterSubsetContext(context.Background(), subject, predicate, object, limit, offset)
+}
+
+// FilterContext is the same as Filter, but stops sending triples & closes the channel when the context is cancelled.
+func (s *listSnapshot) FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return s.FilterSubsetContext(ctx, subject, predicate, object, -1, 0)
+}
+
+// FilterSubsetContext is the same as FilterSubset, but stops sending triples & closes the channel when the context is cancelled.
+func (s *listSnapshot) FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	s.Lock()
+	defer s.Unlock()
+	if s.released {
+		return noResults()
+	}
+	return s.graph.filterTriples(ctx, s.triples, subject, predicate, object, limit, offset)
+}
+
+// Release the snapshot.
+func (s *listSnapshot) Release() {
+	s.Lock()
+	defer s.Unlock()
+	s.released = true
+	s.triples = nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSnapshotIsolation(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+
+	for _, g := range txGraphs(100) {
+		snapshot := g.Snapshot()
+		// modify the graph after the snapshot has been taken
+		g.Add(rdf.NewTriple(subj, subj, subj))
+		g.Delete(subj, rdf.NewURI("http://example.org/p0"), all[2])
+		g.DeleteTriples([]rdf.Triple{rdf.NewTriple(subj, rdf.NewURI("http://example.org/p1"), rdf.NewLiteral("1"))})
+		second := g.Snapshot()
+		g.Delete(all[0], all[1], all[2])
+
+		if cpt := countTriples(snapshot.Filter(all[0], all[1], all[2])); cpt != 100 {
+			t.Error("the snapshot should contains 100 triples, but it contains", cpt, "triples")
+		}
+		if cpt := countTriples(snapshot.Filter(subj, rdf.NewURI("http://example.org/p0"), all[2])); cpt != 1 {
+			t.Error("the snapshot should still contains the triple deleted from the graph")
+		}
+		if cpt := countTriples(snapshot.Filter(subj, subj, subj)); cpt != 0 {
+			t.Error("the snapshot shouldn't contains the triple added to the graph")
+		}
+		if cpt := countTriples(snapshot.FilterSubset(all[0], all[1], all[2], 9, 0)); cpt != 9 {
+			t.Error("expected 9 results but instead got", cpt, "results")
+		}
+		if cpt := countTriples(second.Filter(all[0], all[1], all[2])); cpt != 99 {
+			t.Error("the second snapshot should contains 99 triples, but it contains", cpt, "triples")
+		}
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 0 {
+			t.Error("the graph should be empty, but it contains", cpt, "triples")
+		}
+
+		// a released snapshot doesn't send any triple
+		snapshot.Release()
+		snapshot.Release()
+		if cpt := countTriples(snapshot.Filter(all[0], all[1], all[2])); cpt != 0 {
+			t.Error("a released snapshot shouldn't send any triple, but it sent", cpt, "triples")
+		}
+		second.Release()
+	}
+}
+
+func TestSnapshotRelease(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	g := NewTreeGraph()
+	for i := 0; i < 200; i++ {
+		g.Add(rdf.NewTriple(rdf.NewURI("http://example.org/s"+strconv.Itoa(i%10)), rdf.NewURI("http://example.org/p"+strconv.Itoa(i)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+
+	// a query started before the release of a snapshot is completed
+	snapshot := g.Snapshot()
+	results := snapshot.Filter(all[0], all[1], all[2])
+	snapshot.Release()
+	g.Delete(all[0], all[1], all[2])
+	if cpt := countTriples(results); cpt != 200 {
+		t.Error("expected 200 results but instead got", cpt, "results")
+	}
+
+	// once the snapshots & the queries are over, the writers stop copying the tree
+	countTriples(g.Filter(all[0], all[1], all[2]))
+	if snapshots := atomic.LoadInt64(&g.snapshots); snapshots != 0 {
+		t.Error("expected no snapshot to be reading the graph, but instead got", snapshots)
+	}
+	root := g.root
+	g.Add(rdf.NewTriple(rdf.NewURI("http://example.org/subject"), rdf.NewURI("http://example.org/p"), rdf.NewLiteral("value")))
+	if g.root != root {
+		t.Error("the tree shouldn't be copied when no snapshot is reading it")
+	}
+}
+
+func TestSlowReaderDoesntBlockWriters(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+
+	for _, g := range txGraphs(1000) {
+		results := g.Filter(all[0], all[1], all[2])
+		// read a single triple, then modify the graph while the query is still running
+		<-results
+		done := make(chan bool)
+		go func() {
+			g.Add(rdf.NewTriple(subj, subj, subj))
+			g.Delete(subj, all[1], rdf.NewLiteral("10"))
+			done <- true
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("a slow reader shouldn't block the writers of the graph")
+		}
+		if cpt := countTriples(results); cpt != 999 {
+			t.Error("the query should send all the triples of the graph, but it sent", cpt+1, "triples")
+		}
+	}
+}
@@ -5,26 +5,36 @@
 package graph
 
 import (
+	"context"
 	"github.com/Callidon/joseki/rdf"
 	"sync"
+	"sync/atomic"
 )
 
 // TreeGraph is a implementation of a RDF Graph based on the HDT-MR model proposed by Giménez-García et al.
 //
+// The tree is versioned & copy-on-write: the nodes shared with a snapshot are never modified,
+// the writers copy them instead. So readers can query the graph without blocking the writers.
+//
 // For more details, see http://dataweb.infor.uva.es/projects/hdt-mr/
 type TreeGraph struct {
 	dictionnary *bimap
 	root        *bitmapNode
 	nextID      int
 	triples     map[string][]rdf.Triple
+	// version of the tree modified by the writers
+	version int64
+	// number of snapshots & queries currently reading the tree
+	snapshots int64
 	*sync.RWMutex
 	*rdfReader
+	*notifier
 }
 
 // NewTreeGraph creates a new empty Tree Graph.
 func NewTreeGraph() *TreeGraph {
 	reader := newRDFReader()
-	g := &TreeGraph{newBimap(), newBitmapNode(-1), 0, make(map[string][]rdf.Triple), &sync.RWMutex{}, reader}
+	g := &TreeGraph{newBimap(), newBitmapNode(-1), 0, make(map[string][]rdf.Triple), 0, 0, &sync.RWMutex{}, reader, newNotifier()}
 	reader.graph = g
 	return g
 }
@@ -41,131 +51,382 @@ func (g *TreeGraph) registerNode(node rdf.Node) int {
 	return key
 }
 
-// Recursively remove nodes that match criteria
-func (g *TreeGraph) removeNodes(root, previous *bitmapNode, datas []*rdf.Node) {
-	if root != nil {
-		node := (*datas[0])
-		_, isVar := node.(rdf.Variable)
+// hasNode returns True if a node is in the graph dictionnary.
+func (g *TreeGraph) hasNode(node rdf.Node) bool {
+	g.RLock()
+	defer g.RUnlock()
+	_, inDict := g.dictionnary.locate(node)
+	return inDict
+}
+
+// patternIDs returns the IDs of the nodes of a triple pattern, with -1 for the variables,
+// and False if one of the nodes isn't in the dictionnary. The graph must be locked by the caller.
+func (g *TreeGraph) patternIDs(nodes ...rdf.Node) ([]int, bool) {
+	ids := make([]int, len(nodes))
+	for i, node := range nodes {
+		if _, isVar := node.(rdf.Variable); isVar {
+			ids[i] = -1
+			continue
+		}
 		id, inDict := g.dictionnary.locate(node)
-		// delegate operation to root's sons if it's a Variable or if the root match the current citeria
-		if isVar || (inDict && root.id == id) {
-			for _, son := range root.sons {
-				g.removeNodes(son, root, datas[1:])
-			}
-			// if root doesn't have any sons after the operation, delete it
-			if len(root.sons) == 0 {
-				delete(previous.sons, root.id)
-			}
+		if !inDict {
+			return nil, false
 		}
+		ids[i] = id
 	}
+	return ids, true
 }
 
-// sendTriples sends triples collected by another process, and respect the limit & offset of a query
-func sendTriple(input <-chan bitmapTriple, out chan<- rdf.Triple, dict *bimap, limit, offset int) {
+// newNode creates a new node in the current version of the tree.
+func (g *TreeGraph) newNode(id int) *bitmapNode {
+	node := newBitmapNode(id)
+	node.version = atomic.LoadInt64(&g.version)
+	return node
+}
+
+// mutable returns a node which can be modified by the writers: the node itself,
+// or a copy of it if it may be shared with a snapshot. The graph must be locked by the caller.
+func (g *TreeGraph) mutable(node *bitmapNode) *bitmapNode {
+	if atomic.LoadInt64(&g.snapshots) == 0 || node.version == atomic.LoadInt64(&g.version) {
+		return node
+	}
+	res := g.newNode(node.id)
+	for key, son := range node.sons {
+		res.sons[key] = son
+	}
+	return res
+}
+
+// acquire returns the current root of the tree, which won't be modified until release is called.
+//
+// It only needs the read lock: the writers are excluded while the counters are updated,
+// and the readers update them atomically.
+func (g *TreeGraph) acquire() *bitmapNode {
+	g.RLock()
+	defer g.RUnlock()
+	// the next modifications are made in a new version of the tree
+	atomic.AddInt64(&g.version, 1)
+	atomic.AddInt64(&g.snapshots, 1)
+	return g.root
+}
+
+// pin prevents the writers from modifying a root already acquired, until release is called.
+// The root must still be held by a snapshot, so the writers already copy its nodes.
+func (g *TreeGraph) pin() {
+	atomic.AddInt64(&g.snapshots, 1)
+}
+
+// release indicates that a root acquired or pinned isn't read anymore.
+func (g *TreeGraph) release() {
+	atomic.AddInt64(&g.snapshots, -1)
+}
+
+// Recursively remove nodes that match criteria. The parent must be mutable.
+// The path contains the IDs of the ancestors of the root, and the triples removed are saved if removed isn't nil.
+func (g *TreeGraph) removeNodes(parent, root *bitmapNode, ids []int, path []int, removed *[]bitmapTriple) {
+	// delegate operation to root's sons if it's a Variable or if the root match the current citeria
+	if ids[0] != -1 && root.id != ids[0] {
+		return
+	}
+	if len(root.sons) > 0 {
+		root = g.mutable(root)
+		parent.sons[root.id] = root
+		path = append(path[:len(path):len(path)], root.id)
+		for _, son := range root.sons {
+			g.removeNodes(root, son, ids[1:], path, removed)
+		}
+	} else if removed != nil {
+		*removed = append(*removed, newBitmapTriple(path[0], path[1], root.id))
+	}
+	// if root doesn't have any sons after the operation, delete it
+	if len(root.sons) == 0 {
+		delete(parent.sons, root.id)
+	}
+}
+
+// sendTriples sends triples collected by another process, and respect the limit & offset of a query.
+// It stops when the context is cancelled, or when the limit has been reached.
+// The triples are also checked with a function when it isn't nil, e.g. to match the quoted triples of a pattern.
+func (g *TreeGraph) sendTriples(ctx context.Context, cancel context.CancelFunc, input <-chan bitmapTriple, out chan<- rdf.Triple, limit, offset int, matches func(rdf.Triple) bool) {
 	defer close(out)
+	defer cancel()
 	cpt := 0
+	if offset < 0 {
+		offset = 0
+	}
 	for bTriple := range input {
-		// send the triple if the offset threshold has been reached but not the limit threashold
-		if cpt >= offset && (cpt-offset <= limit || limit == -1) {
-			triple, err := bTriple.Triple(dict)
-			if err != nil {
-				panic(err)
+		// stop as soon as the limit threashold has been reached
+		if limit != -1 && cpt-offset >= limit {
+			return
+		}
+		g.RLock()
+		triple, err := bTriple.Triple(g.dictionnary)
+		g.RUnlock()
+		check(err)
+		if matches != nil && !matches(triple) {
+			continue
+		}
+		// send the triple if the offset threshold has been reached
+		if cpt >= offset {
+			select {
+			case out <- triple:
+			case <-ctx.Done():
+				return
 			}
-			out <- triple
 		}
 		cpt++
 	}
 }
 
-// Recursively collect data from the graph in order to form triple pattern matching criterias.
-// The graph can be query with a Limit (the max number of rsults to send in the output channel)
-// and an Offset (the number of results to skip before sending them in the output channel).
-// These two parameters can be set to -1 to be ignored.
-func (g *TreeGraph) findNodes(root *bitmapNode, datas []*rdf.Node, triple []int, out chan<- bitmapTriple, wg *sync.WaitGroup) {
+// Recursively collect data from the tree in order to form triple pattern matching criterias.
+// The IDs of the variables in the pattern are set to -1.
+func findNodes(ctx context.Context, root *bitmapNode, ids []int, triple []int, out chan<- bitmapTriple, wg *sync.WaitGroup) {
 	defer wg.Done()
-	// utilitary function to update WaitGroup counter when skipping sons
-	skipSons := func(wg *sync.WaitGroup) {
+	// when the query is cancelled, there's no need to visit the node's sons
+	if ctx.Err() != nil {
 		for _, son := range root.sons {
 			son.updateCounter(wg)
 		}
+		return
 	}
-
-	// skip the node if the limit have a default value or has been reached
-	node := (*datas[0])
-	_, isVar := node.(rdf.Variable)
-	id, inDict := g.dictionnary.locate(node)
 	// when the root is a variable or the value we need, save it & delegate the operation to its sons
-	if isVar || (inDict && root.id == id) {
+	if ids[0] == -1 || root.id == ids[0] {
 		if len(root.sons) == 0 {
-			out <- newBitmapTriple(triple[0], triple[1], root.id)
-			//sendValue(append(triple, root.id), out, g.dictionnary, limit, offset)
+			select {
+			case out <- newBitmapTriple(triple[0], triple[1], root.id):
+			case <-ctx.Done():
+			}
 		} else {
+			// each son gets its own copy of the triple, as they are visited concurrently
+			triple = append(triple[:len(triple):len(triple)], root.id)
 			for _, son := range root.sons {
-				go g.findNodes(son, datas[1:], append(triple, root.id), out, wg)
+				go findNodes(ctx, son, ids[1:], triple, out, wg)
 			}
 		}
 	} else {
 		// the node doesn't match our query, so there's no need to visit its sons
-		skipSons(wg)
+		for _, son := range root.sons {
+			son.updateCounter(wg)
+		}
 	}
 }
 
-// Add a new Triple pattern to the graph.
-func (g *TreeGraph) Add(triple rdf.Triple) {
-	defer g.Unlock()
+// filterTree fetch the triples of a version of the tree that match a BGP given in parameters,
+// then calls done once the tree isn't read anymore.
+func (g *TreeGraph) filterTree(ctx context.Context, root *bitmapNode, subject, predicate, object rdf.Node, limit, offset int, done func()) <-chan rdf.Triple {
+	var wg sync.WaitGroup
+	bitmapResults := make(chan bitmapTriple, bufferSize)
+	results := make(chan rdf.Triple, bufferSize)
+	pattern, matches := quotedPattern(subject, predicate, object)
+	g.RLock()
+	ids, known := g.patternIDs(pattern...)
+	g.RUnlock()
+	// no triple can match a pattern with an unknown node
+	if !known {
+		done()
+		close(results)
+		return results
+	}
+
+	// fetch data in the tree & wait for the operation to be complete before closing the pipeline
+	ctx, cancel := context.WithCancel(ctx)
+	go g.sendTriples(ctx, cancel, bitmapResults, results, limit, offset, matches)
+	for _, son := range root.sons {
+		wg.Add(son.length() + 1)
+		go findNodes(ctx, son, ids, make([]int, 0, 3), bitmapResults, &wg)
+	}
+	// use a daemon to wait for the end of all related goroutines before closing the channel
+	go func() {
+		defer close(bitmapResults)
+		wg.Wait()
+		done()
+	}()
+	return results
+}
+
+// insert inserts a triple into the tree, and returns False if it was already in it.
+// The graph must be locked by the caller.
+func (g *TreeGraph) insert(triple rdf.Triple) bool {
 	// add each node of the triple to the dictionnary & then update the graph
 	subjID, predID, objID := g.registerNode(triple.Subject), g.registerNode(triple.Predicate), g.registerNode(triple.Object)
 	datas := []int{subjID, predID, objID}
 	currentNode := g.root
-	g.Lock()
-	// insert each data in the graph
+	depth := 0
+	// look for the first data which isn't in the graph
+	for ; depth < len(datas); depth++ {
+		node, inSons := currentNode.sons[datas[depth]]
+		if !inSons {
+			break
+		}
+		currentNode = node
+	}
+	if depth == len(datas) {
+		return false
+	}
+	// insert each data in the graph, copying the nodes shared with snapshots
+	g.root = g.mutable(g.root)
+	currentNode = g.root
 	for _, nodeID := range datas {
 		node, inSons := currentNode.sons[nodeID]
 		if inSons {
-			// skip to next node if the current data is the same as the current node
-			currentNode = node
+			node = g.mutable(node)
 		} else {
-			// add the new node, then use it for the next data ton insert
-			currentNode.sons[nodeID] = newBitmapNode(nodeID)
-			currentNode = currentNode.sons[nodeID]
+			node = g.newNode(nodeID)
+		}
+		currentNode.sons[nodeID] = node
+		currentNode = node
+	}
+	return true
+}
+
+// Add a new Triple pattern to the graph.
+func (g *TreeGraph) Add(triple rdf.Triple) {
+	g.Lock()
+	defer g.Unlock()
+	if g.insert(triple) {
+		g.notify(newEvents(TripleAdded, triple))
+	}
+}
+
+// AddAll adds several triples to the graph, and returns the number of triples which weren't already in the graph.
+func (g *TreeGraph) AddAll(triples []rdf.Triple) int {
+	g.Lock()
+	defer g.Unlock()
+	added := make([]rdf.Triple, 0)
+	for _, triple := range triples {
+		if g.insert(triple) {
+			added = append(added, triple)
+		}
+	}
+	g.notify(newEvents(TripleAdded, added...))
+	return len(added)
+}
+
+// AddFrom adds the triples read from a channel to the graph, until the channel is closed.
+// It returns the number of triples which weren't already in the graph.
+func (g *TreeGraph) AddFrom(triples <-chan rdf.Triple) int {
+	return addFrom(g, triples)
+}
+
+// remove removes a triple from the tree, and returns False if it wasn't in it.
+// The graph must be locked by the caller.
+func (g *TreeGraph) remove(triple rdf.Triple) bool {
+	ids, known := g.patternIDs(triple.Subject, triple.Predicate, triple.Object)
+	if !known || ids[0] == -1 || ids[1] == -1 || ids[2] == -1 {
+		return false
+	}
+	subjID, predID, objID := ids[0], ids[1], ids[2]
+	subjNode, inSons := g.root.sons[subjID]
+	if !inSons {
+		return false
+	}
+	predNode, inSons := subjNode.sons[predID]
+	if !inSons {
+		return false
+	}
+	if _, inSons = predNode.sons[objID]; !inSons {
+		return false
+	}
+	// copy the nodes shared with snapshots, then remove the triple & the nodes which don't have any sons left
+	g.root = g.mutable(g.root)
+	subjNode = g.mutable(subjNode)
+	g.root.sons[subjID] = subjNode
+	predNode = g.mutable(predNode)
+	subjNode.sons[predID] = predNode
+	delete(predNode.sons, objID)
+	if len(predNode.sons) == 0 {
+		delete(subjNode.sons, predID)
+	}
+	if len(subjNode.sons) == 0 {
+		delete(g.root.sons, subjID)
+	}
+	return true
+}
+
+// DeleteTriples deletes several triples from the graph, and returns the number of triples removed.
+func (g *TreeGraph) DeleteTriples(triples []rdf.Triple) int {
+	g.Lock()
+	defer g.Unlock()
+	removed := make([]rdf.Triple, 0)
+	for _, triple := range triples {
+		if g.remove(triple) {
+			removed = append(removed, triple)
+		}
+	}
+	g.notify(newEvents(TripleDeleted, removed...))
+	return len(removed)
+}
+
+// Begin starts a new transaction on the graph.
+func (g *TreeGraph) Begin() *Tx {
+	return newTx(g)
+}
+
+// commit applies the operations of a transaction to the graph.
+func (g *TreeGraph) commit(operations []txOperation) {
+	g.Lock()
+	defer g.Unlock()
+	events := make([]Event, 0)
+	for _, op := range operations {
+		for _, triple := range op.triples {
+			if op.deletion && g.remove(triple) {
+				events = append(events, Event{TripleDeleted, triple, 0, false})
+			} else if !op.deletion && g.insert(triple) {
+				events = append(events, Event{TripleAdded, triple, 0, false})
+			}
 		}
 	}
+	g.notify(events)
+}
+
+// Snapshot returns an immutable view of the graph in its current state.
+func (g *TreeGraph) Snapshot() Snapshot {
+	return &treeSnapshot{g, g.acquire(), &sync.Mutex{}}
 }
 
 // Delete triples from the graph that match a BGP given in parameters.
 func (g *TreeGraph) Delete(subject, predicate, object rdf.Node) {
 	g.Lock()
 	defer g.Unlock()
+	ids, known := g.patternIDs(subject, predicate, object)
+	if !known {
+		return
+	}
+	// save the triples removed only if someone is listening to the modifications of the graph
+	var removed *[]bitmapTriple
+	if g.hasSubscribers() {
+		removed = &[]bitmapTriple{}
+	}
+	g.root = g.mutable(g.root)
 	for _, son := range g.root.sons {
-		g.removeNodes(son, g.root, []*rdf.Node{&subject, &predicate, &object})
+		g.removeNodes(g.root, son, ids, make([]int, 0, 2), removed)
+	}
+	if removed != nil {
+		g.notify(g.bitmapEvents(TripleDeleted, g.dictionnary, *removed))
 	}
 }
 
 // FilterSubset fetch triples form the graph that match a BGP given in parameters.
 // It impose a Limit(the max number of results to be send in the output channel)
 // and an Offset (the number of results to skip before sending them in the output channel) to the nodes requested.
+//
+// The triples are read from the version of the graph at the time of the call,
+// so the graph can be modified while the results are consumed.
 func (g *TreeGraph) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
-	var wg sync.WaitGroup
-	bitmapResults := make(chan bitmapTriple, bufferSize)
-	results := make(chan rdf.Triple, bufferSize)
-
-	// fetch data in the tree & wait for the operation to be complete before closing the pipeline
-	g.RLock()
-	go sendTriple(bitmapResults, results, g.dictionnary, limit, offset)
-	for _, son := range g.root.sons {
-		wg.Add(son.length() + 1)
-		go g.findNodes(son, []*rdf.Node{&subject, &predicate, &object}, make([]int, 0, 3), bitmapResults, &wg)
-	}
-	// use a daemon to wait for the end of all related goroutines before closing the channel
-	go func() {
-		defer close(bitmapResults)
-		defer g.RUnlock()
-		wg.Wait()
-	}()
-	return results
+	return g.FilterSubsetContext(context.Background(), subject, predicate, object, limit, offset)
 }
 
 // Filter fetch triples form the graph that match a BGP given in parameters.
 func (g *TreeGraph) Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple {
 	return g.FilterSubset(subject, predicate, object, -1, 0)
 }
+
+// FilterSubsetContext is the same as FilterSubset, but stops sending triples & closes the channel when the context is cancelled.
+func (g *TreeGraph) FilterSubsetContext(ctx context.Context, subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	return g.filterTree(ctx, g.acquire(), subject, predicate, object, limit, offset, g.release)
+}
+
+// FilterContext is the same as Filter, but stops sending triples & closes the channel when the context is cancelled.
+func (g *TreeGraph) FilterContext(ctx context.Context, subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return g.FilterSubsetContext(ctx, subject, predicate, object, -1, 0)
+}
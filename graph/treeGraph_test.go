@@ -8,6 +8,7 @@ import (
 	"github.com/Callidon/joseki/rdf"
 	"math/rand"
 	"os"
+	"strconv"
 	"testing"
 )
 
@@ -185,7 +186,7 @@ func TestDeleteTreeGraph(t *testing.T) {
 
 	// insert random triples in the graph
 	for i := 0; i < nbDatas; i++ {
-		triple = rdf.NewTriple(subj, rdf.NewURI(string(rand.Intn(nbDatas))), rdf.NewLiteral(string(rand.Intn(nbDatas))))
+		triple = rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i)), rdf.NewLiteral(strconv.Itoa(rand.Intn(nbDatas))))
 		graph.Add(triple)
 	}
 
@@ -223,6 +224,79 @@ func TestDeleteTreeGraph(t *testing.T) {
 	}
 }
 
+func TestAddAllTreeGraph(t *testing.T) {
+	graph := NewTreeGraph()
+	subj := rdf.NewURI("http://example.org/subject")
+	triples := make([]rdf.Triple, 0)
+	for i := 0; i < 100; i++ {
+		triples = append(triples, rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i%10)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+	if added := graph.AddAll(triples); added != 100 {
+		t.Error("expected 100 triples to be added but instead got", added)
+	}
+	// the triples already in the graph aren't inserted twice
+	if added := graph.AddAll(triples[:50]); added != 0 {
+		t.Error("expected no triple to be added but instead got", added)
+	}
+	duplicates := []rdf.Triple{triples[0], rdf.NewTriple(subj, subj, subj), rdf.NewTriple(subj, subj, subj)}
+	if added := graph.AddAll(duplicates); added != 1 {
+		t.Error("expected 1 triple to be added but instead got", added)
+	}
+
+	// add triples from a channel
+	input := make(chan rdf.Triple)
+	go func() {
+		defer close(input)
+		for i := 0; i < 2*bufferSize; i++ {
+			input <- rdf.NewTriple(rdf.NewURI("http://example.org/s"+strconv.Itoa(i)), subj, subj)
+		}
+		input <- triples[0]
+	}()
+	if added := graph.AddFrom(input); added != 2*bufferSize {
+		t.Error("expected", 2*bufferSize, "triples to be added but instead got", added)
+	}
+
+	cpt := 0
+	for _ = range graph.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		cpt++
+	}
+	if cpt != 101+2*bufferSize {
+		t.Error("the graph should contains", 101+2*bufferSize, "triples, but it contains", cpt, "triples")
+	}
+}
+
+func TestDeleteTriplesTreeGraph(t *testing.T) {
+	graph := NewTreeGraph()
+	subj := rdf.NewURI("http://example.org/subject")
+	triples := make([]rdf.Triple, 0)
+	for i := 0; i < 100; i++ {
+		triples = append(triples, rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i%10)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+	graph.AddAll(triples)
+
+	// triples which aren't in the graph are ignored
+	toDelete := append(triples[:40:40], rdf.NewTriple(subj, subj, subj), rdf.NewTriple(subj, triples[51].Predicate, triples[60].Object))
+	if removed := graph.DeleteTriples(toDelete); removed != 40 {
+		t.Error("expected 40 triples to be removed but instead got", removed)
+	}
+	if removed := graph.DeleteTriples(toDelete); removed != 0 {
+		t.Error("expected no triple to be removed but instead got", removed)
+	}
+
+	cpt := 0
+	for result := range graph.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		for _, triple := range toDelete {
+			if test, _ := result.Equals(triple); test {
+				t.Error("the graph shouldn't contains the triple", triple)
+			}
+		}
+		cpt++
+	}
+	if cpt != 60 {
+		t.Error("the graph should contains 60 triples, but it contains", cpt, "triples")
+	}
+}
+
 func TestLoadFromFileTreeGraph(t *testing.T) {
 	graph := NewTreeGraph()
 	cpt := 0
@@ -0,0 +1,212 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"github.com/Callidon/joseki/rdf"
+	"sync"
+)
+
+// txOperation is a modification made by a transaction, which is applied to the graph on commit.
+type txOperation struct {
+	triples  []rdf.Triple
+	deletion bool
+}
+
+// txGraph is a graph which supports transactions.
+type txGraph interface {
+	Graph
+	// commit applies the operations of a transaction to the graph, while holding its lock.
+	commit(operations []txOperation)
+}
+
+// Tx is a transaction on a graph, created with Graph.Begin.
+//
+// A transaction works on a snapshot of the graph taken when it begins, and sees its own writes.
+// Its modifications are invisible to the other users of the graph until Commit is called,
+// then they are applied all at once: concurrent readers see either all of them or none of them.
+// A transaction can be used by several goroutines.
+//
+// The graph isn't copied: the transaction reads the snapshot & keeps a log of its own writes,
+// so it must be committed or rolled back to release the snapshot.
+type Tx struct {
+	graph    txGraph
+	snapshot Snapshot
+	// triples added by the transaction, in the order of their addition
+	added []rdf.Triple
+	// triples modified by the transaction, by key: True if they have been added, False if they have been deleted
+	modified   map[string]bool
+	operations []txOperation
+	closed     bool
+	*sync.Mutex
+}
+
+// newTx creates a new transaction on a graph.
+func newTx(g txGraph) *Tx {
+	return &Tx{g, g.Snapshot(), make([]rdf.Triple, 0), make(map[string]bool), make([]txOperation, 0), false, &sync.Mutex{}}
+}
+
+// state returns True if a triple has been added by the transaction, False if it has been deleted,
+// and whether it has been modified at all.
+func (tx *Tx) state(key string) (bool, bool) {
+	tx.Lock()
+	defer tx.Unlock()
+	added, modified := tx.modified[key]
+	return added, modified
+}
+
+// Add a new Triple pattern to the graph, within the transaction.
+// It has no effect if the transaction has already been committed or rolled back.
+func (tx *Tx) Add(triple rdf.Triple) {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.closed {
+		return
+	}
+	key := tripleKey(triple)
+	if !tx.modified[key] {
+		tx.added = append(tx.added, triple)
+		tx.modified[key] = true
+	}
+	tx.operations = append(tx.operations, txOperation{[]rdf.Triple{triple}, false})
+}
+
+// Delete triples that match a BGP given in parameters from the graph, within the transaction.
+// It has no effect if the transaction has already been committed or rolled back.
+func (tx *Tx) Delete(subject, predicate, object rdf.Node) {
+	tx.Lock()
+	if tx.closed {
+		tx.Unlock()
+		return
+	}
+	results := tx.filter(context.Background(), subject, predicate, object, -1, 0)
+	tx.Unlock()
+	// the triples deleted are saved, so the commit only removes them & not the triples added concurrently
+	deleted := make([]rdf.Triple, 0)
+	for triple := range results {
+		deleted = append(deleted, triple)
+	}
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.closed || len(deleted) == 0 {
+		return
+	}
+	for _, triple := range deleted {
+		tx.modified[tripleKey(triple)] = false
+	}
+	tx.operations = append(tx.operations, txOperation{deleted, true})
+}
+
+// filter fetch the triples of the snapshot which haven't been deleted, then the triples added by the transaction,
+// that match a BGP given in parameters. The transaction must be locked by the caller.
+func (tx *Tx) filter(ctx context.Context, subject, predicate, object rdf.Node, limit, offset int) <-chan rdf.Triple {
+	results := make(chan rdf.Triple, bufferSize)
+	// the triples added after the call aren't sent
+	added := tx.added
+	ctx, cancel := context.WithCancel(ctx)
+	fromSnapshot := tx.snapshot.FilterSubsetContext(ctx, subject, predicate, object, -1, 0)
+	go func() {
+		defer close(results)
+		defer cancel()
+		cpt := 0
+		// send sends a triple if the offset has been reached, and returns False once the limit has been reached
+		send := func(triple rdf.Triple) bool {
+			if cpt >= offset {
+				select {
+				case results <- triple:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			cpt++
+			return limit == -1 || cpt-offset < limit
+		}
+		if limit == 0 {
+			return
+		}
+		// the triples added by the transaction which are already in the snapshot are only sent once
+		sent := make(map[string]bool)
+		for triple := range fromSnapshot {
+			key := tripleKey(triple)
+			isAdded, modified := tx.state(key)
+			if modified && !isAdded {
+				continue
+			} else if modified {
+				sent[key] = true
+			}
+			if !send(triple) {
+				return
+			}
+		}
+		for _, triple := range added {
+			key := tripleKey(triple)
+			if isAdded, _ := tx.state(key); !isAdded || sent[key] {
+				continue
+			}
+			if !rdf.Matches(subject, triple.Subject) || !rdf.Matches(predicate, triple.Predicate) || !rdf.Matches(object, triple.Object) {
+				continue
+			}
+			sent[key] = true
+			if !send(triple) {
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// Filter fetch triples form the graph that match a BGP given in parameters, including the modifications made by the transaction.
+// It doesn't send any triple if the transaction has already been committed or rolled back.
+func (tx *Tx) Filter(subject, predicate, object rdf.Node) <-chan rdf.Triple {
+	return tx.FilterSubset(subject, predicate, object, -1, 0)
+}
+
+// FilterSubset is the same as Filter, but with a Limit and an Offset.
+func (tx *Tx) FilterSubset(subject rdf.Node, predicate rdf.Node, object rdf.Node, limit int, offset int) <-chan rdf.Triple {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.closed {
+		return noResults()
+	}
+	return tx.filter(context.Background(), subject, predicate, object, limit, offset)
+}
+
+// close releases the snapshot of the transaction & discards its modifications.
+// The transaction must be locked by the caller.
+func (tx *Tx) close() {
+	tx.closed = true
+	tx.snapshot.Release()
+	tx.added, tx.operations = nil, nil
+}
+
+// Commit applies the modifications made by the transaction to the graph, then closes the transaction.
+//
+// The modifications are applied in the same order as they were made, on the current state of the graph.
+// If another transaction has been committed in the meantime, its modifications are kept, unless they're overwritten by this one.
+func (tx *Tx) Commit() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.closed {
+		return errors.New("Error : the transaction has already been committed or rolled back")
+	}
+	if len(tx.operations) > 0 {
+		tx.graph.commit(tx.operations)
+	}
+	tx.close()
+	return nil
+}
+
+// Rollback discards the modifications made by the transaction, then closes the transaction.
+func (tx *Tx) Rollback() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.closed {
+		return errors.New("Error : the transaction has already been committed or rolled back")
+	}
+	tx.close()
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// countTriples counts the triples sent in a channel, for testing purpose
+func countTriples(triples <-chan rdf.Triple) int {
+	cpt := 0
+	for _ = range triples {
+		cpt++
+	}
+	return cpt
+}
+
+// txGraphs returns a graph of each type, filled with the same triples, for testing purpose
+func txGraphs(nbTriples int) []Graph {
+	graphs := []Graph{NewListGraph(), NewTreeGraph()}
+	subj := rdf.NewURI("http://example.org/subject")
+	for _, g := range graphs {
+		for i := 0; i < nbTriples; i++ {
+			g.Add(rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i)), rdf.NewLiteral(strconv.Itoa(i))))
+		}
+	}
+	return graphs
+}
+
+func TestTxCommit(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+	newTriple := rdf.NewTriple(rdf.NewURI("http://example.org/new"), subj, subj)
+
+	for _, g := range txGraphs(10) {
+		tx := g.Begin()
+		tx.Add(newTriple)
+		tx.Delete(subj, rdf.NewURI("http://example.org/p0"), rdf.NewVariable("o"))
+		tx.Delete(subj, rdf.NewURI("http://example.org/p1"), rdf.NewVariable("o"))
+
+		// the transaction sees its own writes, but not the graph
+		if cpt := countTriples(tx.Filter(all[0], all[1], all[2])); cpt != 9 {
+			t.Error("the transaction should contains 9 triples, but it contains", cpt, "triples")
+		}
+		if cpt := countTriples(tx.Filter(newTriple.Subject, newTriple.Predicate, newTriple.Object)); cpt != 1 {
+			t.Error("the transaction should contains the triple", newTriple)
+		}
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 10 {
+			t.Error("the graph shouldn't be modified before the commit, but it contains", cpt, "triples")
+		}
+
+		if err := tx.Commit(); err != nil {
+			t.Error("committing a transaction shouldn't produce the error :", err)
+		}
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 9 {
+			t.Error("the graph should contains 9 triples after the commit, but it contains", cpt, "triples")
+		}
+		if cpt := countTriples(g.Filter(subj, rdf.NewURI("http://example.org/p0"), all[2])); cpt != 0 {
+			t.Error("the triples deleted by the transaction should have been removed from the graph")
+		}
+		if cpt := countTriples(g.Filter(newTriple.Subject, newTriple.Predicate, newTriple.Object)); cpt != 1 {
+			t.Error("the graph should contains the triple", newTriple, "after the commit")
+		}
+
+		// a closed transaction cannot be used anymore
+		if err := tx.Commit(); err == nil {
+			t.Error("committing a transaction twice should produce an error")
+		}
+		if err := tx.Rollback(); err == nil {
+			t.Error("rolling back a committed transaction should produce an error")
+		}
+		if cpt := countTriples(tx.Filter(all[0], all[1], all[2])); cpt != 0 {
+			t.Error("a committed transaction shouldn't send any triple, but it sent", cpt, "triples")
+		}
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+
+	for _, g := range txGraphs(10) {
+		tx := g.Begin()
+		tx.Add(rdf.NewTriple(subj, subj, subj))
+		tx.Delete(subj, all[1], all[2])
+		if cpt := countTriples(tx.Filter(all[0], all[1], all[2])); cpt != 0 {
+			t.Error("the transaction should be empty, but it contains", cpt, "triples")
+		}
+		if err := tx.Rollback(); err != nil {
+			t.Error("rolling back a transaction shouldn't produce the error :", err)
+		}
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 10 {
+			t.Error("the graph shouldn't be modified by a rolled back transaction, but it contains", cpt, "triples")
+		}
+		// operations on a closed transaction are ignored
+		tx.Add(rdf.NewTriple(subj, subj, subj))
+		if err := tx.Commit(); err == nil {
+			t.Error("committing a rolled back transaction should produce an error")
+		}
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 10 {
+			t.Error("the graph shouldn't be modified by a rolled back transaction, but it contains", cpt, "triples")
+		}
+	}
+}
+
+func TestTxIsolation(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+
+	for _, g := range txGraphs(10) {
+		tx := g.Begin()
+		// modifications of the graph made after the beginning of a transaction aren't visible by the transaction
+		g.Add(rdf.NewTriple(subj, subj, subj))
+		if cpt := countTriples(tx.Filter(all[0], all[1], all[2])); cpt != 10 {
+			t.Error("the transaction should contains 10 triples, but it contains", cpt, "triples")
+		}
+		// but they are kept by the commit
+		tx.Delete(subj, rdf.NewURI("http://example.org/p0"), all[2])
+		tx.Commit()
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 10 {
+			t.Error("the graph should contains 10 triples, but it contains", cpt, "triples")
+		}
+
+		// concurrent readers see all the modifications of a transaction or none of them
+		tx = g.Begin()
+		for i := 0; i < 100; i++ {
+			tx.Add(rdf.NewTriple(rdf.NewURI("http://example.org/s"+strconv.Itoa(i)), subj, subj))
+		}
+		var wg sync.WaitGroup
+		wg.Add(10)
+		for i := 0; i < 10; i++ {
+			go func() {
+				defer wg.Done()
+				if cpt := countTriples(g.Filter(all[0], subj, subj)); cpt != 1 && cpt != 101 {
+					t.Error("a reader shouldn't see a partially committed transaction, but it found", cpt, "triples")
+				}
+			}()
+		}
+		tx.Commit()
+		wg.Wait()
+	}
+}
+
+func TestTxWriteLog(t *testing.T) {
+	all := []rdf.Node{rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")}
+	subj := rdf.NewURI("http://example.org/subject")
+	existing := rdf.NewTriple(subj, rdf.NewURI("http://example.org/p0"), rdf.NewLiteral("0"))
+	newTriple := rdf.NewTriple(subj, subj, subj)
+
+	for _, g := range txGraphs(10) {
+		tx := g.Begin()
+		// the triples already in the snapshot are only sent once
+		tx.Add(existing)
+		tx.Add(newTriple)
+		tx.Add(newTriple)
+		if cpt := countTriples(tx.Filter(all[0], all[1], all[2])); cpt != 11 {
+			t.Error("the transaction should contains 11 triples, but it contains", cpt, "triples")
+		}
+		// the limit & the offset apply to the triples of the snapshot & to the triples added
+		if cpt := countTriples(tx.FilterSubset(all[0], all[1], all[2], 5, 8)); cpt != 3 {
+			t.Error("expected 3 triples after the offset, but instead got", cpt)
+		}
+		if cpt := countTriples(tx.FilterSubset(all[0], all[1], all[2], 4, 0)); cpt != 4 {
+			t.Error("expected 4 triples with a limit of 4, but instead got", cpt)
+		}
+		// a triple deleted then added again is visible
+		tx.Delete(subj, subj, subj)
+		if cpt := countTriples(tx.Filter(subj, subj, all[2])); cpt != 0 {
+			t.Error("the triple", newTriple, "should have been deleted by the transaction")
+		}
+		tx.Add(newTriple)
+		if cpt := countTriples(tx.Filter(subj, subj, all[2])); cpt != 1 {
+			t.Error("the triple", newTriple, "should have been added again by the transaction")
+		}
+		tx.Commit()
+		if cpt := countTriples(g.Filter(all[0], all[1], all[2])); cpt != 11 {
+			t.Error("the graph should contains 11 triples after the commit, but it contains", cpt, "triples")
+		}
+	}
+}
+
+func BenchmarkBeginTreeGraph(b *testing.B) {
+	g := txGraphs(0)[1]
+	subj := rdf.NewURI("http://example.org/subject")
+	for i := 0; i < 100000; i++ {
+		g.Add(rdf.NewTriple(subj, rdf.NewURI("http://example.org/p"+strconv.Itoa(i%100)), rdf.NewLiteral(strconv.Itoa(i))))
+	}
+	b.ResetTimer()
+	// beginning a transaction doesn't copy the graph, so its cost doesn't depend on the size of the graph
+	for i := 0; i < b.N; i++ {
+		tx := g.Begin()
+		tx.Add(rdf.NewTriple(subj, subj, rdf.NewLiteral(strconv.Itoa(i))))
+		tx.Commit()
+	}
+}
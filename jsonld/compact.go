@@ -0,0 +1,692 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Compact runs the Compaction algorithm on a JSON-LD document, which expands it, then shortens
+// its IRIs & values using the terms defined in a context.
+//
+// Compaction reference : https://www.w3.org/TR/json-ld11-api/#compaction-algorithm
+func Compact(input, context interface{}, options *Options) (map[string]interface{}, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	expanded, err := Expand(input, options)
+	if err != nil {
+		return nil, err
+	}
+	context = unwrapContext(context)
+	active, err := newActiveContext(options.Base, options).process(context, options.Base, nil, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return active.compactDocument(expanded, context)
+}
+
+// unwrapContext returns the value of the @context entry of a context given as a document.
+func unwrapContext(context interface{}) interface{} {
+	if obj, isMap := context.(map[string]interface{}); isMap {
+		if ctx, hasCtx := obj["@context"]; hasCtx {
+			return ctx
+		}
+	}
+	return context
+}
+
+// compactDocument compacts an expanded document, and adds the context to the result.
+func (c *activeContext) compactDocument(expanded []interface{}, context interface{}) (map[string]interface{}, error) {
+	compacted, err := c.compact("", expanded)
+	if err != nil {
+		return nil, err
+	}
+	result, isMap := compacted.(map[string]interface{})
+	if !isMap {
+		result = make(map[string]interface{})
+		if array := asArray(compacted); len(array) > 0 {
+			result[c.compactIRI("@graph", nil, true, false)] = array
+		}
+	}
+	if !isEmptyContext(context) {
+		result["@context"] = context
+	}
+	return result, nil
+}
+
+// isEmptyContext returns True if a context doesn't contain any definition.
+func isEmptyContext(context interface{}) bool {
+	switch ctx := context.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(ctx) == 0
+	case []interface{}:
+		return len(ctx) == 0
+	}
+	return false
+}
+
+// inverseEntry is an entry of the inverse context, indexed by "@language", "@type" & "@any".
+type inverseEntry map[string]map[string]string
+
+// inverseContext creates the inverse context, used to select the best term for an IRI.
+//
+// Inverse Context Creation reference : https://www.w3.org/TR/json-ld11-api/#inverse-context-creation
+func (c *activeContext) inverseContext() map[string]interface{} {
+	if c.inverse != nil {
+		return c.inverse
+	}
+	c.inverse = make(map[string]interface{})
+	defaultLanguage := "@none"
+	if c.language != "" {
+		defaultLanguage = c.language
+	}
+	terms := make([]string, 0, len(c.terms))
+	for term := range c.terms {
+		terms = append(terms, term)
+	}
+	// shortest terms are prefered, then the lexicographically least
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) < len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+
+	setDefault := func(m map[string]string, key, term string) {
+		if _, inMap := m[key]; !inMap {
+			m[key] = term
+		}
+	}
+	for _, term := range terms {
+		def := c.terms[term]
+		if def == nil || def.id == "" {
+			continue
+		}
+		containers := append([]string{}, def.container...)
+		sort.Strings(containers)
+		container := strings.Join(containers, "")
+		if container == "" {
+			container = "@none"
+		}
+		if _, inMap := c.inverse[def.id]; !inMap {
+			c.inverse[def.id] = make(map[string]inverseEntry)
+		}
+		containerMap := c.inverse[def.id].(map[string]inverseEntry)
+		if _, inMap := containerMap[container]; !inMap {
+			containerMap[container] = inverseEntry{
+				"@language": make(map[string]string),
+				"@type":     make(map[string]string),
+				"@any":      map[string]string{"@none": term},
+			}
+		}
+		entry := containerMap[container]
+		languageMap, typeMap := entry["@language"], entry["@type"]
+		switch {
+		case def.reverse:
+			setDefault(typeMap, "@reverse", term)
+		case def.typeMapping == "@none":
+			setDefault(languageMap, "@any", term)
+			setDefault(typeMap, "@any", term)
+		case def.typeMapping != "":
+			setDefault(typeMap, def.typeMapping, term)
+		case def.hasLanguage:
+			language := def.language
+			if language == "" {
+				language = "@null"
+			}
+			setDefault(languageMap, language, term)
+		default:
+			setDefault(languageMap, defaultLanguage, term)
+			setDefault(languageMap, "@none", term)
+			setDefault(typeMap, "@none", term)
+		}
+	}
+	return c.inverse
+}
+
+// selectTerm runs the Term Selection algorithm, which finds the best term to compact an IRI.
+//
+// Term Selection reference : https://www.w3.org/TR/json-ld11-api/#term-selection
+func (c *activeContext) selectTerm(iri string, containers []string, typeLanguage string, preferredValues []string) string {
+	containerMap, _ := c.inverseContext()[iri].(map[string]inverseEntry)
+	for _, container := range containers {
+		entry, inMap := containerMap[container]
+		if !inMap {
+			continue
+		}
+		valueMap := entry[typeLanguage]
+		for _, value := range preferredValues {
+			if term, inMap := valueMap[value]; inMap {
+				return term
+			}
+		}
+	}
+	return ""
+}
+
+// compactIRI runs the IRI Compaction algorithm, which compacts an IRI into a term, a compact IRI or a relative IRI.
+//
+// IRI Compaction reference : https://www.w3.org/TR/json-ld11-api/#iri-compaction
+func (c *activeContext) compactIRI(iri string, value interface{}, vocab, reverse bool) string {
+	if iri == "" {
+		return ""
+	}
+	if _, inInverse := c.inverseContext()[iri]; vocab && inInverse {
+		containers, typeLanguage, preferred := c.compactionContainers(value, reverse)
+		if term := c.selectTerm(iri, containers, typeLanguage, preferred); term != "" {
+			return term
+		}
+	}
+	if vocab && c.hasVocab && strings.HasPrefix(iri, c.vocab) && len(iri) > len(c.vocab) {
+		suffix := iri[len(c.vocab):]
+		if _, isTerm := c.terms[suffix]; !isTerm {
+			return suffix
+		}
+	}
+
+	// try to build a compact IRI using a prefix
+	compactIRI := ""
+	terms := make([]string, 0, len(c.terms))
+	for term := range c.terms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	for _, term := range terms {
+		def := c.terms[term]
+		if def == nil || def.id == "" || def.id == iri || !strings.HasPrefix(iri, def.id) || !def.prefix {
+			continue
+		}
+		candidate := term + ":" + iri[len(def.id):]
+		isShorter := compactIRI == "" || len(candidate) < len(compactIRI) || (len(candidate) == len(compactIRI) && candidate < compactIRI)
+		candidateDef, isTerm := c.terms[candidate]
+		if isShorter && (!isTerm || (candidateDef != nil && candidateDef.id == iri && value == nil)) {
+			compactIRI = candidate
+		}
+	}
+	if compactIRI != "" {
+		return compactIRI
+	}
+	if !vocab {
+		return relativize(c.base, iri)
+	}
+	return iri
+}
+
+// compactionContainers returns the containers, the type/language & the preferred values used to select a term for a value.
+func (c *activeContext) compactionContainers(value interface{}, reverse bool) ([]string, string, []string) {
+	defaultLanguage := "@none"
+	if c.language != "" {
+		defaultLanguage = c.language
+	}
+	containers := make([]string, 0)
+	typeLanguage, typeLanguageValue := "@language", "@null"
+	obj, isMap := value.(map[string]interface{})
+	_, hasIndex := obj["@index"]
+	if isMap && hasIndex && !isGraphObject(obj) {
+		containers = append(containers, "@index", "@index@set")
+	}
+
+	switch {
+	case reverse:
+		typeLanguage, typeLanguageValue = "@type", "@reverse"
+		containers = append(containers, "@set")
+	case isListObject(obj):
+		if !hasIndex {
+			containers = append(containers, "@list")
+		}
+		list := asArray(obj["@list"])
+		commonType, commonLanguage := "", ""
+		if len(list) == 0 {
+			commonLanguage = defaultLanguage
+		}
+		for _, item := range list {
+			itemLanguage, itemType := "@none", "@none"
+			if isValueObject(item) {
+				itemMap := item.(map[string]interface{})
+				if language, hasLanguage := itemMap["@language"].(string); hasLanguage {
+					itemLanguage = language
+				} else if datatype, hasType := itemMap["@type"].(string); hasType {
+					itemType = datatype
+				} else {
+					itemLanguage = "@null"
+				}
+			} else {
+				itemType = "@id"
+			}
+			if commonLanguage == "" {
+				commonLanguage = itemLanguage
+			} else if commonLanguage != itemLanguage && isValueObject(item) {
+				commonLanguage = "@none"
+			}
+			if commonType == "" {
+				commonType = itemType
+			} else if commonType != itemType {
+				commonType = "@none"
+			}
+			if commonLanguage == "@none" && commonType == "@none" {
+				break
+			}
+		}
+		if commonLanguage == "" {
+			commonLanguage = "@none"
+		}
+		if commonType == "" {
+			commonType = "@none"
+		}
+		if commonType != "@none" {
+			typeLanguage, typeLanguageValue = "@type", commonType
+		} else {
+			typeLanguageValue = commonLanguage
+		}
+	case isGraphObject(obj):
+		containers = append(containers, "@graph@set", "@graph")
+	default:
+		if isValueObject(obj) {
+			language, hasLanguage := obj["@language"].(string)
+			datatype, hasType := obj["@type"].(string)
+			if hasLanguage && !hasIndex {
+				typeLanguageValue = language
+				containers = append(containers, "@language", "@language@set")
+			} else if hasType {
+				typeLanguage, typeLanguageValue = "@type", datatype
+			}
+		} else {
+			typeLanguage, typeLanguageValue = "@type", "@id"
+			containers = append(containers, "@id", "@id@set", "@type", "@set@type")
+		}
+		containers = append(containers, "@set")
+	}
+	containers = append(containers, "@none")
+	if !isMap || !hasIndex {
+		containers = append(containers, "@index", "@index@set")
+	}
+	if isValueObject(obj) && len(obj) == 1 {
+		containers = append(containers, "@language", "@language@set")
+	}
+
+	preferredValues := make([]string, 0)
+	if typeLanguageValue == "@reverse" {
+		preferredValues = append(preferredValues, "@reverse")
+	}
+	if id, hasID := obj["@id"].(string); (typeLanguageValue == "@id" || typeLanguageValue == "@reverse") && hasID {
+		compacted := c.compactIRI(id, nil, true, false)
+		if def, isTerm := c.terms[compacted]; isTerm && def != nil && def.id == id {
+			preferredValues = append(preferredValues, "@vocab", "@id", "@none")
+		} else {
+			preferredValues = append(preferredValues, "@id", "@vocab", "@none")
+		}
+	} else {
+		preferredValues = append(preferredValues, typeLanguageValue, "@none")
+		if isListObject(obj) && len(asArray(obj["@list"])) == 0 {
+			typeLanguage = "@any"
+		}
+	}
+	preferredValues = append(preferredValues, "@any")
+	return containers, typeLanguage, preferredValues
+}
+
+// relativize returns an IRI relative to a base IRI, if they share the same scheme & authority.
+func relativize(base, iri string) string {
+	baseURL, err := url.Parse(base)
+	if base == "" || err != nil {
+		return iri
+	}
+	iriURL, err := url.Parse(iri)
+	if err != nil || iriURL.Scheme != baseURL.Scheme || iriURL.Host != baseURL.Host {
+		return iri
+	}
+	if iri == base {
+		return ""
+	}
+	// remove the common part of the paths, then go up from the remaining segments of the base
+	baseSegments := strings.Split(baseURL.Path, "/")
+	iriSegments := strings.Split(iriURL.Path, "/")
+	common := 0
+	for common < len(baseSegments)-1 && common < len(iriSegments)-1 && baseSegments[common] == iriSegments[common] {
+		common++
+	}
+	relative := strings.Repeat("../", len(baseSegments)-1-common) + strings.Join(iriSegments[common:], "/")
+	if iriURL.RawQuery != "" {
+		relative += "?" + iriURL.RawQuery
+	}
+	if iriURL.Fragment != "" {
+		relative += "#" + iriURL.Fragment
+	}
+	if relative == "" || strings.Contains(strings.SplitN(relative, "/", 2)[0], ":") {
+		relative = "./" + relative
+	}
+	return relative
+}
+
+// compactValue runs the Value Compaction algorithm, which compacts a value object or a node reference.
+//
+// Value Compaction reference : https://www.w3.org/TR/json-ld11-api/#value-compaction
+func (c *activeContext) compactValue(activeProperty string, value map[string]interface{}) interface{} {
+	def := c.terms[activeProperty]
+	language, direction := c.language, c.direction
+	if def != nil && def.hasLanguage {
+		language = def.language
+	}
+	if def != nil && def.hasDir {
+		direction = def.direction
+	}
+	_, hasIndex := value["@index"]
+	indexContainer := def.hasContainer("@index")
+	typeMapping := ""
+	if def != nil {
+		typeMapping = def.typeMapping
+	}
+
+	if id, hasID := value["@id"].(string); hasID && (len(value) == 1 || (len(value) == 2 && hasIndex && indexContainer)) {
+		if typeMapping == "@id" {
+			return c.compactIRI(id, nil, false, false)
+		} else if typeMapping == "@vocab" {
+			return c.compactIRI(id, nil, true, false)
+		}
+	}
+	if !isValueObject(value) || (hasIndex && !indexContainer) {
+		return c.compactKeys(value)
+	}
+
+	datatype, hasType := value["@type"].(string)
+	valueLanguage, hasLanguage := value["@language"].(string)
+	valueDirection, _ := value["@direction"].(string)
+	_, isString := value["@value"].(string)
+	switch {
+	case hasType && datatype == typeMapping:
+		return value["@value"]
+	case typeMapping == "@none" || (hasType && datatype != typeMapping):
+		return c.compactKeys(value)
+	case !isString:
+		if !hasLanguage {
+			return value["@value"]
+		}
+	case strings.ToLower(valueLanguage) == language && valueDirection == direction:
+		return value["@value"]
+	}
+	return c.compactKeys(value)
+}
+
+// compactKeys compacts the keywords & the type of a value object or a node reference.
+func (c *activeContext) compactKeys(value map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(value))
+	for key, v := range value {
+		switch key {
+		case "@type":
+			if datatype, isString := v.(string); isString {
+				v = c.compactIRI(datatype, nil, true, false)
+			}
+		case "@id":
+			if id, isString := v.(string); isString {
+				v = c.compactIRI(id, nil, false, false)
+			}
+		}
+		result[c.compactIRI(key, nil, true, false)] = v
+	}
+	return result
+}
+
+// addCompactedValue adds a value to an entry of a compacted map.
+func addCompactedValue(obj map[string]interface{}, key string, value interface{}, asArrayValue bool) {
+	if previous, inMap := obj[key]; inMap {
+		obj[key] = append(asArray(previous), asArray(value)...)
+	} else if _, isArray := value.([]interface{}); asArrayValue && !isArray {
+		obj[key] = []interface{}{value}
+	} else {
+		obj[key] = value
+	}
+}
+
+// compact compacts an expanded element, with the property it is the value of.
+func (c *activeContext) compact(activeProperty string, element interface{}) (interface{}, error) {
+	var err error
+	active := c
+	propertyDef := c.terms[activeProperty]
+
+	switch elt := element.(type) {
+	case []interface{}:
+		result := make([]interface{}, 0, len(elt))
+		for _, item := range elt {
+			compacted, err := active.compact(activeProperty, item)
+			if err != nil {
+				return nil, err
+			}
+			if compacted != nil {
+				result = append(result, compacted)
+			}
+		}
+		if len(result) == 1 && !propertyDef.hasContainer("@list") && !propertyDef.hasContainer("@set") && activeProperty != "@graph" && activeProperty != "@list" {
+			return result[0], nil
+		}
+		return result, nil
+	case map[string]interface{}:
+		if propertyDef != nil && propertyDef.hasContext {
+			if active, err = active.process(propertyDef.context, propertyDef.baseURL, nil, true, true); err != nil {
+				return nil, err
+			}
+		}
+		_, hasID := elt["@id"]
+		if isValueObject(elt) || (hasID && len(elt) == 1) {
+			compacted := active.compactValue(activeProperty, elt)
+			if _, isMap := compacted.(map[string]interface{}); !isMap || (propertyDef != nil && propertyDef.typeMapping == "@json") {
+				return compacted, nil
+			}
+			if isValueObject(elt) {
+				return compacted, nil
+			}
+		}
+		if isListObject(elt) && propertyDef.hasContainer("@list") {
+			return active.compact(activeProperty, elt["@list"])
+		}
+		return active.compactObject(activeProperty, elt)
+	}
+	return element, nil
+}
+
+// compactObject compacts an expanded node object.
+func (c *activeContext) compactObject(activeProperty string, elt map[string]interface{}) (interface{}, error) {
+	var err error
+	active := c
+	insideReverse := activeProperty == "@reverse"
+	result := make(map[string]interface{})
+
+	// apply the contexts scoped to the types of the node
+	typeScoped := active
+	if types, hasType := elt["@type"]; hasType {
+		compactedTypes := make([]string, 0)
+		for _, item := range asArray(types) {
+			if typeStr, isString := item.(string); isString {
+				compactedTypes = append(compactedTypes, typeScoped.compactIRI(typeStr, nil, true, false))
+			}
+		}
+		sort.Strings(compactedTypes)
+		for _, term := range compactedTypes {
+			if def, isTerm := typeScoped.terms[term]; isTerm && def.hasContext {
+				if active, err = active.process(def.context, def.baseURL, nil, false, false); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, expandedProperty := range sortedKeys(elt) {
+		expandedValue := elt[expandedProperty]
+		switch expandedProperty {
+		case "@id":
+			result[active.compactIRI("@id", nil, true, false)] = active.compactIRI(expandedValue.(string), nil, false, false)
+			continue
+		case "@type":
+			types := make([]interface{}, 0)
+			for _, item := range asArray(expandedValue) {
+				if typeStr, isString := item.(string); isString {
+					types = append(types, typeScoped.compactIRI(typeStr, nil, true, false))
+				} else {
+					types = append(types, item)
+				}
+			}
+			alias := active.compactIRI("@type", nil, true, false)
+			typeDef := active.terms["@type"]
+			if len(types) == 1 && !typeDef.hasContainer("@set") {
+				result[alias] = types[0]
+			} else {
+				result[alias] = types
+			}
+			continue
+		case "@reverse":
+			compacted, err := active.compact("@reverse", expandedValue)
+			if err != nil {
+				return nil, err
+			}
+			reverseMap, _ := compacted.(map[string]interface{})
+			for _, property := range sortedKeys(reverseMap) {
+				if def, isTerm := active.terms[property]; isTerm && def != nil && def.reverse {
+					addCompactedValue(result, property, reverseMap[property], def.hasContainer("@set"))
+					delete(reverseMap, property)
+				}
+			}
+			if len(reverseMap) > 0 {
+				result[active.compactIRI("@reverse", nil, true, false)] = reverseMap
+			}
+			continue
+		case "@preserve":
+			compacted, err := active.compact(activeProperty, expandedValue)
+			if err != nil {
+				return nil, err
+			}
+			if array, isArray := compacted.([]interface{}); !isArray || len(array) > 0 {
+				result["@preserve"] = compacted
+			}
+			continue
+		case "@index":
+			if c.terms[activeProperty].hasContainer("@index") {
+				continue
+			}
+			result[active.compactIRI("@index", nil, true, false)] = expandedValue
+			continue
+		case "@value", "@language", "@direction", "@explicit", "@default", "@embed", "@requireAll", "@omitDefault":
+			result[active.compactIRI(expandedProperty, nil, true, false)] = expandedValue
+			continue
+		}
+
+		values := asArray(expandedValue)
+		if len(values) == 0 {
+			itemActiveProperty := active.compactIRI(expandedProperty, expandedValue, true, insideReverse)
+			addCompactedValue(result, itemActiveProperty, []interface{}{}, true)
+		}
+		for _, expandedItem := range values {
+			itemActiveProperty := active.compactIRI(expandedProperty, expandedItem, true, insideReverse)
+			def := active.terms[itemActiveProperty]
+			asArrayValue := def.hasContainer("@set") || def.hasContainer("@list") || itemActiveProperty == "@graph" || itemActiveProperty == "@list"
+			var toCompact interface{} = expandedItem
+			if isListObject(expandedItem) {
+				toCompact = expandedItem.(map[string]interface{})["@list"]
+			} else if isGraphObject(expandedItem) {
+				toCompact = expandedItem.(map[string]interface{})["@graph"]
+			}
+			compactedItem, err := active.compact(itemActiveProperty, toCompact)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case isListObject(expandedItem):
+				compactedItem = asArray(compactedItem)
+				if !def.hasContainer("@list") {
+					wrapped := map[string]interface{}{active.compactIRI("@list", nil, true, false): compactedItem}
+					if index, hasIndex := expandedItem.(map[string]interface{})["@index"]; hasIndex {
+						wrapped[active.compactIRI("@index", nil, true, false)] = index
+					}
+					addCompactedValue(result, itemActiveProperty, wrapped, asArrayValue)
+				} else {
+					result[itemActiveProperty] = compactedItem
+				}
+			case isGraphObject(expandedItem):
+				itemMap := expandedItem.(map[string]interface{})
+				wrapped := map[string]interface{}{active.compactIRI("@graph", nil, true, false): asArray(compactedItem)}
+				if id, hasID := itemMap["@id"].(string); hasID {
+					wrapped[active.compactIRI("@id", nil, true, false)] = active.compactIRI(id, nil, false, false)
+				}
+				addCompactedValue(result, itemActiveProperty, wrapped, asArrayValue)
+			case def.hasContainer("@language") || def.hasContainer("@index") || def.hasContainer("@id") || def.hasContainer("@type"):
+				if err = active.compactMapContainer(result, itemActiveProperty, def, expandedItem.(map[string]interface{}), compactedItem, asArrayValue); err != nil {
+					return nil, err
+				}
+			default:
+				addCompactedValue(result, itemActiveProperty, compactedItem, asArrayValue)
+			}
+		}
+	}
+	return result, nil
+}
+
+// compactMapContainer adds a compacted item to a language, index, id or type map.
+func (c *activeContext) compactMapContainer(result map[string]interface{}, property string, def *termDefinition, expandedItem map[string]interface{}, compactedItem interface{}, asArrayValue bool) error {
+	mapObject, isMap := result[property].(map[string]interface{})
+	if !isMap {
+		mapObject = make(map[string]interface{})
+		result[property] = mapObject
+	}
+	mapKey := ""
+	compactedMap, _ := compactedItem.(map[string]interface{})
+	switch {
+	case def.hasContainer("@language"):
+		if value, hasValue := compactedMap[c.compactIRI("@value", nil, true, false)]; hasValue && isValueObject(expandedItem) {
+			compactedItem = value
+		}
+		mapKey, _ = expandedItem["@language"].(string)
+	case def.hasContainer("@index"):
+		mapKey, _ = expandedItem["@index"].(string)
+		if compactedMap != nil {
+			delete(compactedMap, c.compactIRI("@index", nil, true, false))
+		}
+	case def.hasContainer("@id"):
+		idKey := c.compactIRI("@id", nil, true, false)
+		if id, hasID := compactedMap[idKey].(string); hasID {
+			mapKey = c.compactIRI(c.expandIRIOrValue(id), nil, false, false)
+			delete(compactedMap, idKey)
+		}
+	case def.hasContainer("@type"):
+		typeKey := c.compactIRI("@type", nil, true, false)
+		types := asArray(compactedMap[typeKey])
+		if len(types) > 0 {
+			mapKey, _ = types[0].(string)
+			types = types[1:]
+		}
+		if len(types) == 1 {
+			compactedMap[typeKey] = types[0]
+		} else if len(types) > 1 {
+			compactedMap[typeKey] = types
+		} else {
+			delete(compactedMap, typeKey)
+		}
+		// a node which only has an id is compacted again, as a node reference
+		if id, hasID := expandedItem["@id"]; hasID && len(compactedMap) == 1 {
+			recompacted, err := c.compact(property, map[string]interface{}{"@id": id})
+			if err != nil {
+				return err
+			}
+			compactedItem = recompacted
+		}
+	}
+	if mapKey == "" {
+		mapKey = c.compactIRI("@none", nil, true, false)
+	}
+	addCompactedValue(mapObject, mapKey, compactedItem, asArrayValue)
+	return nil
+}
+
+// expandIRIOrValue expands a compacted IRI, or returns it unchanged if it cannot be expanded.
+func (c *activeContext) expandIRIOrValue(value string) string {
+	expanded, err := c.expandIRI(value, true, false, nil, nil)
+	if err != nil || expanded == "" {
+		return value
+	}
+	return expanded
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	inputs := []string{
+		`[{"@id": "http://example.org/me", "http://xmlns.com/foaf/0.1/name": [{"@value": "Thomas"}]}]`,
+		`[{"@id": "http://example.org/a", "@type": ["http://schema.org/Book"], "http://schema.org/name": [{"@value": "Joseki", "@language": "en"}]},
+		  {"@id": "http://example.org/b", "http://schema.org/name": [{"@value": "Other", "@language": "fr"}]}]`,
+		`[{"@id": "_:me", "http://xmlns.com/foaf/0.1/knows": [{"@id": "http://example.org/you"}, {"@id": "_:him"}]}]`,
+		`[{"@id": "http://example.org/doc", "http://example.org/list": [{"@list": [{"@value": 1}, {"@value": true}]}]}]`,
+		`[{"@id": "http://example.org/a", "http://www.w3.org/2000/01/rdf-schema#label": [{"@value": "Hello", "@language": "en"},
+		   {"@value": "Bonjour", "@language": "fr"}, {"@value": "Salut", "@language": "fr"}]}]`,
+		`[{"@id": "http://example.org/me", "@reverse": {"http://xmlns.com/foaf/0.1/knows": [{"@id": "http://example.org/you"}]}}]`,
+		`[{"@id": "http://example.org/a", "http://example.org/date": [{"@value": "2016-06-01", "@type": "http://www.w3.org/2001/XMLSchema#date"}]}]`,
+	}
+	contexts := []string{
+		`{"name": "http://xmlns.com/foaf/0.1/name"}`,
+		`{"@vocab": "http://schema.org/", "@language": "en"}`,
+		`{"foaf": "http://xmlns.com/foaf/0.1/", "knows": {"@id": "foaf:knows", "@type": "@id"}}`,
+		`{"@base": "http://example.org/", "list": {"@id": "http://example.org/list", "@container": "@list"}}`,
+		`{"label": {"@id": "http://www.w3.org/2000/01/rdf-schema#label", "@container": "@language"}}`,
+		`{"isKnownBy": {"@reverse": "http://xmlns.com/foaf/0.1/knows", "@type": "@id"}}`,
+		`{"xsd": "http://www.w3.org/2001/XMLSchema#", "date": {"@id": "http://example.org/date", "@type": "xsd:date"}}`,
+	}
+	expected := []string{
+		`{"@id": "http://example.org/me", "name": "Thomas"}`,
+		`{"@graph": [{"@id": "http://example.org/a", "@type": "Book", "name": "Joseki"},
+		  {"@id": "http://example.org/b", "name": {"@value": "Other", "@language": "fr"}}]}`,
+		`{"@id": "_:me", "knows": ["http://example.org/you", "_:him"]}`,
+		`{"@id": "doc", "list": [1, true]}`,
+		`{"@id": "http://example.org/a", "label": {"en": "Hello", "fr": ["Bonjour", "Salut"]}}`,
+		`{"@id": "http://example.org/me", "isKnownBy": "http://example.org/you"}`,
+		`{"@id": "http://example.org/a", "date": "2016-06-01"}`,
+	}
+
+	for i, input := range inputs {
+		context := parseJSON(contexts[i], t)
+		result, err := Compact(parseJSON(input, t), map[string]interface{}{"@context": context}, nil)
+		if err != nil {
+			t.Error("compaction of", input, "shouldn't produce the error :", err)
+			continue
+		}
+		if !reflect.DeepEqual(result["@context"], context) {
+			t.Error("expected the compacted document to contain the context", contexts[i], "but instead got", result["@context"])
+		}
+		delete(result, "@context")
+		resultJSON, _ := json.Marshal(result)
+		if !reflect.DeepEqual(parseJSON(string(resultJSON), t), parseJSON(expected[i], t)) {
+			t.Error("expected the compaction of", input, "to be", expected[i], "but instead got", string(resultJSON))
+		}
+	}
+}
+
+func TestCompactIRI(t *testing.T) {
+	context := parseJSON(`{"@context": {"@base": "http://example.org/base/doc", "ex": "http://example.org/vocab#", "name": "http://xmlns.com/foaf/0.1/name"}}`, t)
+	active, err := newActiveContext("", nil).process(unwrapContext(context), "", nil, false, true)
+	if err != nil {
+		t.Fatal("processing a valid context shouldn't produce the error :", err)
+	}
+	iris := []string{
+		"http://xmlns.com/foaf/0.1/name",
+		"http://example.org/vocab#knows",
+		"http://example.org/base/other",
+		"http://example.org/other/doc",
+		"http://another.org/doc",
+	}
+	expectedVocab := []string{"name", "ex:knows", "http://example.org/base/other", "http://example.org/other/doc", "http://another.org/doc"}
+	expectedRelative := []string{"http://xmlns.com/foaf/0.1/name", "ex:knows", "other", "../other/doc", "http://another.org/doc"}
+
+	for i, iri := range iris {
+		if compacted := active.compactIRI(iri, nil, true, false); compacted != expectedVocab[i] {
+			t.Error("expected", iri, "to be compacted relative to the vocabulary as", expectedVocab[i], "but instead got", compacted)
+		}
+		if compacted := active.compactIRI(iri, nil, false, false); compacted != expectedRelative[i] {
+			t.Error("expected", iri, "to be compacted relative to the base as", expectedRelative[i], "but instead got", compacted)
+		}
+	}
+}
@@ -0,0 +1,618 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"reflect"
+	"strings"
+)
+
+const (
+	// Maximum number of remote contexts which can be included while processing a context
+	maxRemoteContexts = 32
+)
+
+// termDefinition is the definition of a term in a JSON-LD context.
+//
+// A term definition with an empty IRI mapping is a null mapping, which prevents the term from being expanded.
+type termDefinition struct {
+	id          string
+	reverse     bool
+	typeMapping string
+	language    string
+	hasLanguage bool
+	direction   string
+	hasDir      bool
+	container   []string
+	index       string
+	nest        string
+	context     interface{}
+	hasContext  bool
+	baseURL     string
+	prefix      bool
+	protected   bool
+}
+
+// hasContainer returns True if the container mapping of a term contains a given keyword.
+func (d *termDefinition) hasContainer(keyword string) bool {
+	return d != nil && contains(d.container, keyword)
+}
+
+// equivalent returns True if two term definitions are the same, excluding their protected flag.
+func (d *termDefinition) equivalent(other *termDefinition) bool {
+	a, b := *d, *other
+	a.protected, b.protected = false, false
+	return reflect.DeepEqual(a, b)
+}
+
+// activeContext is the context used to interpret the terms & IRIs of a JSON-LD document.
+//
+// JSON-LD context reference : https://www.w3.org/TR/json-ld11/#the-context
+type activeContext struct {
+	base      string
+	original  string
+	vocab     string
+	hasVocab  bool
+	language  string
+	direction string
+	terms     map[string]*termDefinition
+	previous  *activeContext
+	inverse   map[string]interface{}
+	options   *Options
+}
+
+// newActiveContext creates a new empty context, with a base IRI.
+func newActiveContext(base string, options *Options) *activeContext {
+	return &activeContext{base: base, original: base, terms: make(map[string]*termDefinition), options: options}
+}
+
+// clone returns a copy of the context.
+func (c *activeContext) clone() *activeContext {
+	res := *c
+	res.terms = make(map[string]*termDefinition, len(c.terms))
+	for term, def := range c.terms {
+		res.terms[term] = def
+	}
+	res.inverse = nil
+	return &res
+}
+
+// resolve resolves a relative IRI against a base IRI.
+// The IRI is left unchanged if it cannot be resolved.
+func resolve(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+	iri, err := rdf.ResolveIRI(base, ref)
+	if err != nil {
+		return ref
+	}
+	return iri
+}
+
+// process runs the Context Processing algorithm, which updates the context with a local context.
+//
+// Context Processing reference : https://www.w3.org/TR/json-ld11-api/#context-processing-algorithm
+func (c *activeContext) process(localContext interface{}, baseURL string, remoteContexts []string, overrideProtected, propagate bool) (*activeContext, error) {
+	result := c.clone()
+	if obj, isMap := localContext.(map[string]interface{}); isMap {
+		if value, isBool := obj["@propagate"].(bool); isBool {
+			propagate = value
+		}
+	}
+	if !propagate && result.previous == nil {
+		result.previous = c
+	}
+
+	contexts := asArray(localContext)
+	if localContext == nil {
+		contexts = []interface{}{nil}
+	}
+	for _, context := range contexts {
+		switch ctx := context.(type) {
+		case nil:
+			if !overrideProtected {
+				for _, def := range result.terms {
+					if def.protected {
+						return nil, newError("invalid context nullification", "a context with protected terms cannot be nullified")
+					}
+				}
+			}
+			previous := result.previous
+			result = newActiveContext(c.original, c.options)
+			if !propagate {
+				result.previous = previous
+			}
+			continue
+		case string:
+			ref := resolve(baseURL, ctx)
+			if len(remoteContexts) > maxRemoteContexts {
+				return nil, newError("context overflow", "too many remote contexts included while loading "+ref)
+			}
+			doc, err := c.options.loader().LoadDocument(ref)
+			if err != nil {
+				return nil, newError("loading remote context failed", ref)
+			}
+			docMap, isMap := doc.Document.(map[string]interface{})
+			if !isMap {
+				return nil, newError("invalid remote context", ref)
+			}
+			remoteCtx, hasCtx := docMap["@context"]
+			if !hasCtx {
+				return nil, newError("invalid remote context", ref)
+			}
+			result, err = result.process(remoteCtx, doc.DocumentURL, append(remoteContexts, ref), overrideProtected, true)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case map[string]interface{}:
+			if err := result.processMap(ctx, baseURL, remoteContexts, overrideProtected); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, newError("invalid local context", "")
+		}
+	}
+	return result, nil
+}
+
+// processMap updates the context with the definitions of a local context represented as a map.
+func (c *activeContext) processMap(ctx map[string]interface{}, baseURL string, remoteContexts []string, overrideProtected bool) error {
+	if version, hasVersion := ctx["@version"]; hasVersion {
+		if number, isNumber := version.(float64); !isNumber || number != 1.1 {
+			return newError("invalid @version value", "")
+		}
+	}
+	if importValue, hasImport := ctx["@import"]; hasImport {
+		ref, isString := importValue.(string)
+		if !isString {
+			return newError("invalid @import value", "")
+		}
+		ref = resolve(baseURL, ref)
+		doc, err := c.options.loader().LoadDocument(ref)
+		if err != nil {
+			return newError("loading remote context failed", ref)
+		}
+		docMap, isMap := doc.Document.(map[string]interface{})
+		if !isMap {
+			return newError("invalid remote context", ref)
+		}
+		imported, isMap := docMap["@context"].(map[string]interface{})
+		if !isMap {
+			return newError("invalid remote context", ref)
+		}
+		if _, hasImport := imported["@import"]; hasImport {
+			return newError("invalid context entry", "an imported context cannot contain @import")
+		}
+		merged := make(map[string]interface{}, len(imported)+len(ctx))
+		for key, value := range imported {
+			merged[key] = value
+		}
+		for key, value := range ctx {
+			merged[key] = value
+		}
+		delete(merged, "@import")
+		ctx = merged
+	}
+	if value, hasBase := ctx["@base"]; hasBase && len(remoteContexts) == 0 {
+		switch base := value.(type) {
+		case nil:
+			c.base = ""
+		case string:
+			if isAbsoluteIRI(base) {
+				c.base = base
+			} else if c.base != "" {
+				c.base = resolve(c.base, base)
+			} else {
+				return newError("invalid base IRI", base)
+			}
+		default:
+			return newError("invalid base IRI", "")
+		}
+	}
+	if value, hasVocab := ctx["@vocab"]; hasVocab {
+		switch vocab := value.(type) {
+		case nil:
+			c.vocab, c.hasVocab = "", false
+		case string:
+			expanded, err := c.expandIRI(vocab, true, true, nil, nil)
+			if err != nil {
+				return err
+			}
+			if !isAbsoluteIRI(expanded) && !isBlankNodeID(expanded) && expanded != "" {
+				return newError("invalid vocab mapping", vocab)
+			}
+			c.vocab, c.hasVocab = expanded, true
+		default:
+			return newError("invalid vocab mapping", "")
+		}
+	}
+	if value, hasLanguage := ctx["@language"]; hasLanguage {
+		switch language := value.(type) {
+		case nil:
+			c.language = ""
+		case string:
+			c.language = strings.ToLower(language)
+		default:
+			return newError("invalid default language", "")
+		}
+	}
+	if value, hasDirection := ctx["@direction"]; hasDirection {
+		switch direction := value.(type) {
+		case nil:
+			c.direction = ""
+		case string:
+			if direction != "ltr" && direction != "rtl" {
+				return newError("invalid base direction", direction)
+			}
+			c.direction = direction
+		default:
+			return newError("invalid base direction", "")
+		}
+	}
+	if value, hasPropagate := ctx["@propagate"]; hasPropagate {
+		if _, isBool := value.(bool); !isBool {
+			return newError("invalid @propagate value", "")
+		}
+	}
+	protected := false
+	if value, hasProtected := ctx["@protected"]; hasProtected {
+		isBool := false
+		if protected, isBool = value.(bool); !isBool {
+			return newError("invalid @protected value", "")
+		}
+	}
+
+	// create the term definitions
+	defined := make(map[string]bool)
+	for _, term := range sortedKeys(ctx) {
+		switch term {
+		case "@base", "@direction", "@import", "@language", "@propagate", "@protected", "@version", "@vocab":
+			continue
+		}
+		if err := c.createTermDefinition(ctx, term, defined, baseURL, protected, overrideProtected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTermDefinition runs the Create Term Definition algorithm, which adds a term of a local context to the active context.
+//
+// Create Term Definition reference : https://www.w3.org/TR/json-ld11-api/#create-term-definition
+func (c *activeContext) createTermDefinition(local map[string]interface{}, term string, defined map[string]bool, baseURL string, defaultProtected, overrideProtected bool) error {
+	if done, visited := defined[term]; visited {
+		if done {
+			return nil
+		}
+		return newError("cyclic IRI mapping", term)
+	}
+	if term == "" {
+		return newError("invalid term definition", "the empty string cannot be defined as a term")
+	}
+	defined[term] = false
+	value := local[term]
+
+	if term == "@type" {
+		obj, isMap := value.(map[string]interface{})
+		if !isMap || len(obj) == 0 {
+			return newError("keyword redefinition", term)
+		}
+		for key, entry := range obj {
+			if (key != "@container" || entry != "@set") && key != "@protected" {
+				return newError("keyword redefinition", term)
+			}
+		}
+	} else if isKeyword(term) {
+		return newError("keyword redefinition", term)
+	} else if keywordForm.MatchString(term) {
+		// terms having the form of a keyword are ignored
+		defined[term] = true
+		return nil
+	}
+
+	previous := c.terms[term]
+	delete(c.terms, term)
+	simpleTerm := false
+	var obj map[string]interface{}
+	switch v := value.(type) {
+	case nil:
+		obj = map[string]interface{}{"@id": nil}
+	case string:
+		obj = map[string]interface{}{"@id": v}
+		simpleTerm = true
+	case map[string]interface{}:
+		obj = v
+	default:
+		return newError("invalid term definition", term)
+	}
+
+	def := &termDefinition{protected: defaultProtected}
+	if protected, hasProtected := obj["@protected"]; hasProtected {
+		isBool := false
+		if def.protected, isBool = protected.(bool); !isBool {
+			return newError("invalid @protected value", term)
+		}
+	}
+
+	if typeValue, hasType := obj["@type"]; hasType {
+		typeStr, isString := typeValue.(string)
+		if !isString {
+			return newError("invalid type mapping", term)
+		}
+		expanded, err := c.expandIRI(typeStr, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if expanded != "@id" && expanded != "@vocab" && expanded != "@json" && expanded != "@none" && !isAbsoluteIRI(expanded) {
+			return newError("invalid type mapping", typeStr)
+		}
+		def.typeMapping = expanded
+	}
+
+	if reverseValue, hasReverse := obj["@reverse"]; hasReverse {
+		if _, hasID := obj["@id"]; hasID {
+			return newError("invalid reverse property", term)
+		}
+		if _, hasNest := obj["@nest"]; hasNest {
+			return newError("invalid reverse property", term)
+		}
+		reverse, isString := reverseValue.(string)
+		if !isString {
+			return newError("invalid IRI mapping", term)
+		}
+		if keywordForm.MatchString(reverse) {
+			defined[term] = true
+			return nil
+		}
+		expanded, err := c.expandIRI(reverse, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if !isAbsoluteIRI(expanded) && !isBlankNodeID(expanded) {
+			return newError("invalid IRI mapping", term)
+		}
+		def.id, def.reverse = expanded, true
+		if containerValue, hasContainer := obj["@container"]; hasContainer {
+			if containerValue != "@set" && containerValue != "@index" && containerValue != nil {
+				return newError("invalid reverse property", term)
+			}
+			if containerValue != nil {
+				def.container = []string{containerValue.(string)}
+			}
+		}
+		c.terms[term] = def
+		defined[term] = true
+		return nil
+	}
+
+	idValue, hasID := obj["@id"]
+	if hasID && idValue != term {
+		switch id := idValue.(type) {
+		case nil:
+			// null mapping: the term is defined but cannot be expanded
+			def.id = ""
+		case string:
+			if !isKeyword(id) && keywordForm.MatchString(id) {
+				defined[term] = true
+				return nil
+			}
+			expanded, err := c.expandIRI(id, false, true, local, defined)
+			if err != nil {
+				return err
+			}
+			if !isKeyword(expanded) && !isAbsoluteIRI(expanded) && !isBlankNodeID(expanded) {
+				return newError("invalid IRI mapping", term)
+			}
+			if expanded == "@context" {
+				return newError("invalid keyword alias", term)
+			}
+			def.id = expanded
+			// a term which looks like an IRI must be expanded to the same IRI
+			if (len(term) > 2 && strings.Contains(term[1:len(term)-1], ":")) || strings.Contains(term, "/") {
+				defined[term] = true
+				termIRI, err := c.expandIRI(term, false, true, local, defined)
+				if err != nil {
+					return err
+				}
+				if termIRI != def.id {
+					return newError("invalid IRI mapping", term)
+				}
+			}
+			if !strings.Contains(term, ":") && !strings.Contains(term, "/") && simpleTerm {
+				def.prefix = isBlankNodeID(def.id) || strings.ContainsAny(def.id[len(def.id)-1:], ":/?#[]@")
+			}
+		default:
+			return newError("invalid IRI mapping", term)
+		}
+	} else if colon := strings.Index(term[1:], ":"); colon >= 0 {
+		prefix, suffix := term[:colon+1], term[colon+2:]
+		if _, inLocal := local[prefix]; inLocal {
+			if err := c.createTermDefinition(local, prefix, defined, baseURL, defaultProtected, overrideProtected); err != nil {
+				return err
+			}
+		}
+		if prefixDef, isTerm := c.terms[prefix]; isTerm {
+			def.id = prefixDef.id + suffix
+		} else {
+			def.id = term
+		}
+	} else if strings.Contains(term, "/") {
+		expanded, err := c.expandIRI(term, false, true, local, defined)
+		if err != nil {
+			return err
+		}
+		if !isAbsoluteIRI(expanded) {
+			return newError("invalid IRI mapping", term)
+		}
+		def.id = expanded
+	} else if term == "@type" {
+		def.id = "@type"
+	} else if c.hasVocab {
+		def.id = c.vocab + term
+	} else {
+		return newError("invalid IRI mapping", term)
+	}
+
+	if containerValue, hasContainer := obj["@container"]; hasContainer {
+		for _, entry := range asArray(containerValue) {
+			container, isString := entry.(string)
+			if !isString {
+				return newError("invalid container mapping", term)
+			}
+			switch container {
+			case "@list", "@set", "@index", "@language", "@id", "@type", "@graph":
+				def.container = append(def.container, container)
+			default:
+				return newError("invalid container mapping", term)
+			}
+		}
+		if def.hasContainer("@list") && len(def.container) > 1 {
+			return newError("invalid container mapping", term)
+		}
+		if def.hasContainer("@type") {
+			if def.typeMapping == "" {
+				def.typeMapping = "@id"
+			} else if def.typeMapping != "@id" && def.typeMapping != "@vocab" {
+				return newError("invalid type mapping", term)
+			}
+		}
+	}
+
+	if indexValue, hasIndex := obj["@index"]; hasIndex {
+		index, isString := indexValue.(string)
+		if !def.hasContainer("@index") || !isString || isKeyword(index) {
+			return newError("invalid term definition", term)
+		}
+		def.index = index
+	}
+	if contextValue, hasContext := obj["@context"]; hasContext {
+		// check that the scoped context is valid, but keep it to be processed when the term is used
+		if _, err := c.process(contextValue, baseURL, nil, true, true); err != nil {
+			return newError("invalid scoped context", term+" : "+err.Error())
+		}
+		def.context, def.hasContext, def.baseURL = contextValue, true, baseURL
+	}
+	if languageValue, hasLanguage := obj["@language"]; hasLanguage && !hasTypeKey(obj) {
+		switch language := languageValue.(type) {
+		case nil:
+			def.language, def.hasLanguage = "", true
+		case string:
+			def.language, def.hasLanguage = strings.ToLower(language), true
+		default:
+			return newError("invalid language mapping", term)
+		}
+	}
+	if directionValue, hasDirection := obj["@direction"]; hasDirection && !hasTypeKey(obj) {
+		switch direction := directionValue.(type) {
+		case nil:
+			def.direction, def.hasDir = "", true
+		case string:
+			if direction != "ltr" && direction != "rtl" {
+				return newError("invalid base direction", term)
+			}
+			def.direction, def.hasDir = direction, true
+		default:
+			return newError("invalid base direction", term)
+		}
+	}
+	if nestValue, hasNest := obj["@nest"]; hasNest {
+		nest, isString := nestValue.(string)
+		if !isString || (isKeyword(nest) && nest != "@nest") {
+			return newError("invalid @nest value", term)
+		}
+		def.nest = nest
+	}
+	if prefixValue, hasPrefix := obj["@prefix"]; hasPrefix {
+		prefix, isBool := prefixValue.(bool)
+		if strings.Contains(term, ":") || strings.Contains(term, "/") {
+			return newError("invalid term definition", term)
+		}
+		if !isBool {
+			return newError("invalid @prefix value", term)
+		}
+		def.prefix = prefix
+		if prefix && isKeyword(def.id) {
+			return newError("invalid term definition", term)
+		}
+	}
+	for key := range obj {
+		switch key {
+		case "@id", "@reverse", "@container", "@context", "@direction", "@index", "@language", "@nest", "@prefix", "@protected", "@type":
+		default:
+			return newError("invalid term definition", term+" : unexpected entry "+key)
+		}
+	}
+
+	// protected terms can only be redefined with the same definition
+	if previous != nil && previous.protected && !overrideProtected {
+		if !previous.equivalent(def) {
+			return newError("protected term redefinition", term)
+		}
+		def = previous
+	}
+	c.terms[term] = def
+	defined[term] = true
+	return nil
+}
+
+// hasTypeKey returns True if a term definition given as a map has a @type entry.
+func hasTypeKey(obj map[string]interface{}) bool {
+	_, hasType := obj["@type"]
+	return hasType
+}
+
+// expandIRI runs the IRI Expansion algorithm, which expands a string into an IRI, a blank node identifier or a keyword.
+// An empty string is returned if the value is mapped to null.
+//
+// IRI Expansion reference : https://www.w3.org/TR/json-ld11-api/#iri-expansion
+func (c *activeContext) expandIRI(value string, documentRelative, vocab bool, local map[string]interface{}, defined map[string]bool) (string, error) {
+	if isKeyword(value) {
+		return value, nil
+	}
+	if keywordForm.MatchString(value) {
+		return "", nil
+	}
+	if local != nil {
+		if _, inLocal := local[value]; inLocal && !defined[value] {
+			if err := c.createTermDefinition(local, value, defined, "", false, false); err != nil {
+				return "", err
+			}
+		}
+	}
+	if def, isTerm := c.terms[value]; isTerm {
+		if isKeyword(def.id) || vocab {
+			return def.id, nil
+		}
+	}
+
+	if colon := strings.Index(value, ":"); colon > 0 {
+		prefix, suffix := value[:colon], value[colon+1:]
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return value, nil
+		}
+		if local != nil {
+			if _, inLocal := local[prefix]; inLocal && !defined[prefix] {
+				if err := c.createTermDefinition(local, prefix, defined, "", false, false); err != nil {
+					return "", err
+				}
+			}
+		}
+		if def, isTerm := c.terms[prefix]; isTerm && def.id != "" && def.prefix {
+			return def.id + suffix, nil
+		}
+		if isAbsoluteIRI(value) {
+			return value, nil
+		}
+	}
+	if vocab && c.hasVocab {
+		return c.vocab + value, nil
+	} else if documentRelative {
+		return resolve(c.base, value), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import "testing"
+
+func TestProcessContext(t *testing.T) {
+	loader := NewMapLoader(map[string]string{
+		"http://example.org/context.jsonld": `{"@context": {"foaf": "http://xmlns.com/foaf/0.1/", "name": "foaf:name"}}`,
+	})
+	active := newActiveContext("http://example.org/base/", &Options{DocumentLoader: loader})
+	local := parseJSON(`["http://example.org/context.jsonld", {
+		"@vocab": "http://schema.org/",
+		"knows": {"@id": "foaf:knows", "@type": "@id", "@container": "@set"},
+		"ex": {"@id": "http://example.org/", "@prefix": true},
+		"label": {"@id": "http://www.w3.org/2000/01/rdf-schema#label", "@language": "EN"},
+		"ignored": null
+	}]`, t)
+
+	ctx, err := active.process(local, active.base, nil, false, true)
+	if err != nil {
+		t.Fatal("processing a valid context shouldn't produce the error :", err)
+	}
+	terms := [][]string{
+		[]string{"name", "http://xmlns.com/foaf/0.1/name"},
+		[]string{"knows", "http://xmlns.com/foaf/0.1/knows"},
+		[]string{"label", "http://www.w3.org/2000/01/rdf-schema#label"},
+		[]string{"ignored", ""},
+	}
+	for _, term := range terms {
+		if def, isTerm := ctx.terms[term[0]]; !isTerm || def.id != term[1] {
+			t.Error("expected the term", term[0], "to be mapped to", term[1])
+		}
+	}
+	if def := ctx.terms["knows"]; def.typeMapping != "@id" || !def.hasContainer("@set") {
+		t.Error("expected the term knows to have the type mapping @id and the container @set")
+	}
+	if def := ctx.terms["label"]; def.language != "en" || !def.hasLanguage {
+		t.Error("expected the term label to have the language mapping en")
+	}
+
+	iris := [][]string{
+		[]string{"foaf:age", "http://xmlns.com/foaf/0.1/age"},
+		[]string{"ex:foo", "http://example.org/foo"},
+		[]string{"Book", "http://schema.org/Book"},
+		[]string{"ignored", ""},
+		[]string{"http://other.org/bar", "http://other.org/bar"},
+		[]string{"_:b0", "_:b0"},
+		[]string{"@type", "@type"},
+	}
+	for _, iri := range iris {
+		if expanded, _ := ctx.expandIRI(iri[0], false, true, nil, nil); expanded != iri[1] {
+			t.Error("expected", iri[0], "to be expanded to", iri[1], "but instead got", expanded)
+		}
+	}
+	if expanded, _ := ctx.expandIRI("../doc", true, false, nil, nil); expanded != "http://example.org/doc" {
+		t.Error("expected ../doc to be resolved to http://example.org/doc but instead got", expanded)
+	}
+}
+
+func TestProcessContextErrors(t *testing.T) {
+	inputs := []string{
+		`{"a": "b:c", "b": "a:d"}`,
+		`{"@type": "http://example.org/type"}`,
+		`{"term": {"@id": "http://example.org/term", "@container": "@foo"}}`,
+		`{"term": {"@reverse": "http://example.org/term", "@id": "http://example.org/term"}}`,
+		`{"term": 22}`,
+		`"http://example.org/unknown.jsonld"`,
+		`22`,
+		`{"term": {"@id": "http://example.org/term", "@type": "@foo"}}`,
+		`{"@version": 1.0}`,
+	}
+	codes := []string{
+		"cyclic IRI mapping",
+		"keyword redefinition",
+		"invalid container mapping",
+		"invalid reverse property",
+		"invalid term definition",
+		"loading remote context failed",
+		"invalid local context",
+		"invalid type mapping",
+		"invalid @version value",
+	}
+
+	for i, input := range inputs {
+		active := newActiveContext("", &Options{DocumentLoader: NewMapLoader(nil)})
+		_, err := active.process(parseJSON(input, t), "", nil, false, true)
+		if jsonErr, isJSONErr := err.(*Error); !isJSONErr || jsonErr.Code != codes[i] {
+			t.Error("expected the processing of", input, "to produce the error", codes[i], "but instead got", err)
+		}
+	}
+}
+
+func TestProtectedContext(t *testing.T) {
+	active := newActiveContext("", &Options{})
+	ctx, err := active.process(parseJSON(`{"@protected": true, "name": "http://xmlns.com/foaf/0.1/name"}`, t), "", nil, false, true)
+	if err != nil {
+		t.Fatal("processing a valid context shouldn't produce the error :", err)
+	}
+	// redefine the term with the same definition is allowed
+	if _, err = ctx.process(parseJSON(`{"name": "http://xmlns.com/foaf/0.1/name"}`, t), "", nil, false, true); err != nil {
+		t.Error("redefining a protected term with the same definition shouldn't produce the error :", err)
+	}
+	if _, err = ctx.process(parseJSON(`{"name": "http://schema.org/name"}`, t), "", nil, false, true); err == nil {
+		t.Error("redefining a protected term should produce an error")
+	}
+	if _, err = ctx.process(nil, "", nil, false, true); err == nil {
+		t.Error("nullifying a context with protected terms should produce an error")
+	}
+}
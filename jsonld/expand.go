@@ -0,0 +1,622 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"sort"
+	"strings"
+)
+
+// Expand runs the Expansion algorithm on a JSON-LD document, which removes its context
+// and represents all its IRIs, types and values in an explicit form.
+//
+// Expansion reference : https://www.w3.org/TR/json-ld11-api/#expansion-algorithm
+func Expand(input interface{}, options *Options) ([]interface{}, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	active := newActiveContext(options.Base, options)
+	if options.ExpandContext != nil {
+		expandContext := options.ExpandContext
+		if obj, isMap := expandContext.(map[string]interface{}); isMap {
+			if ctx, hasCtx := obj["@context"]; hasCtx {
+				expandContext = ctx
+			}
+		}
+		var err error
+		if active, err = active.process(expandContext, options.Base, nil, false, true); err != nil {
+			return nil, err
+		}
+	}
+	return expandDocument(active, input, false)
+}
+
+// expandDocument expands a document, then normalizes the result into an array of node objects.
+func expandDocument(active *activeContext, input interface{}, frameExpansion bool) ([]interface{}, error) {
+	expanded, err := newExpander(frameExpansion).expand(active, "", input, active.base, false)
+	if err != nil {
+		return nil, err
+	}
+	if obj, isMap := expanded.(map[string]interface{}); isMap && len(obj) == 1 {
+		if graph, hasGraph := obj["@graph"]; hasGraph {
+			expanded = graph
+		}
+	}
+	return asArray(expanded), nil
+}
+
+// expander runs the Expansion algorithm.
+type expander struct {
+	frameExpansion bool
+}
+
+// newExpander creates a new expander.
+func newExpander(frameExpansion bool) *expander {
+	return &expander{frameExpansion}
+}
+
+// expand expands an element of a JSON-LD document, with the property it is the value of.
+func (e *expander) expand(active *activeContext, activeProperty string, element interface{}, baseURL string, fromMap bool) (interface{}, error) {
+	var err error
+	if element == nil {
+		return nil, nil
+	}
+	propertyDef := active.terms[activeProperty]
+
+	switch elt := element.(type) {
+	case []interface{}:
+		result := make([]interface{}, 0, len(elt))
+		for _, item := range elt {
+			expanded, err := e.expand(active, activeProperty, item, baseURL, fromMap)
+			if err != nil {
+				return nil, err
+			}
+			if propertyDef.hasContainer("@list") {
+				if array, isArray := expanded.([]interface{}); isArray {
+					expanded = map[string]interface{}{"@list": array}
+				}
+			}
+			if array, isArray := expanded.([]interface{}); isArray {
+				result = append(result, array...)
+			} else if expanded != nil {
+				result = append(result, expanded)
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		return e.expandMap(active, activeProperty, elt, baseURL, fromMap)
+	}
+
+	// the element is a scalar
+	if activeProperty == "" || activeProperty == "@graph" {
+		return nil, nil
+	}
+	if propertyDef != nil && propertyDef.hasContext {
+		if active, err = active.process(propertyDef.context, propertyDef.baseURL, nil, true, true); err != nil {
+			return nil, err
+		}
+	}
+	return active.expandValue(activeProperty, element)
+}
+
+// expandMap expands a JSON-LD map, representing a node object, a value object, a list or a set.
+func (e *expander) expandMap(active *activeContext, activeProperty string, elt map[string]interface{}, baseURL string, fromMap bool) (interface{}, error) {
+	var err error
+	propertyDef := active.terms[activeProperty]
+
+	// revert the type-scoped contexts, unless the element is a value object or a node reference
+	if active.previous != nil && !fromMap {
+		revert := true
+		for _, key := range sortedKeys(elt) {
+			expandedKey, err := active.expandIRI(key, false, true, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if expandedKey == "@value" || (expandedKey == "@id" && len(elt) == 1) {
+				revert = false
+				break
+			}
+		}
+		if revert {
+			active = active.previous
+		}
+	}
+	if propertyDef != nil && propertyDef.hasContext {
+		if active, err = active.process(propertyDef.context, propertyDef.baseURL, nil, true, true); err != nil {
+			return nil, err
+		}
+	}
+	if ctx, hasCtx := elt["@context"]; hasCtx {
+		if active, err = active.process(ctx, baseURL, nil, false, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// apply the contexts scoped to the types of the node
+	typeScoped := active
+	inputType := ""
+	for _, key := range sortedKeys(elt) {
+		expandedKey, err := active.expandIRI(key, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if expandedKey != "@type" {
+			continue
+		}
+		terms := make([]string, 0)
+		for _, value := range asArray(elt[key]) {
+			if term, isString := value.(string); isString {
+				terms = append(terms, term)
+			}
+		}
+		sort.Strings(terms)
+		for _, term := range terms {
+			if def, isTerm := typeScoped.terms[term]; isTerm && def.hasContext {
+				if active, err = active.process(def.context, def.baseURL, nil, false, false); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(terms) > 0 {
+			inputType, err = typeScoped.expandIRI(terms[len(terms)-1], false, true, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := make(map[string]interface{})
+	if err = e.expandObject(active, typeScoped, activeProperty, elt, baseURL, inputType, result); err != nil {
+		return nil, err
+	}
+	return e.postProcess(activeProperty, result)
+}
+
+// expandObject expands the entries of a map, and adds them to the expanded result.
+func (e *expander) expandObject(active, typeScoped *activeContext, activeProperty string, elt map[string]interface{}, baseURL, inputType string, result map[string]interface{}) error {
+	nests := make([]string, 0)
+	for _, key := range sortedKeys(elt) {
+		value := elt[key]
+		if key == "@context" {
+			continue
+		}
+		expandedProperty, err := active.expandIRI(key, false, true, nil, nil)
+		if err != nil {
+			return err
+		}
+		if expandedProperty == "" || (!strings.Contains(expandedProperty, ":") && !isKeyword(expandedProperty)) {
+			continue
+		}
+
+		if isKeyword(expandedProperty) {
+			if activeProperty == "@reverse" {
+				return newError("invalid reverse property map", key)
+			}
+			if _, inResult := result[expandedProperty]; inResult && expandedProperty != "@included" && expandedProperty != "@type" {
+				return newError("colliding keywords", expandedProperty)
+			}
+			var expandedValue interface{}
+			switch expandedProperty {
+			case "@id":
+				id, isString := value.(string)
+				if !isString {
+					if !e.frameExpansion {
+						return newError("invalid @id value", "")
+					}
+					expandedValue = value
+					break
+				}
+				if expandedValue, err = active.expandIRI(id, true, false, nil, nil); err != nil {
+					return err
+				}
+			case "@type":
+				types := make([]interface{}, 0)
+				for _, item := range asArray(value) {
+					typeStr, isString := item.(string)
+					if !isString {
+						if obj, isMap := item.(map[string]interface{}); e.frameExpansion && isMap && len(obj) == 0 {
+							types = append(types, item)
+							continue
+						}
+						return newError("invalid type value", "")
+					}
+					expandedType, err := typeScoped.expandIRI(typeStr, true, true, nil, nil)
+					if err != nil {
+						return err
+					}
+					types = append(types, expandedType)
+				}
+				if previous, hasType := result["@type"]; hasType {
+					types = append(asArray(previous), types...)
+				}
+				if _, isArray := value.([]interface{}); isArray || len(types) > 1 || e.frameExpansion {
+					expandedValue = types
+				} else if len(types) == 1 {
+					expandedValue = types[0]
+				} else {
+					expandedValue = types
+				}
+			case "@graph":
+				graph, err := e.expand(active, "@graph", value, baseURL, false)
+				if err != nil {
+					return err
+				}
+				expandedValue = asArray(graph)
+			case "@included":
+				included, err := e.expand(active, "", value, baseURL, false)
+				if err != nil {
+					return err
+				}
+				for _, item := range asArray(included) {
+					if _, isMap := item.(map[string]interface{}); !isMap || isValueObject(item) || isListObject(item) {
+						return newError("invalid @included value", "")
+					}
+				}
+				if previous, hasIncluded := result["@included"]; hasIncluded {
+					expandedValue = append(asArray(previous), asArray(included)...)
+				} else {
+					expandedValue = asArray(included)
+				}
+			case "@value":
+				switch value.(type) {
+				case map[string]interface{}, []interface{}:
+					if inputType != "@json" {
+						return newError("invalid value object value", "")
+					}
+				}
+				result["@value"] = value
+				continue
+			case "@language":
+				language, isString := value.(string)
+				if !isString {
+					return newError("invalid language-tagged string", "")
+				}
+				expandedValue = strings.ToLower(language)
+			case "@direction":
+				if value != "ltr" && value != "rtl" {
+					return newError("invalid base direction", "")
+				}
+				expandedValue = value
+			case "@index":
+				if _, isString := value.(string); !isString {
+					return newError("invalid @index value", "")
+				}
+				expandedValue = value
+			case "@list":
+				if activeProperty == "" || activeProperty == "@graph" {
+					continue
+				}
+				list, err := e.expand(active, activeProperty, value, baseURL, false)
+				if err != nil {
+					return err
+				}
+				expandedValue = asArray(list)
+			case "@set":
+				if expandedValue, err = e.expand(active, activeProperty, value, baseURL, false); err != nil {
+					return err
+				}
+			case "@reverse":
+				if _, isMap := value.(map[string]interface{}); !isMap {
+					return newError("invalid @reverse value", "")
+				}
+				reverse, err := e.expand(active, "@reverse", value, baseURL, false)
+				if err != nil {
+					return err
+				}
+				if err = mergeReverse(result, reverse.(map[string]interface{})); err != nil {
+					return err
+				}
+				continue
+			case "@nest":
+				nests = append(nests, key)
+				continue
+			case "@explicit", "@embed", "@requireAll", "@omitDefault":
+				if !e.frameExpansion {
+					continue
+				}
+				// framing flags are kept as they are
+				expandedValue = value
+			case "@default":
+				if !e.frameExpansion {
+					continue
+				}
+				if expandedValue, err = e.expand(active, expandedProperty, value, baseURL, false); err != nil {
+					return err
+				}
+				if expandedValue == nil {
+					expandedValue = value
+				}
+			}
+			if expandedValue != nil {
+				result[expandedProperty] = expandedValue
+			}
+			continue
+		}
+
+		def := active.terms[key]
+		expandedValue, err := e.expandProperty(active, key, def, value, baseURL)
+		if err != nil {
+			return err
+		}
+		if expandedValue == nil {
+			continue
+		}
+		if def.hasContainer("@list") && !isListObject(expandedValue) {
+			expandedValue = map[string]interface{}{"@list": asArray(expandedValue)}
+		}
+		if def.hasContainer("@graph") && !def.hasContainer("@id") && !def.hasContainer("@index") {
+			graphs := make([]interface{}, 0)
+			for _, item := range asArray(expandedValue) {
+				graphs = append(graphs, map[string]interface{}{"@graph": asArray(item)})
+			}
+			expandedValue = graphs
+		}
+
+		if def != nil && def.reverse {
+			reverseMap, hasReverse := result["@reverse"].(map[string]interface{})
+			if !hasReverse {
+				reverseMap = make(map[string]interface{})
+				result["@reverse"] = reverseMap
+			}
+			for _, item := range asArray(expandedValue) {
+				if isValueObject(item) || isListObject(item) {
+					return newError("invalid reverse property value", key)
+				}
+				reverseMap[expandedProperty] = append(asArray(reverseMap[expandedProperty]), item)
+			}
+		} else {
+			result[expandedProperty] = append(asArray(result[expandedProperty]), asArray(expandedValue)...)
+		}
+	}
+
+	// expand the properties nested in the @nest entries
+	for _, nestKey := range nests {
+		for _, nested := range asArray(elt[nestKey]) {
+			nestedMap, isMap := nested.(map[string]interface{})
+			if !isMap {
+				return newError("invalid @nest value", "")
+			}
+			for key := range nestedMap {
+				expandedKey, err := active.expandIRI(key, false, true, nil, nil)
+				if err != nil {
+					return err
+				}
+				if expandedKey == "@value" {
+					return newError("invalid @nest value", "")
+				}
+			}
+			if err := e.expandObject(active, typeScoped, activeProperty, nestedMap, baseURL, inputType, result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// expandProperty expands the value of a property which isn't a keyword, depending on the definition of its term.
+func (e *expander) expandProperty(active *activeContext, key string, def *termDefinition, value interface{}, baseURL string) (interface{}, error) {
+	valueMap, isMap := value.(map[string]interface{})
+	switch {
+	case def != nil && def.typeMapping == "@json":
+		return map[string]interface{}{"@value": value, "@type": "@json"}, nil
+	case isMap && def.hasContainer("@language"):
+		result := make([]interface{}, 0)
+		for _, language := range sortedKeys(valueMap) {
+			expandedLanguage, err := active.expandIRI(language, false, true, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range asArray(valueMap[language]) {
+				if item == nil {
+					continue
+				}
+				if _, isString := item.(string); !isString {
+					return nil, newError("invalid language map value", key)
+				}
+				langValue := map[string]interface{}{"@value": item}
+				if expandedLanguage != "@none" {
+					langValue["@language"] = strings.ToLower(language)
+				}
+				if def.hasDir && def.direction != "" {
+					langValue["@direction"] = def.direction
+				} else if !def.hasDir && active.direction != "" {
+					langValue["@direction"] = active.direction
+				}
+				result = append(result, langValue)
+			}
+		}
+		return result, nil
+	case isMap && (def.hasContainer("@index") || def.hasContainer("@type") || def.hasContainer("@id")):
+		return e.expandIndexMap(active, key, def, valueMap, baseURL)
+	}
+	return e.expand(active, key, value, baseURL, false)
+}
+
+// expandIndexMap expands the value of a property using an index, id or type map.
+func (e *expander) expandIndexMap(active *activeContext, key string, def *termDefinition, valueMap map[string]interface{}, baseURL string) (interface{}, error) {
+	result := make([]interface{}, 0)
+	indexKey := "@index"
+	if def.index != "" {
+		indexKey = def.index
+	}
+	for _, index := range sortedKeys(valueMap) {
+		var err error
+		mapContext := active
+		if def.hasContainer("@type") || def.hasContainer("@id") {
+			if active.previous != nil {
+				mapContext = active.previous
+			}
+		}
+		if indexDef, isTerm := active.terms[index]; isTerm && def.hasContainer("@type") && indexDef.hasContext {
+			if mapContext, err = mapContext.process(indexDef.context, indexDef.baseURL, nil, false, true); err != nil {
+				return nil, err
+			}
+		}
+		expandedIndex, err := active.expandIRI(index, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		items, err := e.expand(mapContext, key, asArray(valueMap[index]), baseURL, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range asArray(items) {
+			if def.hasContainer("@graph") && !isGraphObject(item) {
+				item = map[string]interface{}{"@graph": asArray(item)}
+			}
+			obj, isMap := item.(map[string]interface{})
+			if !isMap {
+				result = append(result, item)
+				continue
+			}
+			switch {
+			case def.hasContainer("@index") && indexKey != "@index" && expandedIndex != "@none":
+				// property-valued index: the index is added as a value of the indexing property
+				property, err := active.expandIRI(indexKey, false, true, nil, nil)
+				if err != nil {
+					return nil, err
+				}
+				indexValue, err := active.expandValue(indexKey, index)
+				if err != nil {
+					return nil, err
+				}
+				obj[property] = append([]interface{}{indexValue}, asArray(obj[property])...)
+			case def.hasContainer("@index") && expandedIndex != "@none":
+				if _, hasIndex := obj["@index"]; !hasIndex {
+					obj["@index"] = index
+				}
+			case def.hasContainer("@id") && expandedIndex != "@none":
+				if _, hasID := obj["@id"]; !hasID {
+					if obj["@id"], err = active.expandIRI(index, true, false, nil, nil); err != nil {
+						return nil, err
+					}
+				}
+			case def.hasContainer("@type") && expandedIndex != "@none":
+				obj["@type"] = append([]interface{}{expandedIndex}, asArray(obj["@type"])...)
+			}
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// mergeReverse merges an expanded @reverse map into an expanded node object.
+func mergeReverse(result, reverse map[string]interface{}) error {
+	if doubleReverse, hasReverse := reverse["@reverse"].(map[string]interface{}); hasReverse {
+		for property, items := range doubleReverse {
+			result[property] = append(asArray(result[property]), asArray(items)...)
+		}
+	}
+	for property, items := range reverse {
+		if property == "@reverse" {
+			continue
+		}
+		reverseMap, hasReverse := result["@reverse"].(map[string]interface{})
+		if !hasReverse {
+			reverseMap = make(map[string]interface{})
+			result["@reverse"] = reverseMap
+		}
+		for _, item := range asArray(items) {
+			if isValueObject(item) || isListObject(item) {
+				return newError("invalid reverse property value", property)
+			}
+			reverseMap[property] = append(asArray(reverseMap[property]), item)
+		}
+	}
+	return nil
+}
+
+// postProcess validates an expanded map, and simplifies it when possible.
+func (e *expander) postProcess(activeProperty string, result map[string]interface{}) (interface{}, error) {
+	if value, hasValue := result["@value"]; hasValue {
+		for key := range result {
+			switch key {
+			case "@direction", "@index", "@language", "@type", "@value":
+			default:
+				return nil, newError("invalid value object", "unexpected entry "+key)
+			}
+		}
+		_, hasLanguage := result["@language"]
+		_, hasDirection := result["@direction"]
+		typeValue, hasType := result["@type"]
+		if hasType && (hasLanguage || hasDirection) {
+			return nil, newError("invalid value object", "a value object cannot have both a type and a language")
+		}
+		if typeValue == "@json" {
+			return result, nil
+		}
+		if value == nil {
+			return nil, nil
+		}
+		if _, isString := value.(string); !isString && hasLanguage {
+			return nil, newError("invalid language-tagged value", "")
+		}
+		if hasType {
+			typeStr, isString := typeValue.(string)
+			if !isString || (!e.frameExpansion && !isAbsoluteIRI(typeStr)) {
+				return nil, newError("invalid typed value", "")
+			}
+		}
+	} else if typeValue, hasType := result["@type"]; hasType {
+		result["@type"] = asArray(typeValue)
+	} else if _, hasSet := result["@set"]; hasSet || isListObject(result) {
+		for key := range result {
+			if key != "@set" && key != "@list" && key != "@index" {
+				return nil, newError("invalid set or list object", "")
+			}
+		}
+		if hasSet {
+			return result["@set"], nil
+		}
+	}
+
+	if _, hasLanguage := result["@language"]; hasLanguage && len(result) == 1 {
+		return nil, nil
+	}
+	if activeProperty == "" || activeProperty == "@graph" {
+		_, hasValue := result["@value"]
+		_, hasList := result["@list"]
+		_, hasID := result["@id"]
+		if len(result) == 0 || hasValue || hasList || (!e.frameExpansion && hasID && len(result) == 1) {
+			return nil, nil
+		}
+	}
+	return result, nil
+}
+
+// expandValue runs the Value Expansion algorithm, which expands a scalar value into a value object or a node reference.
+//
+// Value Expansion reference : https://www.w3.org/TR/json-ld11-api/#value-expansion
+func (c *activeContext) expandValue(activeProperty string, value interface{}) (interface{}, error) {
+	def := c.terms[activeProperty]
+	if str, isString := value.(string); isString && def != nil {
+		if def.typeMapping == "@id" {
+			id, err := c.expandIRI(str, true, false, nil, nil)
+			return map[string]interface{}{"@id": id}, err
+		} else if def.typeMapping == "@vocab" {
+			id, err := c.expandIRI(str, true, true, nil, nil)
+			return map[string]interface{}{"@id": id}, err
+		}
+	}
+	result := map[string]interface{}{"@value": value}
+	if def != nil && def.typeMapping != "" && def.typeMapping != "@id" && def.typeMapping != "@vocab" && def.typeMapping != "@none" {
+		result["@type"] = def.typeMapping
+	} else if _, isString := value.(string); isString {
+		language, direction := c.language, c.direction
+		if def != nil && def.hasLanguage {
+			language = def.language
+		}
+		if def != nil && def.hasDir {
+			direction = def.direction
+		}
+		if language != "" {
+			result["@language"] = language
+		}
+		if direction != "" {
+			result["@direction"] = direction
+		}
+	}
+	return result, nil
+}
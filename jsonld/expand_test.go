@@ -0,0 +1,94 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parseJSON parses a JSON string, for testing purpose
+func parseJSON(input string, t *testing.T) interface{} {
+	var doc interface{}
+	if err := json.NewDecoder(strings.NewReader(input)).Decode(&doc); err != nil {
+		t.Fatal("cannot parse the JSON document", input, ":", err)
+	}
+	return doc
+}
+
+func TestExpand(t *testing.T) {
+	inputs := []string{
+		`{"@context": {"name": "http://xmlns.com/foaf/0.1/name"}, "@id": "http://example.org/me", "name": "Thomas"}`,
+		`{"@context": {"@vocab": "http://schema.org/", "@language": "en"}, "@type": "Book", "name": "Joseki", "isbn": 12}`,
+		`{"@context": {"foaf": "http://xmlns.com/foaf/0.1/", "knows": {"@id": "foaf:knows", "@type": "@id"}},
+		  "@id": "_:me", "knows": ["http://example.org/you", "_:him"]}`,
+		`{"@context": {"@base": "http://example.org/", "list": {"@id": "http://example.org/list", "@container": "@list"}},
+		  "@id": "doc", "list": [1, true, "three"]}`,
+		`{"@context": {"label": {"@id": "http://www.w3.org/2000/01/rdf-schema#label", "@container": "@language"}},
+		  "@id": "http://example.org/a", "label": {"en": "Hello", "fr": ["Bonjour", "Salut"]}}`,
+		`{"@context": {"isKnownBy": {"@reverse": "http://xmlns.com/foaf/0.1/knows"}},
+		  "@id": "http://example.org/me", "isKnownBy": {"@id": "http://example.org/you"}}`,
+		`{"@context": {"@vocab": "http://example.org/", "data": {"@type": "@json"}, "meta": "@nest"},
+		  "@id": "http://example.org/a", "meta": {"data": {"b": [1, 2]}}}`,
+		`{"@context": {"@vocab": "http://example.org/", "Person": {"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}},
+		  "@graph": [{"@type": "Person", "name": "Art"}, {"name": "Dave"}, "free-floating"]}`,
+	}
+	expected := []string{
+		`[{"@id": "http://example.org/me", "http://xmlns.com/foaf/0.1/name": [{"@value": "Thomas"}]}]`,
+		`[{"@type": ["http://schema.org/Book"], "http://schema.org/name": [{"@value": "Joseki", "@language": "en"}],
+		   "http://schema.org/isbn": [{"@value": 12}]}]`,
+		`[{"@id": "_:me", "http://xmlns.com/foaf/0.1/knows": [{"@id": "http://example.org/you"}, {"@id": "_:him"}]}]`,
+		`[{"@id": "http://example.org/doc", "http://example.org/list": [{"@list": [{"@value": 1}, {"@value": true}, {"@value": "three"}]}]}]`,
+		`[{"@id": "http://example.org/a", "http://www.w3.org/2000/01/rdf-schema#label": [{"@value": "Hello", "@language": "en"},
+		   {"@value": "Bonjour", "@language": "fr"}, {"@value": "Salut", "@language": "fr"}]}]`,
+		`[{"@id": "http://example.org/me", "@reverse": {"http://xmlns.com/foaf/0.1/knows": [{"@id": "http://example.org/you"}]}}]`,
+		`[{"@id": "http://example.org/a", "http://example.org/data": [{"@value": {"b": [1, 2]}, "@type": "@json"}]}]`,
+		`[{"@type": ["http://example.org/Person"], "http://xmlns.com/foaf/0.1/name": [{"@value": "Art"}]},
+		  {"http://example.org/name": [{"@value": "Dave"}]}]`,
+	}
+
+	for i, input := range inputs {
+		result, err := Expand(parseJSON(input, t), nil)
+		if err != nil {
+			t.Error("expansion of", input, "shouldn't produce the error :", err)
+			continue
+		}
+		// compare the JSON representations, since the numbers are represented differently
+		resultJSON, _ := json.Marshal(result)
+		if expectedDoc := parseJSON(expected[i], t); !reflect.DeepEqual(parseJSON(string(resultJSON), t), expectedDoc) {
+			t.Error("expected the expansion of", input, "to be", expected[i], "but instead got", string(resultJSON))
+		}
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	inputs := []string{
+		`{"@id": 22}`,
+		`{"@type": {"foo": "bar"}}`,
+		`{"@context": {"@vocab": "http://example.org/"}, "@value": "foo", "name": "bar"}`,
+		`{"@context": {"@vocab": "http://example.org/"}, "@value": "foo", "@type": "xsd:string", "@language": "en"}`,
+		`{"@context": {"@vocab": "http://example.org/"}, "p": {"@value": 22, "@language": "en"}}`,
+		`{"@context": {"@vocab": "http://example.org/"}, "p": {"@list": [], "foo": "bar"}}`,
+		`{"@reverse": "http://example.org"}`,
+	}
+	codes := []string{
+		"invalid @id value",
+		"invalid type value",
+		"invalid value object",
+		"invalid value object",
+		"invalid language-tagged value",
+		"invalid set or list object",
+		"invalid @reverse value",
+	}
+
+	for i, input := range inputs {
+		_, err := Expand(parseJSON(input, t), nil)
+		if jsonErr, isJSONErr := err.(*Error); !isJSONErr || jsonErr.Code != codes[i] {
+			t.Error("expected the expansion of", input, "to produce the error", codes[i], "but instead got", err)
+		}
+	}
+}
@@ -0,0 +1,554 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// framer runs the Framing algorithm, which shapes the nodes of a flattened document into trees.
+//
+// Framing reference : https://www.w3.org/TR/json-ld11-framing/#framing-algorithm
+type framer struct {
+	nodes       map[string]map[string]interface{}
+	embed       string
+	explicit    bool
+	requireAll  bool
+	omitDefault bool
+	stack       []string
+	embedded    map[string]bool
+}
+
+// Frame runs the Framing algorithm on a JSON-LD document. The nodes matching the frame are embedded
+// in a tree shaped like the frame, which is then compacted using the context of the frame.
+//
+// Framing reference : https://www.w3.org/TR/json-ld11-framing/
+func Frame(input, frame interface{}, options *Options) (map[string]interface{}, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	expanded, err := Expand(input, options)
+	if err != nil {
+		return nil, err
+	}
+	frameMap, isMap := frame.(map[string]interface{})
+	if !isMap {
+		return nil, newError("invalid frame", "a frame must be a map")
+	}
+	active := newActiveContext(options.Base, options)
+	expandedFrame, err := expandDocument(active, frameMap, true)
+	if err != nil {
+		return nil, err
+	}
+	topFrame := make(map[string]interface{})
+	if len(expandedFrame) > 0 {
+		if topFrame, isMap = expandedFrame[0].(map[string]interface{}); !isMap {
+			return nil, newError("invalid frame", "")
+		}
+	}
+
+	// frame the nodes of all the graphs merged together
+	generator := newNodeMapGenerator()
+	if err = generator.generate(expanded, DefaultGraph, nil, "", nil); err != nil {
+		return nil, err
+	}
+	f := &framer{
+		nodes:    mergeNodeMaps(generator.nodes),
+		embed:    "@once",
+		stack:    make([]string, 0),
+		embedded: make(map[string]bool),
+	}
+	framed := make([]interface{}, 0)
+	if err = f.frame(f.subjects(), topFrame, &framed, ""); err != nil {
+		return nil, err
+	}
+	pruneBlankNodes(framed)
+
+	context := frameMap["@context"]
+	compactCtx, err := newActiveContext(options.Base, options).process(context, options.Base, nil, false, true)
+	if err != nil {
+		return nil, err
+	}
+	result, err := compactCtx.compactDocument(framed, context)
+	if err != nil {
+		return nil, err
+	}
+	// a frame matching no node produces an empty graph
+	if len(framed) == 0 {
+		result[compactCtx.compactIRI("@graph", nil, true, false)] = []interface{}{}
+	}
+	return replacePreserve(result).(map[string]interface{}), nil
+}
+
+// mergeNodeMaps merges the nodes of all the graphs of a node map into a single map.
+func mergeNodeMaps(nodes nodeMap) map[string]map[string]interface{} {
+	merged := make(map[string]map[string]interface{})
+	graphNames := make([]string, 0, len(nodes))
+	for name := range nodes {
+		graphNames = append(graphNames, name)
+	}
+	sort.Strings(graphNames)
+	for _, name := range graphNames {
+		for id, node := range nodes[name] {
+			if _, inMap := merged[id]; !inMap {
+				merged[id] = map[string]interface{}{"@id": id}
+			}
+			for property, values := range node {
+				if property == "@id" {
+					continue
+				}
+				for _, value := range asArray(values) {
+					addValue(merged[id], property, value, false)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// subjects returns the ids of all the nodes, sorted.
+func (f *framer) subjects() []string {
+	ids := make([]string, 0, len(f.nodes))
+	for id := range f.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// embedFlag returns the value of the @embed flag of a frame.
+func (f *framer) embedFlag(frame map[string]interface{}) (string, error) {
+	switch embed := frame["@embed"].(type) {
+	case nil:
+		return f.embed, nil
+	case bool:
+		if embed {
+			return "@once", nil
+		}
+		return "@never", nil
+	case string:
+		switch embed {
+		case "@always", "@once", "@never":
+			return embed, nil
+		case "@last":
+			return "@once", nil
+		}
+	}
+	return "", newError("invalid @embed value", "")
+}
+
+// boolFlag returns the value of a boolean flag of a frame, or a default value if it is not set.
+func boolFlag(frame map[string]interface{}, flag string, defaultValue bool) bool {
+	if value, isBool := frame[flag].(bool); isBool {
+		return value
+	}
+	return defaultValue
+}
+
+// addFrameOutput adds a framed node to its parent, which is either the top-level list or a node.
+func addFrameOutput(parent interface{}, property string, output interface{}) {
+	switch p := parent.(type) {
+	case *[]interface{}:
+		*p = append(*p, output)
+	case map[string]interface{}:
+		addValue(p, property, output, true)
+	}
+}
+
+// frame embeds the nodes matching a frame into their parent.
+func (f *framer) frame(subjects []string, frame map[string]interface{}, parent interface{}, property string) error {
+	embed, err := f.embedFlag(frame)
+	if err != nil {
+		return err
+	}
+	explicit := boolFlag(frame, "@explicit", f.explicit)
+	requireAll := boolFlag(frame, "@requireAll", f.requireAll)
+
+	for _, id := range subjects {
+		node := f.nodes[id]
+		if node == nil || !f.matches(node, frame, requireAll) {
+			continue
+		}
+		// each top-level node can embed again the nodes already embedded by another one
+		if len(f.stack) == 0 {
+			f.embedded = make(map[string]bool)
+		}
+		reference := map[string]interface{}{"@id": id}
+		if embed == "@never" || f.isEmbedding(id) || (embed == "@once" && f.embedded[id]) {
+			addFrameOutput(parent, property, reference)
+			continue
+		}
+		f.embedded[id] = true
+		f.stack = append(f.stack, id)
+
+		output := map[string]interface{}{"@id": id}
+		for _, prop := range sortedKeys(node) {
+			if prop == "@id" {
+				continue
+			}
+			if isKeyword(prop) {
+				output[prop] = copyValue(node[prop])
+				continue
+			}
+			if _, inFrame := frame[prop]; explicit && !inFrame {
+				continue
+			}
+			subframe := f.subframe(frame, prop)
+			for _, item := range asArray(node[prop]) {
+				if isListObject(item) {
+					list := make([]interface{}, 0)
+					for _, listItem := range asArray(item.(map[string]interface{})["@list"]) {
+						if ref, isRef := nodeReference(listItem); isRef {
+							if err = f.frame([]string{ref}, subframe, &list, ""); err != nil {
+								return err
+							}
+						} else {
+							list = append(list, copyValue(listItem))
+						}
+					}
+					addValue(output, prop, map[string]interface{}{"@list": list}, true)
+				} else if ref, isRef := nodeReference(item); isRef {
+					if err = f.frame([]string{ref}, subframe, output, prop); err != nil {
+						return err
+					}
+				} else if valueMatches(subframe, item) {
+					addValue(output, prop, copyValue(item), true)
+				}
+			}
+		}
+
+		// add the default values of the properties missing from the node
+		for _, prop := range sortedKeys(frame) {
+			if _, inOutput := output[prop]; inOutput || isKeyword(prop) {
+				continue
+			}
+			subframe := f.subframe(frame, prop)
+			if boolFlag(subframe, "@omitDefault", f.omitDefault) {
+				continue
+			}
+			preserve, hasDefault := subframe["@default"]
+			if !hasDefault {
+				preserve = "@null"
+			}
+			output[prop] = []interface{}{map[string]interface{}{"@preserve": asArray(preserve)}}
+		}
+
+		// embed the nodes which reference the node through a reverse property
+		if reverseFrame, hasReverse := frame["@reverse"].(map[string]interface{}); hasReverse {
+			for _, reverseProp := range sortedKeys(reverseFrame) {
+				subframe := f.subframe(reverseFrame, reverseProp)
+				for _, subject := range f.subjects() {
+					for _, value := range asArray(f.nodes[subject][reverseProp]) {
+						if ref, isRef := nodeReference(value); !isRef || ref != id {
+							continue
+						}
+						reverse, hasReverse := output["@reverse"].(map[string]interface{})
+						if !hasReverse {
+							reverse = make(map[string]interface{})
+							output["@reverse"] = reverse
+						}
+						if err = f.frame([]string{subject}, subframe, reverse, reverseProp); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+
+		f.stack = f.stack[:len(f.stack)-1]
+		addFrameOutput(parent, property, output)
+	}
+	return nil
+}
+
+// isEmbedding returns True if a node is currently being embedded, i.e. embedding it again would create a cycle.
+func (f *framer) isEmbedding(id string) bool {
+	return contains(f.stack, id)
+}
+
+// subframe returns the frame used for the values of a property, which inherits the flags of the framer.
+func (f *framer) subframe(frame map[string]interface{}, property string) map[string]interface{} {
+	for _, item := range asArray(frame[property]) {
+		if subframe, isMap := item.(map[string]interface{}); isMap {
+			if isListObject(subframe) {
+				if list := asArray(subframe["@list"]); len(list) > 0 {
+					if listFrame, isMap := list[0].(map[string]interface{}); isMap {
+						return listFrame
+					}
+				}
+				break
+			}
+			return subframe
+		}
+	}
+	return map[string]interface{}{
+		"@embed":       f.embed,
+		"@explicit":    f.explicit,
+		"@requireAll":  f.requireAll,
+		"@omitDefault": f.omitDefault,
+	}
+}
+
+// nodeReference returns the id of a value if it references a node.
+func nodeReference(value interface{}) (string, bool) {
+	obj, isMap := value.(map[string]interface{})
+	if !isMap || isValueObject(obj) || isListObject(obj) {
+		return "", false
+	}
+	id, hasID := obj["@id"].(string)
+	return id, hasID
+}
+
+// isWildcard returns True if a frame value matches anything, i.e. it is an empty map.
+func isWildcard(value interface{}) bool {
+	obj, isMap := value.(map[string]interface{})
+	return isMap && len(obj) == 0
+}
+
+// isMatchNone returns True if a frame value matches nothing, i.e. it is an empty array.
+func isMatchNone(value interface{}) bool {
+	array, isArray := value.([]interface{})
+	return isArray && len(array) == 0
+}
+
+// matches returns True if a node matches a frame.
+//
+// The @id & @type of the frame must always be matched, while the other properties are matched
+// either all together or at least one of them, depending on the @requireAll flag.
+//
+// Frame Matching reference : https://www.w3.org/TR/json-ld11-framing/#frame-matching-algorithm
+func (f *framer) matches(node, frame map[string]interface{}, requireAll bool) bool {
+	wildcard, matchesSome := true, false
+	for _, key := range sortedKeys(frame) {
+		frameValues := asArray(frame[key])
+		nodeValues := asArray(node[key])
+		switch {
+		case key == "@id":
+			if len(frameValues) == 0 || isWildcard(frameValues[0]) {
+				continue
+			}
+			if !containsValue(frameValues, node["@id"]) {
+				return false
+			}
+			wildcard = false
+			matchesSome = true
+			continue
+		case key == "@type":
+			wildcard = false
+			matchType := false
+			switch {
+			case isMatchNone(frame[key]):
+				matchType = len(nodeValues) == 0
+			case len(frameValues) == 1 && isWildcard(frameValues[0]):
+				matchType = len(nodeValues) > 0
+			default:
+				for _, frameType := range frameValues {
+					matchType = matchType || containsValue(nodeValues, frameType)
+				}
+			}
+			if !matchType {
+				return false
+			}
+			matchesSome = true
+			continue
+		case isKeyword(key):
+			continue
+		}
+
+		wildcard = false
+		var subframe map[string]interface{}
+		if len(frameValues) > 0 {
+			subframe, _ = frameValues[0].(map[string]interface{})
+		}
+		if _, hasDefault := subframe["@default"]; hasDefault && len(nodeValues) == 0 {
+			continue
+		}
+		matchProperty := false
+		switch {
+		case isMatchNone(frame[key]):
+			if len(nodeValues) > 0 {
+				return false
+			}
+			matchProperty = true
+		case subframe == nil || isWildcard(subframe) || isFlagsOnly(subframe):
+			matchProperty = len(nodeValues) > 0
+		case isValuePattern(subframe):
+			for _, value := range nodeValues {
+				matchProperty = matchProperty || valueMatches(subframe, value)
+			}
+		case isListObject(subframe):
+			for _, value := range nodeValues {
+				matchProperty = matchProperty || isListObject(value)
+			}
+		default:
+			for _, value := range nodeValues {
+				if ref, isRef := nodeReference(value); isRef && f.nodes[ref] != nil {
+					matchProperty = matchProperty || f.matches(f.nodes[ref], subframe, boolFlag(subframe, "@requireAll", requireAll))
+				}
+			}
+		}
+		if !matchProperty && requireAll {
+			return false
+		}
+		matchesSome = matchesSome || matchProperty
+	}
+	return wildcard || matchesSome
+}
+
+// isFlagsOnly returns True if a frame only contains framing flags.
+func isFlagsOnly(frame map[string]interface{}) bool {
+	for key := range frame {
+		switch key {
+		case "@embed", "@explicit", "@requireAll", "@omitDefault", "@default":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isValuePattern returns True if a frame is a pattern matching value objects.
+func isValuePattern(frame map[string]interface{}) bool {
+	_, hasValue := frame["@value"]
+	_, hasLanguage := frame["@language"]
+	return hasValue || hasLanguage
+}
+
+// containsValue returns True if a value is in an array.
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueMatches returns True if a value object matches a value pattern.
+//
+// Value Matching reference : https://www.w3.org/TR/json-ld11-framing/#value-matching-algorithm
+func valueMatches(pattern map[string]interface{}, value interface{}) bool {
+	obj, isMap := value.(map[string]interface{})
+	if !isValuePattern(pattern) {
+		return true
+	}
+	if !isMap || !isValueObject(obj) {
+		return false
+	}
+	matchEntry := func(key string, normalize func(interface{}) interface{}) bool {
+		patternValue, inPattern := pattern[key]
+		actual, inValue := obj[key]
+		if !inPattern {
+			return !inValue
+		}
+		patternValues := asArray(patternValue)
+		if len(patternValues) == 1 && isWildcard(patternValues[0]) {
+			return inValue
+		}
+		if isMatchNone(patternValue) {
+			return !inValue
+		}
+		for _, v := range patternValues {
+			if inValue && reflect.DeepEqual(normalize(v), normalize(actual)) {
+				return true
+			}
+		}
+		return false
+	}
+	identity := func(v interface{}) interface{} { return v }
+	lower := func(v interface{}) interface{} {
+		if str, isString := v.(string); isString {
+			return strings.ToLower(str)
+		}
+		return v
+	}
+	return matchEntry("@value", identity) && matchEntry("@type", identity) && matchEntry("@language", lower)
+}
+
+// copyValue returns a deep copy of an expanded value.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// pruneBlankNodes removes the identifiers of the blank nodes which are used only once in a framed document.
+func pruneBlankNodes(framed []interface{}) {
+	usages := make(map[string]int)
+	var count func(value interface{})
+	count = func(value interface{}) {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				count(item)
+			}
+		case map[string]interface{}:
+			for key, item := range v {
+				if id, isString := item.(string); key == "@id" && isString && isBlankNodeID(id) {
+					usages[id]++
+				}
+				count(item)
+			}
+		}
+	}
+	count(framed)
+
+	var prune func(value interface{})
+	prune = func(value interface{}) {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				prune(item)
+			}
+		case map[string]interface{}:
+			if id, isString := v["@id"].(string); isString && usages[id] == 1 && len(v) > 1 {
+				delete(v, "@id")
+			}
+			for _, item := range v {
+				prune(item)
+			}
+		}
+	}
+	prune(framed)
+}
+
+// replacePreserve replaces the @preserve entries of a compacted framed document by their values.
+func replacePreserve(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, replacePreserve(item))
+		}
+		return result
+	case map[string]interface{}:
+		if preserve, hasPreserve := v["@preserve"]; hasPreserve {
+			return replacePreserve(preserve)
+		}
+		for key, item := range v {
+			v[key] = replacePreserve(item)
+		}
+		return v
+	case string:
+		if v == "@null" {
+			return nil
+		}
+	}
+	return value
+}
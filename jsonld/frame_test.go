@@ -0,0 +1,103 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const frameInput = `{
+	"@context": {
+		"dc": "http://purl.org/dc/elements/1.1/",
+		"ex": "http://example.org/vocab#",
+		"ex:contains": {"@type": "@id"}
+	},
+	"@graph": [{
+		"@id": "http://example.org/library",
+		"@type": "ex:Library",
+		"ex:contains": "http://example.org/library/the-republic"
+	}, {
+		"@id": "http://example.org/library/the-republic",
+		"@type": "ex:Book",
+		"dc:creator": "Plato",
+		"dc:title": "The Republic",
+		"ex:contains": "_:chapter"
+	}, {
+		"@id": "_:chapter",
+		"@type": "ex:Chapter",
+		"dc:title": "The Introduction"
+	}]
+}`
+
+const frameContext = `{"dc": "http://purl.org/dc/elements/1.1/", "ex": "http://example.org/vocab#"}`
+
+func TestFrame(t *testing.T) {
+	frames := []string{
+		`{"@type": "ex:Library", "ex:contains": {"@type": "ex:Book", "ex:contains": {"@type": "ex:Chapter"}}}`,
+		`{"@type": "ex:Book", "@explicit": true, "dc:title": {}, "dc:description": {"@default": "none"}, "dc:subject": {}}`,
+		`{"@type": "ex:Book", "ex:contains": {"@embed": "@never"}}`,
+		`{"dc:title": {"@value": "The Introduction"}}`,
+		`{"@type": "ex:Book", "@explicit": true, "dc:subject": {"@omitDefault": true}}`,
+		`{"@type": "ex:Magazine"}`,
+	}
+	expected := []string{
+		`{"@id": "http://example.org/library", "@type": "ex:Library",
+		  "ex:contains": {"@id": "http://example.org/library/the-republic", "@type": "ex:Book", "dc:creator": "Plato", "dc:title": "The Republic",
+		                  "ex:contains": {"@type": "ex:Chapter", "dc:title": "The Introduction"}}}`,
+		`{"@id": "http://example.org/library/the-republic", "@type": "ex:Book", "dc:title": "The Republic", "dc:description": "none", "dc:subject": null}`,
+		`{"@id": "http://example.org/library/the-republic", "@type": "ex:Book", "dc:creator": "Plato", "dc:title": "The Republic",
+		  "ex:contains": {"@id": "_:b0"}}`,
+		`{"@type": "ex:Chapter", "dc:title": "The Introduction"}`,
+		`{"@id": "http://example.org/library/the-republic", "@type": "ex:Book"}`,
+		`{"@graph": []}`,
+	}
+
+	input := parseJSON(frameInput, t)
+	for i, frame := range frames {
+		frameDoc := parseJSON(frame, t).(map[string]interface{})
+		frameDoc["@context"] = parseJSON(frameContext, t)
+		result, err := Frame(input, frameDoc, nil)
+		if err != nil {
+			t.Error("framing with", frame, "shouldn't produce the error :", err)
+			continue
+		}
+		delete(result, "@context")
+		resultJSON, _ := json.Marshal(result)
+		if !reflect.DeepEqual(parseJSON(string(resultJSON), t), parseJSON(expected[i], t)) {
+			t.Error("expected the framing with", frame, "to be", expected[i], "but instead got", string(resultJSON))
+		}
+	}
+}
+
+func TestFrameMultipleNodes(t *testing.T) {
+	frame := parseJSON(`{"@context": `+frameContext+`, "dc:title": {}}`, t)
+	result, err := Frame(parseJSON(frameInput, t), frame, nil)
+	if err != nil {
+		t.Fatal("framing a valid document shouldn't produce the error :", err)
+	}
+	graph, isArray := result["@graph"].([]interface{})
+	if !isArray || len(graph) != 2 {
+		t.Fatal("expected two top-level nodes in the framed document but instead got", result)
+	}
+	// a node embedded by a top-level node is embedded again in the next ones
+	book := graph[1].(map[string]interface{})
+	if _, isMap := book["ex:contains"].(map[string]interface{}); !isMap {
+		t.Error("expected the chapter to be embedded in the book, but instead got", book["ex:contains"])
+	}
+}
+
+func TestFrameErrors(t *testing.T) {
+	frames := []interface{}{
+		"not a frame",
+		parseJSON(`{"@embed": "@sometimes"}`, t),
+	}
+	for _, frame := range frames {
+		if _, err := Frame(parseJSON(frameInput, t), frame, nil); err == nil {
+			t.Error("framing with", frame, "should produce an error")
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"sort"
+)
+
+// nodeUsage records a reference to a node, as the value of a property of another node.
+type nodeUsage struct {
+	node     map[string]interface{}
+	property string
+	value    map[string]interface{}
+}
+
+// nodeID returns the identifier of a RDF node in a JSON-LD document.
+func nodeID(node rdf.Node) (string, bool) {
+	switch n := node.(type) {
+	case rdf.URI:
+		return n.Value, true
+	case rdf.BlankNode:
+		return "_:" + n.Value, true
+	}
+	return "", false
+}
+
+// rdfToObject converts a RDF node into an expanded JSON-LD value.
+//
+// RDF to Object Conversion reference : https://www.w3.org/TR/json-ld11-api/#rdf-to-object-conversion
+func rdfToObject(node rdf.Node) map[string]interface{} {
+	if id, isResource := nodeID(node); isResource {
+		return map[string]interface{}{"@id": id}
+	}
+	literal, isLiteral := node.(rdf.Literal)
+	if !isLiteral {
+		return map[string]interface{}{"@value": node.String()}
+	}
+	result := map[string]interface{}{"@value": literal.Value}
+	if literal.Lang != "" {
+		result["@language"] = literal.Lang
+		if literal.Dir != "" {
+			result["@direction"] = literal.Dir
+		}
+	} else if literal.Datatype() != rdf.XSDString {
+		result["@type"] = literal.Datatype()
+	}
+	return result
+}
+
+// FromRDF runs the Serialize RDF as JSON-LD algorithm, which converts RDF triples into an expanded JSON-LD document.
+//
+// Serialize RDF as JSON-LD reference : https://www.w3.org/TR/json-ld11-api/#serialize-rdf-as-json-ld-algorithm
+func FromRDF(triples []rdf.Triple) ([]interface{}, error) {
+	nodes := make(map[string]map[string]interface{})
+	usages := make(map[string][]nodeUsage)
+	getNode := func(id string) map[string]interface{} {
+		if _, inMap := nodes[id]; !inMap {
+			nodes[id] = map[string]interface{}{"@id": id}
+		}
+		return nodes[id]
+	}
+
+	for _, triple := range triples {
+		subject, isResource := nodeID(triple.Subject)
+		if !isResource {
+			return nil, newError("invalid RDF node", "the subject "+triple.Subject.String()+" cannot be serialized in JSON-LD")
+		}
+		predicate, isURI := triple.Predicate.(rdf.URI)
+		if !isURI {
+			return nil, newError("invalid RDF node", "the predicate "+triple.Predicate.String()+" cannot be serialized in JSON-LD")
+		}
+		// JSON-LD 1.1 has no representation of the quoted triples
+		if _, isQuoted := triple.Object.(rdf.QuotedTriple); isQuoted {
+			return nil, newError("invalid RDF node", "the object "+triple.Object.String()+" cannot be serialized in JSON-LD")
+		}
+		node := getNode(subject)
+		object, isResource := nodeID(triple.Object)
+		if isResource {
+			getNode(object)
+		}
+		if predicate.Value == rdfNamespace+"type" && isResource {
+			addValue(node, "@type", object, false)
+			continue
+		}
+		value := rdfToObject(triple.Object)
+		addValue(node, predicate.Value, value, false)
+		if isResource {
+			// keep the value stored in the node, which can be converted into a list later
+			values := node[predicate.Value].([]interface{})
+			for _, v := range values {
+				if ref := v.(map[string]interface{}); ref["@id"] == object {
+					value = ref
+				}
+			}
+			usages[object] = append(usages[object], nodeUsage{node, predicate.Value, value})
+		}
+	}
+
+	// convert the RDF collections into lists
+	for _, usage := range usages[rdfNamespace+"nil"] {
+		node, property, head := usage.node, usage.property, usage.value
+		list := make([]interface{}, 0)
+		listNodes := make([]string, 0)
+		for property == rdfNamespace+"rest" && isWellFormedListNode(node, usages) {
+			list = append(list, node[rdfNamespace+"first"].([]interface{})[0])
+			listNodes = append(listNodes, node["@id"].(string))
+			nodeUsages := usages[node["@id"].(string)]
+			node, property, head = nodeUsages[0].node, nodeUsages[0].property, nodeUsages[0].value
+		}
+		delete(head, "@id")
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+		head["@list"] = list
+		for _, id := range listNodes {
+			delete(nodes, id)
+		}
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if len(nodes[id]) > 1 {
+			result = append(result, nodes[id])
+		}
+	}
+	return result, nil
+}
+
+// isWellFormedListNode returns True if a node is a blank node used only once,
+// with exactly one rdf:first & one rdf:rest values, and optionally rdf:List as type.
+func isWellFormedListNode(node map[string]interface{}, usages map[string][]nodeUsage) bool {
+	id, _ := node["@id"].(string)
+	if !isBlankNodeID(id) || len(usages[id]) != 1 {
+		return false
+	}
+	first, hasFirst := node[rdfNamespace+"first"].([]interface{})
+	rest, hasRest := node[rdfNamespace+"rest"].([]interface{})
+	if !hasFirst || !hasRest || len(first) != 1 || len(rest) != 1 {
+		return false
+	}
+	for key, value := range node {
+		switch key {
+		case "@id", rdfNamespace + "first", rdfNamespace + "rest":
+		case "@type":
+			types := asArray(value)
+			if len(types) != 1 || types[0] != rdfNamespace+"List" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"github.com/Callidon/joseki/rdf"
+	"reflect"
+	"testing"
+)
+
+func TestFromRDF(t *testing.T) {
+	book := rdf.NewURI("http://example.org/book")
+	triples := []rdf.Triple{
+		rdf.NewTriple(book, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI("http://schema.org/Book")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/name"), rdf.NewLangLiteral("Joseki", "en")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/title"), rdf.NewDirLangLiteral("Joseki", "ar", "rtl")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/pages"), rdf.NewTypedLiteral("120", "<"+xsdNamespace+"integer>")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/isbn"), rdf.NewTypedLiteral("1234", "<"+xsdNamespace+"string>")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/author"), rdf.NewBlankNode("thomas")),
+		rdf.NewTriple(rdf.NewBlankNode("thomas"), rdf.NewURI("http://schema.org/name"), rdf.NewLiteral("Thomas")),
+		rdf.NewTriple(book, rdf.NewURI("http://schema.org/tags"), rdf.NewBlankNode("l1")),
+		rdf.NewTriple(rdf.NewBlankNode("l1"), rdf.NewURI(rdfNamespace+"first"), rdf.NewLiteral("rdf")),
+		rdf.NewTriple(rdf.NewBlankNode("l1"), rdf.NewURI(rdfNamespace+"rest"), rdf.NewBlankNode("l2")),
+		rdf.NewTriple(rdf.NewBlankNode("l2"), rdf.NewURI(rdfNamespace+"first"), rdf.NewLiteral("go")),
+		rdf.NewTriple(rdf.NewBlankNode("l2"), rdf.NewURI(rdfNamespace+"rest"), rdf.NewURI(rdfNamespace+"nil")),
+	}
+	expected := `[
+		{"@id": "_:thomas", "http://schema.org/name": [{"@value": "Thomas"}]},
+		{"@id": "http://example.org/book", "@type": ["http://schema.org/Book"],
+		 "http://schema.org/name": [{"@value": "Joseki", "@language": "en"}],
+		 "http://schema.org/title": [{"@value": "Joseki", "@language": "ar", "@direction": "rtl"}],
+		 "http://schema.org/pages": [{"@value": "120", "@type": "http://www.w3.org/2001/XMLSchema#integer"}],
+		 "http://schema.org/isbn": [{"@value": "1234"}],
+		 "http://schema.org/author": [{"@id": "_:thomas"}],
+		 "http://schema.org/tags": [{"@list": [{"@value": "rdf"}, {"@value": "go"}]}]}
+	]`
+
+	result, err := FromRDF(triples)
+	if err != nil {
+		t.Fatal("converting valid triples to JSON-LD shouldn't produce the error :", err)
+	}
+	resultJSON, _ := json.Marshal(result)
+	if !reflect.DeepEqual(parseJSON(string(resultJSON), t), parseJSON(expected, t)) {
+		t.Error("expected the conversion of the triples to be", expected, "but instead got", string(resultJSON))
+	}
+
+	// the converted document can be converted back to the same triples
+	dataset, err := ToRDF(result, nil)
+	if err != nil {
+		t.Fatal("converting back the document to RDF shouldn't produce the error :", err)
+	}
+	if len(dataset[DefaultGraph]) != len(triples) {
+		t.Error("expected", len(triples), "triples after a round trip but instead got", len(dataset[DefaultGraph]))
+	}
+}
+
+func TestFromRDFErrors(t *testing.T) {
+	triples := []rdf.Triple{
+		rdf.NewTriple(rdf.NewLiteral("subject"), rdf.NewURI("http://example.org/p"), rdf.NewLiteral("o")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/s"), rdf.NewBlankNode("p"), rdf.NewLiteral("o")),
+	}
+	for _, triple := range triples {
+		if _, err := FromRDF([]rdf.Triple{triple}); err == nil {
+			t.Error("the triple", triple, "shouldn't be converted to JSON-LD")
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Package jsonld provides a processor for JSON-LD 1.1, a JSON-based format to serialize Linked Data.
+//
+// It implements the context processing, expansion and RDF serialization (toRdf) algorithms.
+// Remote contexts are retrieved using a pluggable DocumentLoader, so documents can be processed offline.
+//
+// JSON-LD 1.1 reference : https://www.w3.org/TR/json-ld11/
+//
+// JSON-LD 1.1 Processing Algorithms and API reference : https://www.w3.org/TR/json-ld11-api/
+package jsonld
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// Namespace of the RDF vocabulary
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	// Namespace of the XML Schema datatypes
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+	// DefaultGraph is the name of the default graph in a Dataset
+	DefaultGraph = "@default"
+)
+
+// keywords of the JSON-LD syntax
+var keywords = map[string]bool{
+	"@base": true, "@container": true, "@context": true, "@default": true, "@direction": true,
+	"@embed": true, "@explicit": true, "@graph": true, "@id": true, "@import": true,
+	"@included": true, "@index": true, "@json": true, "@language": true, "@list": true,
+	"@nest": true, "@none": true, "@omitDefault": true, "@prefix": true, "@preserve": true,
+	"@propagate": true, "@protected": true, "@requireAll": true, "@reverse": true, "@set": true,
+	"@type": true, "@value": true, "@version": true, "@vocab": true,
+}
+
+// keywordForm matches the strings which have the form of a keyword, and are reserved for future use
+var keywordForm = regexp.MustCompile("^@[a-zA-Z]+$")
+
+// Options are the options used by the JSON-LD algorithms.
+type Options struct {
+	// Base is the base IRI used to resolve relative IRIs in the document
+	Base string
+	// DocumentLoader is used to retrieve remote contexts. If nil, only local files can be loaded.
+	DocumentLoader DocumentLoader
+	// ExpandContext is a context applied to the document before its own context
+	ExpandContext interface{}
+}
+
+// Error is an error raised by a JSON-LD algorithm, identified by a code defined in the JSON-LD specification.
+//
+// JSON-LD error codes reference : https://www.w3.org/TR/json-ld11-api/#jsonlderrorcode
+type Error struct {
+	Code    string
+	Details string
+}
+
+// newError creates a new JSON-LD Error
+func newError(code, details string) *Error {
+	return &Error{code, details}
+}
+
+// Error returns the description of the error
+func (e *Error) Error() string {
+	if e.Details == "" {
+		return "Error : " + e.Code
+	}
+	return "Error : " + e.Code + " - " + e.Details
+}
+
+// loader returns the document loader set in the options, or a loader for local files if there is none.
+func (o *Options) loader() DocumentLoader {
+	if o.DocumentLoader == nil {
+		return NewFileLoader(nil)
+	}
+	return o.DocumentLoader
+}
+
+// isKeyword returns True if a value is a JSON-LD keyword.
+func isKeyword(value interface{}) bool {
+	str, isString := value.(string)
+	return isString && keywords[str]
+}
+
+// isAbsoluteIRI returns True if a string is an absolute IRI, i.e. it has a scheme.
+func isAbsoluteIRI(value string) bool {
+	colon := strings.Index(value, ":")
+	if colon <= 0 {
+		return false
+	}
+	for i, r := range value[:colon] {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || !(r >= '0' && r <= '9') && r != '+' && r != '-' && r != '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// isBlankNodeID returns True if a string is a blank node identifier.
+func isBlankNodeID(value string) bool {
+	return strings.HasPrefix(value, "_:")
+}
+
+// asArray wraps a value into an array, if it's not already one.
+func asArray(value interface{}) []interface{} {
+	if array, isArray := value.([]interface{}); isArray {
+		return array
+	} else if value == nil {
+		return []interface{}{}
+	}
+	return []interface{}{value}
+}
+
+// sortedKeys returns the keys of a map in lexicographical order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isValueObject returns True if a value is a JSON-LD value object.
+func isValueObject(value interface{}) bool {
+	obj, isMap := value.(map[string]interface{})
+	if !isMap {
+		return false
+	}
+	_, hasValue := obj["@value"]
+	return hasValue
+}
+
+// isListObject returns True if a value is a JSON-LD list object.
+func isListObject(value interface{}) bool {
+	obj, isMap := value.(map[string]interface{})
+	if !isMap {
+		return false
+	}
+	_, hasList := obj["@list"]
+	return hasList
+}
+
+// isGraphObject returns True if a value is a JSON-LD graph object.
+func isGraphObject(value interface{}) bool {
+	obj, isMap := value.(map[string]interface{})
+	if !isMap {
+		return false
+	}
+	if _, hasGraph := obj["@graph"]; !hasGraph {
+		return false
+	}
+	for key := range obj {
+		if key != "@graph" && key != "@id" && key != "@index" && key != "@context" {
+			return false
+		}
+	}
+	return true
+}
+
+// contains returns True if a string is in a slice.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// RemoteDocument is a document retrieved by a DocumentLoader.
+type RemoteDocument struct {
+	// DocumentURL is the final URL of the loaded document
+	DocumentURL string
+	// Document is the parsed JSON content of the document
+	Document interface{}
+}
+
+// DocumentLoader retrieves the remote documents referenced by a JSON-LD document, like remote contexts.
+type DocumentLoader interface {
+	LoadDocument(url string) (*RemoteDocument, error)
+}
+
+// MapLoader is a DocumentLoader which serves documents stored in memory, indexed by their URL.
+type MapLoader struct {
+	documents map[string]string
+}
+
+// NewMapLoader creates a new MapLoader, serving documents given as JSON strings indexed by their URL.
+func NewMapLoader(documents map[string]string) *MapLoader {
+	return &MapLoader{documents}
+}
+
+// LoadDocument loads a document stored in the map.
+func (l *MapLoader) LoadDocument(url string) (*RemoteDocument, error) {
+	content, inMap := l.documents[url]
+	if !inMap {
+		return nil, newError("loading document failed", "no document stored for "+url)
+	}
+	doc, err := decodeJSON(strings.NewReader(content))
+	if err != nil {
+		return nil, newError("loading document failed", err.Error())
+	}
+	return &RemoteDocument{url, doc}, nil
+}
+
+// FileLoader is a DocumentLoader which serves documents from local files.
+//
+// URLs can be mapped to local files, so remote contexts can be used offline.
+// Other URLs are only loaded if they are file:// URLs or paths to local files.
+type FileLoader struct {
+	files map[string]string
+}
+
+// NewFileLoader creates a new FileLoader, using a mapping between URLs and local filenames.
+func NewFileLoader(files map[string]string) *FileLoader {
+	if files == nil {
+		files = make(map[string]string)
+	}
+	return &FileLoader{files}
+}
+
+// LoadDocument loads a document from a local file.
+func (l *FileLoader) LoadDocument(documentURL string) (*RemoteDocument, error) {
+	filename, inMap := l.files[documentURL]
+	if !inMap {
+		parsed, err := url.Parse(documentURL)
+		switch {
+		case err == nil && parsed.Scheme == "file":
+			filename = parsed.Path
+		case err == nil && parsed.Scheme != "" && len(parsed.Scheme) > 1:
+			return nil, newError("loading document failed", "cannot load the remote document "+documentURL)
+		default:
+			filename = documentURL
+		}
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, newError("loading document failed", err.Error())
+	}
+	defer f.Close()
+	doc, err := decodeJSON(f)
+	if err != nil {
+		return nil, newError("loading document failed", err.Error())
+	}
+	return &RemoteDocument{documentURL, doc}, nil
+}
+
+// decodeJSON reads a JSON document.
+func decodeJSON(reader io.Reader) (interface{}, error) {
+	var doc interface{}
+	err := json.NewDecoder(reader).Decode(&doc)
+	return doc, err
+}
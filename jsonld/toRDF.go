@@ -0,0 +1,410 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Callidon/joseki/rdf"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Dataset is a collection of RDF graphs, indexed by their names.
+// The default graph is indexed by DefaultGraph.
+type Dataset map[string][]rdf.Triple
+
+// blankNodeIssuer generates blank node identifiers, and keeps track of the identifiers already relabelled.
+type blankNodeIssuer struct {
+	prefix  string
+	counter int
+	issued  map[string]string
+	order   []string
+}
+
+// newBlankNodeIssuer creates a new blankNodeIssuer, which generates identifiers with a given prefix.
+func newBlankNodeIssuer(prefix string) *blankNodeIssuer {
+	return &blankNodeIssuer{prefix, 0, make(map[string]string), make([]string, 0)}
+}
+
+// issue returns the new identifier of a blank node, or generates a new one if the identifier is empty.
+func (i *blankNodeIssuer) issue(id string) string {
+	if issued, inMap := i.issued[id]; inMap && id != "" {
+		return issued
+	}
+	issued := i.prefix + strconv.Itoa(i.counter)
+	i.counter++
+	if id != "" {
+		i.issued[id] = issued
+		i.order = append(i.order, id)
+	}
+	return issued
+}
+
+// nodeMap is the result of the Node Map Generation algorithm: graph name -> node id -> node.
+type nodeMap map[string]map[string]map[string]interface{}
+
+// nodeMapGenerator runs the Node Map Generation algorithm.
+//
+// Node Map Generation reference : https://www.w3.org/TR/json-ld11-api/#node-map-generation
+type nodeMapGenerator struct {
+	nodes  nodeMap
+	issuer *blankNodeIssuer
+}
+
+// newNodeMapGenerator creates a new nodeMapGenerator.
+func newNodeMapGenerator() *nodeMapGenerator {
+	nodes := make(nodeMap)
+	nodes[DefaultGraph] = make(map[string]map[string]interface{})
+	return &nodeMapGenerator{nodes, newBlankNodeIssuer("_:b")}
+}
+
+// addValue adds a value to a property of a node, unless the value is already present.
+func addValue(node map[string]interface{}, property string, value interface{}, allowDuplicate bool) {
+	values := asArray(node[property])
+	if !allowDuplicate {
+		for _, v := range values {
+			if reflect.DeepEqual(v, value) {
+				node[property] = values
+				return
+			}
+		}
+	}
+	node[property] = append(values, value)
+}
+
+// generate walks through an expanded element, and adds the nodes it contains to the node map.
+// The active subject is either the id of a node, or a map when processing a reverse property.
+func (g *nodeMapGenerator) generate(element interface{}, graph string, activeSubject interface{}, activeProperty string, list map[string]interface{}) error {
+	if array, isArray := element.([]interface{}); isArray {
+		for _, item := range array {
+			if err := g.generate(item, graph, activeSubject, activeProperty, list); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	elt, isMap := element.(map[string]interface{})
+	if !isMap {
+		return nil
+	}
+	if _, hasGraph := g.nodes[graph]; !hasGraph {
+		g.nodes[graph] = make(map[string]map[string]interface{})
+	}
+	nodes := g.nodes[graph]
+
+	// relabel the blank nodes used as types
+	if types, hasType := elt["@type"]; hasType {
+		relabelled := make([]interface{}, 0)
+		for _, item := range asArray(types) {
+			if typeStr, isString := item.(string); isString && isBlankNodeID(typeStr) {
+				item = g.issuer.issue(typeStr)
+			}
+			relabelled = append(relabelled, item)
+		}
+		if _, isValue := elt["@value"]; isValue {
+			elt["@type"] = types
+		} else {
+			elt["@type"] = relabelled
+		}
+	}
+
+	switch {
+	case isValueObject(elt):
+		if list == nil {
+			addValue(nodes[activeSubject.(string)], activeProperty, elt, false)
+		} else {
+			list["@list"] = append(asArray(list["@list"]), elt)
+		}
+	case isListObject(elt):
+		result := map[string]interface{}{"@list": []interface{}{}}
+		if err := g.generate(elt["@list"], graph, activeSubject, activeProperty, result); err != nil {
+			return err
+		}
+		if list == nil {
+			addValue(nodes[activeSubject.(string)], activeProperty, result, true)
+		} else {
+			list["@list"] = append(asArray(list["@list"]), result)
+		}
+	default:
+		// the element is a node object
+		id, _ := elt["@id"].(string)
+		if id == "" || isBlankNodeID(id) {
+			id = g.issuer.issue(id)
+		}
+		node, inMap := nodes[id]
+		if !inMap {
+			node = map[string]interface{}{"@id": id}
+			nodes[id] = node
+		}
+		switch subject := activeSubject.(type) {
+		case map[string]interface{}:
+			// reverse property: the active subject is a value of the node
+			addValue(node, activeProperty, subject, false)
+		case string:
+			if activeProperty != "" {
+				reference := map[string]interface{}{"@id": id}
+				if list == nil {
+					addValue(nodes[subject], activeProperty, reference, false)
+				} else {
+					list["@list"] = append(asArray(list["@list"]), reference)
+				}
+			}
+		}
+		if types, hasType := elt["@type"]; hasType {
+			for _, item := range asArray(types) {
+				addValue(node, "@type", item, false)
+			}
+		}
+		if index, hasIndex := elt["@index"]; hasIndex {
+			if previous, inNode := node["@index"]; inNode && previous != index {
+				return newError("conflicting indexes", id)
+			}
+			node["@index"] = index
+		}
+		if reverse, hasReverse := elt["@reverse"].(map[string]interface{}); hasReverse {
+			referenced := map[string]interface{}{"@id": id}
+			for _, property := range sortedKeys(reverse) {
+				for _, value := range asArray(reverse[property]) {
+					if err := g.generate(value, graph, referenced, property, nil); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if graphValue, hasGraph := elt["@graph"]; hasGraph {
+			if err := g.generate(graphValue, id, nil, "", nil); err != nil {
+				return err
+			}
+		}
+		if included, hasIncluded := elt["@included"]; hasIncluded {
+			if err := g.generate(included, graph, nil, "", nil); err != nil {
+				return err
+			}
+		}
+		for _, property := range sortedKeys(elt) {
+			if isKeyword(property) {
+				continue
+			}
+			name := property
+			if isBlankNodeID(property) {
+				name = g.issuer.issue(property)
+			}
+			if _, inNode := node[name]; !inNode {
+				node[name] = []interface{}{}
+			}
+			if err := g.generate(elt[property], graph, id, name, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toNode converts an IRI or a blank node identifier into a RDF node.
+// It returns nil if the value is a relative IRI, which cannot be represented in RDF.
+func toNode(id string) rdf.Node {
+	if isBlankNodeID(id) {
+		return rdf.NewBlankNode(id[2:])
+	} else if isAbsoluteIRI(id) {
+		return rdf.NewURI(id)
+	}
+	return nil
+}
+
+// canonicalDouble returns the canonical lexical form of a xsd:double.
+func canonicalDouble(value float64) string {
+	str := strconv.FormatFloat(value, 'E', -1, 64)
+	mantissa, exponent := str, "0"
+	if idx := strings.Index(str, "E"); idx >= 0 {
+		mantissa, exponent = str[:idx], str[idx+1:]
+	}
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(exp)
+}
+
+// canonicalJSON returns the canonical serialization of a JSON literal.
+func canonicalJSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", newError("invalid JSON literal", err.Error())
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// rdfSerializer converts a node map into RDF triples.
+type rdfSerializer struct {
+	issuer *blankNodeIssuer
+}
+
+// objectToRDF converts an expanded value into a RDF node, and returns the triples generated for RDF lists.
+//
+// Object to RDF Conversion reference : https://www.w3.org/TR/json-ld11-api/#object-to-rdf-conversion
+func (s *rdfSerializer) objectToRDF(item interface{}) (rdf.Node, []rdf.Triple, error) {
+	obj, isMap := item.(map[string]interface{})
+	if !isMap {
+		return nil, nil, nil
+	}
+	if list, isList := obj["@list"]; isList {
+		return s.listToRDF(asArray(list))
+	}
+	if !isValueObject(obj) {
+		id, _ := obj["@id"].(string)
+		return toNode(id), nil, nil
+	}
+
+	value := obj["@value"]
+	datatype, _ := obj["@type"].(string)
+	if datatype != "" && datatype != "@json" && !isAbsoluteIRI(datatype) {
+		return nil, nil, nil
+	}
+	var lexical string
+	switch v := value.(type) {
+	case bool:
+		lexical = strconv.FormatBool(v)
+		if datatype == "" {
+			datatype = xsdNamespace + "boolean"
+		}
+	case float64:
+		isInteger := v == math.Trunc(v) && math.Abs(v) < 1e21
+		if datatype == xsdNamespace+"double" || (!isInteger && datatype == "") {
+			lexical = canonicalDouble(v)
+			if datatype == "" {
+				datatype = xsdNamespace + "double"
+			}
+		} else {
+			lexical = strconv.FormatFloat(v, 'f', -1, 64)
+			if datatype == "" {
+				datatype = xsdNamespace + "integer"
+			}
+		}
+	case string:
+		lexical = v
+	}
+	if datatype == "@json" {
+		var err error
+		if lexical, err = canonicalJSON(value); err != nil {
+			return nil, nil, err
+		}
+		datatype = rdfNamespace + "JSON"
+	}
+	// the base direction is kept using the rdf:dirLangString literals of RDF 1.2, which require a language
+	if language, hasLanguage := obj["@language"].(string); hasLanguage {
+		direction, _ := obj["@direction"].(string)
+		if !rdf.ValidDirection(direction) {
+			direction = ""
+		}
+		return rdf.NewDirLangLiteral(lexical, language, direction), nil, nil
+	} else if datatype != "" {
+		return rdf.NewTypedLiteral(lexical, datatype), nil, nil
+	}
+	return rdf.NewLiteral(lexical), nil, nil
+}
+
+// listToRDF converts a list into RDF collection, and returns its head with the triples generated.
+//
+// List to RDF Conversion reference : https://www.w3.org/TR/json-ld11-api/#list-to-rdf-conversion
+func (s *rdfSerializer) listToRDF(list []interface{}) (rdf.Node, []rdf.Triple, error) {
+	if len(list) == 0 {
+		return rdf.NewURI(rdfNamespace + "nil"), nil, nil
+	}
+	triples := make([]rdf.Triple, 0)
+	cells := make([]rdf.Node, len(list))
+	for i := range list {
+		cells[i] = toNode(s.issuer.issue(""))
+	}
+	for i, item := range list {
+		object, listTriples, err := s.objectToRDF(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		if object != nil {
+			triples = append(triples, rdf.NewTriple(cells[i], rdf.NewURI(rdfNamespace+"first"), object))
+		}
+		var rest rdf.Node = rdf.NewURI(rdfNamespace + "nil")
+		if i+1 < len(cells) {
+			rest = cells[i+1]
+		}
+		triples = append(triples, rdf.NewTriple(cells[i], rdf.NewURI(rdfNamespace+"rest"), rest))
+		triples = append(triples, listTriples...)
+	}
+	return cells[0], triples, nil
+}
+
+// ToRDF runs the Deserialize JSON-LD to RDF algorithm, which converts a JSON-LD document into a RDF Dataset.
+//
+// Deserialize JSON-LD to RDF reference : https://www.w3.org/TR/json-ld11-api/#deserialize-json-ld-to-rdf-algorithm
+func ToRDF(input interface{}, options *Options) (Dataset, error) {
+	expanded, err := Expand(input, options)
+	if err != nil {
+		return nil, err
+	}
+	generator := newNodeMapGenerator()
+	if err = generator.generate(expanded, DefaultGraph, nil, "", nil); err != nil {
+		return nil, err
+	}
+	serializer := &rdfSerializer{generator.issuer}
+	dataset := make(Dataset)
+
+	graphNames := make([]string, 0, len(generator.nodes))
+	for name := range generator.nodes {
+		graphNames = append(graphNames, name)
+	}
+	sort.Strings(graphNames)
+	for _, graphName := range graphNames {
+		if graphName != DefaultGraph && toNode(graphName) == nil {
+			continue
+		}
+		triples := make([]rdf.Triple, 0)
+		nodes := generator.nodes[graphName]
+		ids := make([]string, 0, len(nodes))
+		for id := range nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			subject := toNode(id)
+			if subject == nil {
+				continue
+			}
+			node := nodes[id]
+			for _, property := range sortedKeys(node) {
+				if property == "@type" {
+					for _, typeValue := range asArray(node[property]) {
+						if object := toNode(typeValue.(string)); object != nil {
+							triples = append(triples, rdf.NewTriple(subject, rdf.NewURI(rdfNamespace+"type"), object))
+						}
+					}
+					continue
+				}
+				// keywords & blank node properties cannot be represented as RDF predicates
+				predicate, isURI := toNode(property).(rdf.URI)
+				if isKeyword(property) || !isURI {
+					continue
+				}
+				for _, item := range asArray(node[property]) {
+					object, listTriples, err := serializer.objectToRDF(item)
+					if err != nil {
+						return nil, err
+					}
+					if object != nil {
+						triples = append(triples, rdf.NewTriple(subject, predicate, object))
+					}
+					triples = append(triples, listTriples...)
+				}
+			}
+		}
+		dataset[graphName] = triples
+	}
+	return dataset, nil
+}
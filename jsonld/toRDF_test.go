@@ -0,0 +1,82 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package jsonld
+
+import "testing"
+
+func TestToRDF(t *testing.T) {
+	input := `{
+		"@context": {
+			"@vocab": "http://schema.org/",
+			"foaf": "http://xmlns.com/foaf/0.1/",
+			"knows": {"@id": "foaf:knows", "@type": "@id"},
+			"authors": {"@id": "http://schema.org/author", "@container": "@list"},
+			"published": {"@id": "http://schema.org/datePublished", "@type": "http://www.w3.org/2001/XMLSchema#date"}
+		},
+		"@graph": [{
+			"@id": "http://example.org/book",
+			"@type": "Book",
+			"name": {"@value": "Joseki", "@language": "en"},
+			"title": {"@value": "Joseki", "@language": "ar", "@direction": "rtl"},
+			"published": "2016-06-01",
+			"pages": 120,
+			"rating": 4.5,
+			"available": true,
+			"authors": [{"@id": "_:thomas", "name": "Thomas"}, "Art"],
+			"relative": {"@id": "relative/iri"}
+		}, {
+			"@id": "_:thomas",
+			"knows": "http://example.org/you"
+		}, {
+			"@id": "http://example.org/named",
+			"@graph": {"@id": "http://example.org/a", "name": "in a named graph"}
+		}]
+	}`
+	expected := []string{
+		`_:b0 <http://schema.org/name> "Thomas"`,
+		`_:b0 <http://xmlns.com/foaf/0.1/knows> <http://example.org/you>`,
+		`<http://example.org/book> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://schema.org/Book>`,
+		`<http://example.org/book> <http://schema.org/author> _:b1`,
+		`_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> _:b0`,
+		`_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:b2`,
+		`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "Art"`,
+		`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil>`,
+		`<http://example.org/book> <http://schema.org/available> "true"^^<http://www.w3.org/2001/XMLSchema#boolean>`,
+		`<http://example.org/book> <http://schema.org/datePublished> "2016-06-01"^^<http://www.w3.org/2001/XMLSchema#date>`,
+		`<http://example.org/book> <http://schema.org/name> "Joseki"@en`,
+		`<http://example.org/book> <http://schema.org/pages> "120"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+		`<http://example.org/book> <http://schema.org/rating> "4.5E0"^^<http://www.w3.org/2001/XMLSchema#double>`,
+		`<http://example.org/book> <http://schema.org/title> "Joseki"@ar--rtl`,
+	}
+
+	dataset, err := ToRDF(parseJSON(input, t), nil)
+	if err != nil {
+		t.Fatal("converting a valid document to RDF shouldn't produce the error :", err)
+	}
+	triples := dataset[DefaultGraph]
+	if len(triples) != len(expected) {
+		t.Error("expected", len(expected), "triples in the default graph but instead got", len(triples))
+	}
+	for i, triple := range triples {
+		value := triple.Subject.String() + " " + triple.Predicate.String() + " " + triple.Object.String()
+		if i < len(expected) && value != expected[i] {
+			t.Error("expected the triple", expected[i], "but instead got", value)
+		}
+	}
+	if named := dataset["http://example.org/named"]; len(named) != 1 {
+		t.Error("expected one triple in the named graph but instead got", len(named))
+	}
+}
+
+func TestCanonicalDouble(t *testing.T) {
+	values := []float64{4.5, 1.0e21, 0.000123, -2.5e-10, 120}
+	expected := []string{"4.5E0", "1.0E21", "1.23E-4", "-2.5E-10", "1.2E2"}
+
+	for i, value := range values {
+		if canonical := canonicalDouble(value); canonical != expected[i] {
+			t.Error("expected the canonical form of", value, "to be", expected[i], "but instead got", canonical)
+		}
+	}
+}
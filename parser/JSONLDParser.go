@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"encoding/json"
+	"github.com/Callidon/joseki/jsonld"
+	"github.com/Callidon/joseki/rdf"
+	"strings"
+)
+
+// JSONLDParser is a parser for reading & loading triples in JSON-LD format.
+//
+// Only the triples of the default graph are read, since named graphs can't be stored in a RDF Graph.
+//
+// JSON-LD reference : https://www.w3.org/TR/json-ld11/
+type JSONLDParser struct {
+	prefixes map[string]string
+	loader   jsonld.DocumentLoader
+	base     string
+}
+
+// NewJSONLDParser creates a new JSONLDParser
+func NewJSONLDParser() *JSONLDParser {
+	return &JSONLDParser{make(map[string]string), nil, ""}
+}
+
+// SetDocumentLoader sets the loader used to retrieve the remote contexts referenced by the documents.
+// By default, only contexts stored in local files can be loaded.
+func (p *JSONLDParser) SetDocumentLoader(loader jsonld.DocumentLoader) {
+	p.loader = loader
+}
+
+// SetBase sets the base IRI used to resolve the relative IRIs of the documents.
+// It is replaced by the @base keywords found in the contexts of a document.
+func (p *JSONLDParser) SetBase(base string) {
+	p.base = base
+}
+
+// Prefixes returns the prefixes read by the parser during the last parsing.
+// For JSON-LD, they are the terms of the top-level context which are defined as a namespace.
+func (p JSONLDParser) Prefixes() map[string]string {
+	return p.prefixes
+}
+
+// readPrefixes saves the terms of a top-level context which are mapped to a namespace.
+func (p *JSONLDParser) readPrefixes(doc interface{}) {
+	obj, isMap := doc.(map[string]interface{})
+	if !isMap {
+		return
+	}
+	contexts, isArray := obj["@context"].([]interface{})
+	if !isArray {
+		contexts = []interface{}{obj["@context"]}
+	}
+	for _, context := range contexts {
+		ctx, isMap := context.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		for term, value := range ctx {
+			iri, isString := value.(string)
+			if isString && iri != "" && !strings.HasPrefix(term, "@") && strings.ContainsAny(iri[len(iri)-1:], "/#:") {
+				p.prefixes[term] = iri
+			}
+		}
+	}
+}
+
+// Read a file containg RDF triples in JSON-LD format & convert them in triples.
+//
+// Triples generated are send throught a channel, which is closed when the parsing of the file has been completed.
+func (p *JSONLDParser) Read(filename string) chan rdf.Triple {
+	out := make(chan rdf.Triple, bufferSize)
+
+	// parse the file using a goroutine
+	go func() {
+		var doc interface{}
+		defer close(out)
+		f, err := Open(filename)
+		check(err)
+		defer f.Close()
+		err = json.NewDecoder(f).Decode(&doc)
+		check(err)
+		p.readPrefixes(doc)
+		dataset, err := jsonld.ToRDF(doc, &jsonld.Options{Base: p.base, DocumentLoader: p.loader})
+		check(err)
+		for _, triple := range dataset[jsonld.DefaultGraph] {
+			out <- triple
+		}
+	}()
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"github.com/Callidon/joseki/jsonld"
+	"github.com/Callidon/joseki/rdf"
+	"testing"
+)
+
+func TestReadJSONLDParser(t *testing.T) {
+	parser := NewJSONLDParser()
+	parser.SetDocumentLoader(jsonld.NewFileLoader(map[string]string{
+		"http://example.org/context.jsonld": "datas/context.jsonld",
+	}))
+	cpt := 0
+	prefixes := [][]string{
+		[]string{"sw", "http://www.w3.org/2001/sw/RDFCore/"},
+		[]string{"foaf", "http://xmlns.com/foaf/0.1/"},
+	}
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+			rdf.NewURI("http://www.w3.org/1999/02/22-rdf-syntax-ns#type"),
+			rdf.NewURI("http://xmlns.com/foaf/0.1/Document")),
+		rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+			rdf.NewURI("http://purl.org/dc/terms/title"),
+			rdf.NewLangLiteral("N-Triples", "en")),
+		rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+			rdf.NewURI("http://purl.org/dc/terms/title"),
+			rdf.NewTypedLiteral("Turtle", "http://www.w3.org/2001/XMLSchema#string")),
+		rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+			rdf.NewURI("http://xmlns.com/foaf/0.1/maker"),
+			rdf.NewBlankNode("b0")),
+	}
+
+	// check for triples
+	for elt := range parser.Read("datas/test.jsonld") {
+		if cpt >= len(datas) {
+			t.Error("unexpected triple", elt)
+		} else if test, err := elt.Equals(datas[cpt]); !test || (err != nil) {
+			t.Error(elt, "should be equal to", datas[cpt])
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("read", cpt, "nodes of the file instead of", len(datas))
+	}
+
+	// check for prefixes
+	parserPrefixes := parser.Prefixes()
+	for _, prefix := range prefixes {
+		if value := parserPrefixes[prefix[0]]; value != prefix[1] {
+			t.Error("for key", prefix[0], "expected value", prefix[1], "but got", value)
+		}
+	}
+}
@@ -6,9 +6,10 @@ package parser
 
 import (
 	"bufio"
+	"errors"
 	"github.com/Callidon/joseki/rdf"
 	"io"
-	"os"
+	"strconv"
 )
 
 // NTParser is a parser for reading & loading triples in N-Triples format.
@@ -16,12 +17,49 @@ import (
 // N-Triples reference : https://www.w3.org/2011/rdf-wg/wiki/N-Triples-Format
 type NTParser struct {
 	cutter *lineCutter
+	base   string
+}
+
+// ntToken identifies the token corresponding to an element of a line in N-Triples format.
+// The relative IRIs are resolved against the base IRI, if there is one.
+func ntToken(elt string, lineNumber, rowNumber int, base string) rdfToken {
+	switch {
+	case elt == ".":
+		return newTokenEnd(lineNumber, rowNumber)
+	case elt == "<<":
+		return newTokenQuotedStart(lineNumber, rowNumber)
+	case elt == ">>":
+		return newTokenQuotedEnd(lineNumber, rowNumber)
+	case string(elt[0]) == "<" && string(elt[len(elt)-1]) == ">":
+		value, err := resolveIRI(base, elt[1:len(elt)-1])
+		if err != nil {
+			return newTokenIllegal(err.Error(), lineNumber, rowNumber)
+		}
+		return newTokenURI(value)
+	case len(elt) >= 2 && string(elt[0]) == "_" && string(elt[1]) == ":":
+		return newTokenBlankNode(elt[2:])
+	case len(elt) >= 2 && (string(elt[0]) == "\"" && string(elt[len(elt)-1]) == "\"" || string(elt[0]) == "'" && string(elt[len(elt)-1]) == "'"):
+		value, err := unescapeString(elt[1 : len(elt)-1])
+		if err != nil {
+			return newTokenIllegal(err.Error(), lineNumber, rowNumber)
+		}
+		return newTokenLiteral(value)
+	case len(elt) >= 2 && elt[0:2] == "^^":
+		datatype, err := resolveDatatype(base, elt[2:])
+		if err != nil {
+			return newTokenIllegal(err.Error(), lineNumber, rowNumber)
+		}
+		return newTokenType(datatype, lineNumber, rowNumber)
+	case string(elt[0]) == "@":
+		return newTokenLang(elt[1:], lineNumber, rowNumber)
+	}
+	return newTokenIllegal("Unexpected token when scanning '"+elt+"'", lineNumber, rowNumber)
 }
 
 // scanNtriples read a file in N-Triples format, identify and extract token with their values.
 //
 // The results are sent through a channel, which is closed when the scan of the file has been completed.
-func scanNtriples(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
+func scanNtriples(reader io.Reader, out chan<- rdfToken, l *lineCutter, base string) {
 	// walk through the file using a goroutine
 	go func() {
 		defer close(out)
@@ -42,22 +80,7 @@ func scanNtriples(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 				if string(elt[0]) == "#" {
 					break
 				}
-				switch {
-				case elt == ".":
-					out <- newTokenEnd(lineNumber, rowNumber)
-				case string(elt[0]) == "<" && string(elt[len(elt)-1]) == ">":
-					out <- newTokenURI(elt[1 : len(elt)-1])
-				case string(elt[0]) == "_" && string(elt[1]) == ":":
-					out <- newTokenBlankNode(elt[2:])
-				case string(elt[0]) == "\"" && string(elt[len(elt)-1]) == "\"", string(elt[0]) == "'" && string(elt[len(elt)-1]) == "'":
-					out <- newTokenLiteral(elt[1 : len(elt)-1])
-				case len(elt) >= 2 && elt[0:2] == "^^":
-					out <- newTokenType(elt[2:], lineNumber, rowNumber)
-				case string(elt[0]) == "@":
-					out <- newTokenLang(elt[1:], lineNumber, rowNumber)
-				default:
-					out <- newTokenIllegal("Unexpected token when scanning '"+elt+"'", lineNumber, rowNumber)
-				}
+				out <- ntToken(elt, lineNumber, rowNumber, base)
 				rowNumber += len(elt) + 1
 			}
 			lineNumber++
@@ -67,7 +90,13 @@ func scanNtriples(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 
 // NewNTParser creates a new NTParser
 func NewNTParser() *NTParser {
-	return &NTParser{newLineCutter(wordRegexp)}
+	return &NTParser{newLineCutter(wordRegexp), ""}
+}
+
+// SetBase sets the base IRI used to resolve the relative IRIs of the documents.
+// N-Triples documents should only contain absolute IRIs, so it is only useful to read malformed documents.
+func (p *NTParser) SetBase(base string) {
+	p.base = base
 }
 
 // Prefixes returns the prefixes read by the parser during the last parsing.
@@ -87,11 +116,11 @@ func (p NTParser) Read(filename string) chan rdf.Triple {
 	// scan the file & analyse the tokens using a goroutine
 	go func() {
 		defer close(out)
-		f, err := os.Open(filename)
+		f, err := Open(filename)
 		check(err)
 		defer f.Close()
 		// launch the scan, then interpret each token produced
-		go scanNtriples(bufio.NewReader(f), tokenPipe, p.cutter)
+		go scanNtriples(bufio.NewReader(f), tokenPipe, p.cutter, p.base)
 		for token := range tokenPipe {
 			err = token.Interpret(stack, nil, out)
 			check(err)
@@ -99,3 +128,38 @@ func (p NTParser) Read(filename string) chan rdf.Triple {
 	}()
 	return out
 }
+
+// ParseLine parses a line of a document in N-Triples format, which must contain a whole triple.
+// The line number is only used to report errors.
+//
+// It returns False if the line doesn't contain any triple, i.e. it is a blank line or a comment.
+func (p NTParser) ParseLine(line string, lineNumber int) (rdf.Triple, bool, error) {
+	var triple rdf.Triple
+	stack := newStack()
+	out := make(chan rdf.Triple, 1)
+	rowNumber, found := 1, false
+	for _, elt := range p.cutter.extractSegments(line) {
+		if string(elt[0]) == "#" {
+			break
+		}
+		if found {
+			return triple, false, errors.New("Error : unexpected token '" + elt + "' after the end of the triple at line " + strconv.Itoa(lineNumber))
+		}
+		if err := ntToken(elt, lineNumber, rowNumber, p.base).Interpret(stack, nil, out); err != nil {
+			return triple, false, err
+		}
+		select {
+		case triple = <-out:
+			found = true
+		default:
+		}
+		rowNumber += len(elt) + 1
+	}
+	if !found && stack.Len() > 0 {
+		return triple, false, errors.New("Error : the triple at line " + strconv.Itoa(lineNumber) + " must be on a single line")
+	} else if stack.Len() > 0 {
+		// some nodes haven't been used by the triple, e.g. the start of an unclosed quoted triple
+		return triple, false, errors.New("Error : malformed triple at line " + strconv.Itoa(lineNumber))
+	}
+	return triple, found, nil
+}
@@ -47,7 +47,7 @@ func TestIllegalTokenNTParser(t *testing.T) {
 	input := "illegal_token"
 	expectedMsg := "Unexpected token when scanning 'illegal_token' at line : 1 row : 1"
 	out := make(chan rdfToken, bufferSize)
-	scanNtriples(strings.NewReader(input), out, newLineCutter(wordRegexp))
+	scanNtriples(strings.NewReader(input), out, newLineCutter(wordRegexp), "")
 
 	token := <-out
 	tokenErr := token.Interpret(nil, nil, nil).Error()
@@ -55,3 +55,158 @@ func TestIllegalTokenNTParser(t *testing.T) {
 		t.Error("expected illegal token", expectedMsg, "but instead got", tokenErr)
 	}
 }
+
+func TestParseLineNTParser(t *testing.T) {
+	parser := NewNTParser()
+	lines := []string{
+		`<http://example.org/s> <http://example.org/p> "Hello"@en . # a comment`,
+		`_:b0 <http://example.org/p> <http://example.org/o> .`,
+		`<http://example.org/s> <http://example.org/p> "22"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+	}
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewURI("http://example.org/s"), rdf.NewURI("http://example.org/p"), rdf.NewLangLiteral("Hello", "en")),
+		rdf.NewTriple(rdf.NewBlankNode("b0"), rdf.NewURI("http://example.org/p"), rdf.NewURI("http://example.org/o")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/s"), rdf.NewURI("http://example.org/p"),
+			rdf.NewTypedLiteral("22", "<http://www.w3.org/2001/XMLSchema#integer>")),
+	}
+	for i, line := range lines {
+		triple, found, err := parser.ParseLine(line, i+1)
+		if err != nil || !found {
+			t.Error("parsing the line", line, "shouldn't produce the error :", err)
+		} else if test, err := triple.Equals(datas[i]); !test || err != nil {
+			t.Error(datas[i], "should be equal to", triple)
+		}
+	}
+
+	for _, line := range []string{"", "   ", "# a comment"} {
+		if _, found, err := parser.ParseLine(line, 1); found || err != nil {
+			t.Error("the line", line, "shouldn't contain a triple")
+		}
+	}
+	invalidLines := []string{
+		`<http://example.org/s> <http://example.org/p>`,
+		`<http://example.org/s> <http://example.org/p> <http://example.org/o> . <http://example.org/s>`,
+		`<http://example.org/s> illegal <http://example.org/o> .`,
+	}
+	for _, line := range invalidLines {
+		if _, _, err := parser.ParseLine(line, 1); err == nil {
+			t.Error("parsing the line", line, "should produce an error")
+		}
+	}
+}
+
+func TestBaseNTParser(t *testing.T) {
+	parser := NewNTParser()
+	parser.SetBase("http://example.org/a/b")
+	expected := rdf.NewTriple(rdf.NewURI("http://example.org/a/s"), rdf.NewURI("http://example.org/p"), rdf.NewURI("http://example.org/a/b#o"))
+	triple, found, err := parser.ParseLine(`<s> </p> <#o> .`, 1)
+	if err != nil || !found {
+		t.Fatal("parsing a line with relative IRIs shouldn't produce the error :", err)
+	}
+	if test, err := triple.Equals(expected); !test || err != nil {
+		t.Error(expected, "should be equal to", triple)
+	}
+	expected = rdf.NewTriple(rdf.NewURI("http://example.org/a/s"), rdf.NewURI("http://example.org/p"), rdf.NewTypedLiteral("1", "<http://example.org/a/b#unit>"))
+	triple, found, err = parser.ParseLine(`<s> </p> "1"^^<#unit> .`, 1)
+	if err != nil || !found {
+		t.Fatal("parsing a line with a relative datatype shouldn't produce the error :", err)
+	}
+	if test, err := triple.Equals(expected); !test || err != nil {
+		t.Error(expected, "should be equal to", triple)
+	}
+}
+
+func TestQuotedTriplesNTParser(t *testing.T) {
+	parser := NewNTParser()
+	knows := rdf.NewURI("http://xmlns.com/foaf/0.1/knows")
+	source := rdf.NewURI("http://example.org/source")
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewURI("http://example.org/alice"), knows, rdf.NewURI("http://example.org/bob"))),
+			source, rdf.NewLiteral("facebook")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/carol"), rdf.NewURI("http://example.org/says"),
+			rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewURI("http://example.org/bob"), rdf.NewURI("http://xmlns.com/foaf/0.1/age"), rdf.NewLiteral("23")))),
+		rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(
+			rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewBlankNode("b0"), knows, rdf.NewBlankNode("b1"))),
+			rdf.NewURI("http://example.org/certainty"),
+			rdf.NewTypedLiteral("0.5", "<http://www.w3.org/2001/XMLSchema#decimal>"))),
+			source, rdf.NewURI("http://example.org/carol")),
+	}
+	cpt := 0
+	for elt := range parser.Read("datas/quoted.nt") {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected")
+		}
+		if test, err := elt.Equals(datas[cpt]); !test || err != nil {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+
+	malformed := []string{
+		"<< <http://example.org/a> <http://example.org/b> >> <http://example.org/c> <http://example.org/d> .",
+		"<http://example.org/a> <http://example.org/b> <http://example.org/c> >> .",
+		"<< <http://example.org/a> <http://example.org/b> <http://example.org/c> <http://example.org/d> .",
+	}
+	for _, line := range malformed {
+		if _, _, err := parser.ParseLine(line, 1); err == nil {
+			t.Error("parsing the malformed quoted triple", line, "should produce an error")
+		}
+	}
+}
+
+func TestDirLangNTParser(t *testing.T) {
+	parser := NewNTParser()
+	expected := rdf.NewTriple(rdf.NewURI("http://example.org/book"), rdf.NewURI("http://purl.org/dc/terms/title"),
+		rdf.NewDirLangLiteral("Harry Potter", "ar-EG", "rtl"))
+	triple, found, err := parser.ParseLine(`<http://example.org/book> <http://purl.org/dc/terms/title> "Harry Potter"@ar-eg--rtl .`, 1)
+	if err != nil || !found {
+		t.Fatal("parsing a literal with a base direction shouldn't produce the error :", err)
+	}
+	if literal, _ := triple.Object.(rdf.Literal); literal != expected.Object {
+		t.Error(expected.Object, "should be equal to", triple.Object)
+	}
+
+	malformed := []string{
+		`<http://example.org/book> <http://purl.org/dc/terms/title> "Harry Potter"@ar--up .`,
+		`<http://example.org/book> <http://purl.org/dc/terms/title> "Harry Potter"@--ltr .`,
+		`<http://example.org/book> <http://purl.org/dc/terms/title> "Harry Potter"@en^^<http://example.org/type> .`,
+	}
+	for _, line := range malformed {
+		if _, _, err := parser.ParseLine(line, 1); err == nil {
+			t.Error("parsing the malformed literal", line, "should produce an error")
+		}
+	}
+}
+
+func TestEscapedStringsNTParser(t *testing.T) {
+	parser := NewNTParser()
+	lines := map[string]string{
+		`<http://example.org/s> <http://example.org/p> "line1\nline2" .`:          "line1\nline2",
+		`<http://example.org/s> <http://example.org/p> "Alice \"Al\" \\o/"@en .`:  "Alice \"Al\" \\o/",
+		`<http://example.org/s> <http://example.org/p> "tab\tend\\" .`:            "tab\tend\\",
+		`<http://example.org/s> <http://example.org/p> "caf\u00E9 \U0001F600" .`:  "café 😀",
+		`<http://example.org/s> <http://example.org/p> "it\'s # not a comment" .`: "it's # not a comment",
+	}
+	for line, expected := range lines {
+		triple, found, err := parser.ParseLine(line, 1)
+		if err != nil || !found {
+			t.Error("parsing the line", line, "shouldn't produce the error :", err)
+		} else if literal, _ := triple.Object.(rdf.Literal); literal.Value != expected {
+			t.Errorf("expected the string %q but instead got %q", expected, literal.Value)
+		}
+	}
+
+	invalidLines := []string{
+		`<http://example.org/s> <http://example.org/p> "invalid \x escape" .`,
+		`<http://example.org/s> <http://example.org/p> "truncated \u00E" .`,
+		`<http://example.org/s> <http://example.org/p> "surrogate \uD800" .`,
+	}
+	for _, line := range invalidLines {
+		if _, _, err := parser.ParseLine(line, 1); err == nil {
+			t.Error("parsing the line", line, "should produce an error")
+		}
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"io/ioutil"
+	"strconv"
+)
+
+const (
+	// Namespace of the RDF vocabulary
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	// Namespace reserved for the xml:* attributes
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+	// Namespace of the its:dir attribute, which declares the base direction of the literals
+	itsNamespace = "http://www.w3.org/2005/11/its"
+)
+
+// RDFXMLParser is a parser for reading & loading triples in RDF/XML format.
+//
+// RDF/XML reference : https://www.w3.org/TR/rdf-syntax-grammar/
+type RDFXMLParser struct {
+	prefixes map[string]string
+	base     string
+}
+
+// xmlContext holds the informations inherited by an element from its ancestors.
+type xmlContext struct {
+	base string
+	lang string
+	dir  string
+}
+
+// update returns the context of an element, using the xml:base, xml:lang & its:dir attributes it declares.
+func (c xmlContext) update(elt xml.StartElement) xmlContext {
+	for _, attr := range elt.Attr {
+		switch {
+		case attr.Name.Space == xmlNamespace && attr.Name.Local == "base":
+			c.base = c.resolve(attr.Value)
+		case attr.Name.Space == xmlNamespace && attr.Name.Local == "lang":
+			c.lang = attr.Value
+		case attr.Name.Space == itsNamespace && attr.Name.Local == "dir" && rdf.ValidDirection(attr.Value):
+			c.dir = attr.Value
+		}
+	}
+	return c
+}
+
+// resolve resolves a relative IRI against the base IRI of the context.
+// The IRI is left unchanged if it cannot be resolved.
+func (c xmlContext) resolve(ref string) string {
+	iri, err := resolveIRI(c.base, ref)
+	if err != nil {
+		return ref
+	}
+	return iri
+}
+
+// rdfxmlReader walks through a RDF/XML document & produces the triples it contains.
+type rdfxmlReader struct {
+	decoder  *xml.Decoder
+	raw      []byte
+	prefixes map[string]string
+	base     string
+	labels   *blankLabeller
+	out      chan<- rdf.Triple
+}
+
+// newRDFXMLReader creates a new rdfxmlReader
+func newRDFXMLReader(raw []byte, prefixes map[string]string, base string, out chan<- rdf.Triple) *rdfxmlReader {
+	return &rdfxmlReader{xml.NewDecoder(bytes.NewReader(raw)), raw, prefixes, base, newBlankLabeller(), out}
+}
+
+// next returns the next meaningful XML token of the document, with the offset at which it starts.
+func (r *rdfxmlReader) next() (xml.Token, int64, error) {
+	for {
+		offset := r.decoder.InputOffset()
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, offset, err
+		}
+		switch token.(type) {
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			continue
+		}
+		return token, offset, nil
+	}
+}
+
+// newBlankNode creates a new Blank Node with a label unique in the document.
+func (r *rdfxmlReader) newBlankNode() rdf.BlankNode {
+	return rdf.NewBlankNode(r.labels.generate())
+}
+
+// registerPrefixes saves the namespaces declared by an element.
+func (r *rdfxmlReader) registerPrefixes(elt xml.StartElement) {
+	for _, attr := range elt.Attr {
+		if attr.Name.Space == "xmlns" {
+			r.prefixes[attr.Name.Local] = attr.Value
+		} else if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			r.prefixes[""] = attr.Value
+		}
+	}
+}
+
+// isRDF returns True if a XML name belongs to the RDF namespace, with the given local name.
+func isRDF(name xml.Name, local string) bool {
+	return name.Space == rdfNamespace && name.Local == local
+}
+
+// isSyntaxAttr returns True if an attribute is used by the XML or the RDF/XML syntax, rather than describing a property.
+func isSyntaxAttr(attr xml.Attr) bool {
+	switch {
+	case attr.Name.Space == "xmlns", attr.Name.Space == "" && attr.Name.Local == "xmlns":
+		return true
+	case attr.Name.Space == xmlNamespace, attr.Name.Space == itsNamespace:
+		return true
+	case attr.Name.Space == rdfNamespace:
+		switch attr.Name.Local {
+		case "about", "ID", "nodeID", "resource", "parseType", "datatype", "version":
+			return true
+		}
+	}
+	return false
+}
+
+// findAttr returns the value of a RDF attribute of an element, and a boolean to indicate if it has been found.
+func findAttr(elt xml.StartElement, local string) (string, bool) {
+	for _, attr := range elt.Attr {
+		if isRDF(attr.Name, local) {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// read reads the whole document, starting at the root element.
+func (r *rdfxmlReader) read() error {
+	ctx := xmlContext{r.base, "", ""}
+	for {
+		token, _, err := r.next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		start, isStart := token.(xml.StartElement)
+		if !isStart {
+			continue
+		}
+		if !isRDF(start.Name, "RDF") {
+			_, err = r.readNodeElement(start, ctx)
+			return err
+		}
+		// the children of a rdf:RDF element are all node elements
+		r.registerPrefixes(start)
+		rootCtx := ctx.update(start)
+		for {
+			token, _, err = r.next()
+			if err != nil {
+				return err
+			}
+			switch elt := token.(type) {
+			case xml.StartElement:
+				if _, err = r.readNodeElement(elt, rootCtx); err != nil {
+					return err
+				}
+			case xml.EndElement:
+				return nil
+			}
+		}
+	}
+}
+
+// readNodeElement reads a node element & its properties, then returns the node it describes.
+func (r *rdfxmlReader) readNodeElement(start xml.StartElement, parent xmlContext) (rdf.Node, error) {
+	var subject rdf.Node
+	r.registerPrefixes(start)
+	ctx := parent.update(start)
+
+	// identify the subject of the node element
+	if about, found := findAttr(start, "about"); found {
+		subject = rdf.NewURI(ctx.resolve(about))
+	} else if id, found := findAttr(start, "ID"); found {
+		subject = rdf.NewURI(ctx.resolve("#" + id))
+	} else if nodeID, found := findAttr(start, "nodeID"); found {
+		subject = rdf.NewBlankNode(r.labels.label(nodeID))
+	} else {
+		subject = r.newBlankNode()
+	}
+
+	// a typed node element is a shortcut for a rdf:type property
+	if !isRDF(start.Name, "Description") {
+		r.out <- rdf.NewTriple(subject, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI(start.Name.Space+start.Name.Local))
+	}
+	r.readPropertyAttrs(subject, start, ctx)
+
+	liCpt := 0
+	for {
+		token, _, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		switch elt := token.(type) {
+		case xml.StartElement:
+			if err = r.readPropertyElement(subject, elt, ctx, &liCpt); err != nil {
+				return nil, err
+			}
+		case xml.CharData:
+			if len(bytes.TrimSpace(elt)) > 0 {
+				return nil, errors.New("unexpected text content in node element <" + start.Name.Local + ">")
+			}
+		case xml.EndElement:
+			return subject, nil
+		}
+	}
+}
+
+// readPropertyAttrs produces a triple for each property attribute of an element.
+func (r *rdfxmlReader) readPropertyAttrs(subject rdf.Node, elt xml.StartElement, ctx xmlContext) {
+	for _, attr := range elt.Attr {
+		if isSyntaxAttr(attr) {
+			continue
+		}
+		predicate := rdf.NewURI(attr.Name.Space + attr.Name.Local)
+		if isRDF(attr.Name, "type") {
+			r.out <- rdf.NewTriple(subject, predicate, rdf.NewURI(ctx.resolve(attr.Value)))
+		} else if ctx.lang != "" {
+			r.out <- rdf.NewTriple(subject, predicate, rdf.NewDirLangLiteral(attr.Value, ctx.lang, ctx.dir))
+		} else {
+			r.out <- rdf.NewTriple(subject, predicate, rdf.NewLiteral(attr.Value))
+		}
+	}
+}
+
+// emit sends a triple produced by a property element, and reifies it if the element has a rdf:ID.
+func (r *rdfxmlReader) emit(triple rdf.Triple, start xml.StartElement, ctx xmlContext) {
+	r.out <- triple
+	if id, found := findAttr(start, "ID"); found {
+		statement := rdf.NewURI(ctx.resolve("#" + id))
+		r.out <- rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI(rdfNamespace+"Statement"))
+		r.out <- rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"subject"), triple.Subject)
+		r.out <- rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"predicate"), triple.Predicate)
+		r.out <- rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"object"), triple.Object)
+	}
+}
+
+// readPropertyElement reads a property element of a subject & produces the associated triples.
+func (r *rdfxmlReader) readPropertyElement(subject rdf.Node, start xml.StartElement, parent xmlContext, liCpt *int) error {
+	r.registerPrefixes(start)
+	ctx := parent.update(start)
+
+	// rdf:li elements are converted to the rdf:_n container membership properties
+	predicate := rdf.NewURI(start.Name.Space + start.Name.Local)
+	if isRDF(start.Name, "li") {
+		*liCpt++
+		predicate = rdf.NewURI(rdfNamespace + "_" + strconv.Itoa(*liCpt))
+	}
+
+	parseType, hasParseType := findAttr(start, "parseType")
+	switch {
+	case hasParseType && parseType == "Resource":
+		object := r.newBlankNode()
+		r.emit(rdf.NewTriple(subject, predicate, object), start, ctx)
+		return r.readResourceContent(object, ctx)
+	case hasParseType && parseType == "Collection":
+		return r.readCollection(subject, predicate, start, ctx)
+	case hasParseType:
+		// every other parse type is processed as "Literal"
+		content, err := r.readXMLLiteral()
+		if err != nil {
+			return err
+		}
+		r.emit(rdf.NewTriple(subject, predicate, rdf.NewTypedLiteral(content, rdfNamespace+"XMLLiteral")), start, ctx)
+		return nil
+	}
+
+	var text bytes.Buffer
+	var object rdf.Node
+	for {
+		token, _, err := r.next()
+		if err != nil {
+			return err
+		}
+		switch elt := token.(type) {
+		case xml.StartElement:
+			if object != nil || len(bytes.TrimSpace(text.Bytes())) > 0 {
+				return errors.New("a property element can only contain one node element, in <" + start.Name.Local + ">")
+			}
+			if object, err = r.readNodeElement(elt, ctx); err != nil {
+				return err
+			}
+		case xml.CharData:
+			text.Write(elt)
+		case xml.EndElement:
+			if object == nil {
+				object = r.propertyValue(start, text.String(), ctx)
+			}
+			r.emit(rdf.NewTriple(subject, predicate, object), start, ctx)
+			return nil
+		}
+	}
+}
+
+// propertyValue returns the object of a property element which doesn't contain a node element.
+func (r *rdfxmlReader) propertyValue(start xml.StartElement, text string, ctx xmlContext) rdf.Node {
+	if datatype, found := findAttr(start, "datatype"); found {
+		return rdf.NewTypedLiteral(text, ctx.resolve(datatype))
+	}
+	// check if the element is an empty property element, which describe a resource
+	var object rdf.Node
+	if resource, found := findAttr(start, "resource"); found {
+		object = rdf.NewURI(ctx.resolve(resource))
+	} else if nodeID, found := findAttr(start, "nodeID"); found {
+		object = rdf.NewBlankNode(r.labels.label(nodeID))
+	} else {
+		for _, attr := range start.Attr {
+			if !isSyntaxAttr(attr) {
+				object = r.newBlankNode()
+				break
+			}
+		}
+	}
+	if object != nil {
+		r.readPropertyAttrs(object, start, ctx)
+		return object
+	}
+	if ctx.lang != "" {
+		return rdf.NewDirLangLiteral(text, ctx.lang, ctx.dir)
+	}
+	return rdf.NewLiteral(text)
+}
+
+// readResourceContent reads the content of a property element with rdf:parseType="Resource".
+func (r *rdfxmlReader) readResourceContent(subject rdf.Node, ctx xmlContext) error {
+	liCpt := 0
+	for {
+		token, _, err := r.next()
+		if err != nil {
+			return err
+		}
+		switch elt := token.(type) {
+		case xml.StartElement:
+			if err = r.readPropertyElement(subject, elt, ctx, &liCpt); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// readCollection reads the content of a property element with rdf:parseType="Collection",
+// and produces the RDF list which holds the node elements it contains.
+func (r *rdfxmlReader) readCollection(subject, predicate rdf.Node, start xml.StartElement, ctx xmlContext) error {
+	items := make([]rdf.Node, 0)
+	for end := false; !end; {
+		token, _, err := r.next()
+		if err != nil {
+			return err
+		}
+		switch elt := token.(type) {
+		case xml.StartElement:
+			item, err := r.readNodeElement(elt, ctx)
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		case xml.EndElement:
+			end = true
+		}
+	}
+
+	var head rdf.Node = rdf.NewURI(rdfNamespace + "nil")
+	cells := make([]rdf.Node, len(items))
+	for i := range items {
+		cells[i] = r.newBlankNode()
+	}
+	if len(cells) > 0 {
+		head = cells[0]
+	}
+	r.emit(rdf.NewTriple(subject, predicate, head), start, ctx)
+	for i, item := range items {
+		var rest rdf.Node = rdf.NewURI(rdfNamespace + "nil")
+		if i+1 < len(cells) {
+			rest = cells[i+1]
+		}
+		r.out <- rdf.NewTriple(cells[i], rdf.NewURI(rdfNamespace+"first"), item)
+		r.out <- rdf.NewTriple(cells[i], rdf.NewURI(rdfNamespace+"rest"), rest)
+	}
+	return nil
+}
+
+// readXMLLiteral reads the content of a property element with rdf:parseType="Literal", and returns it unmodified.
+func (r *rdfxmlReader) readXMLLiteral() (string, error) {
+	begin := r.decoder.InputOffset()
+	depth := 0
+	for {
+		token, offset, err := r.next()
+		if err != nil {
+			return "", err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				return string(r.raw[begin:offset]), nil
+			}
+			depth--
+		}
+	}
+}
+
+// NewRDFXMLParser creates a new RDFXMLParser
+func NewRDFXMLParser() *RDFXMLParser {
+	return &RDFXMLParser{make(map[string]string), ""}
+}
+
+// SetBase sets the base IRI used to resolve the relative IRIs of the documents.
+// It is replaced by the xml:base attributes found in a document.
+func (p *RDFXMLParser) SetBase(base string) {
+	p.base = base
+}
+
+// Prefixes returns the prefixes read by the parser during the last parsing.
+// For RDF/XML, they are the XML namespaces declared in the document.
+func (p RDFXMLParser) Prefixes() map[string]string {
+	return p.prefixes
+}
+
+// Read a file containg RDF triples in RDF/XML format & convert them in triples.
+//
+// Triples generated are send throught a channel, which is closed when the parsing of the file has been completed.
+func (p *RDFXMLParser) Read(filename string) chan rdf.Triple {
+	out := make(chan rdf.Triple, bufferSize)
+
+	// parse the file using a goroutine
+	go func() {
+		defer close(out)
+		f, err := Open(filename)
+		check(err)
+		defer f.Close()
+		raw, err := ioutil.ReadAll(f)
+		check(err)
+		err = newRDFXMLReader(raw, p.prefixes, p.base, out).read()
+		check(err)
+	}()
+	return out
+}
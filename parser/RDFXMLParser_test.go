@@ -0,0 +1,160 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"testing"
+)
+
+func TestGeneratedLabelsRDFXMLParser(t *testing.T) {
+	parser := NewRDFXMLParser()
+	// the blank nodes without a rdf:nodeID never take the labels written in the document
+	labels := make(map[string]bool)
+	for triple := range parser.Read("datas/genid.rdf") {
+		labels[triple.Subject.(rdf.BlankNode).Value] = true
+	}
+	if len(labels) != 3 || !labels["genid1"] {
+		t.Error("expected 3 distinct blank nodes & the label genid1 to be kept, but instead got", labels)
+	}
+}
+
+func TestReadRDFXMLParser(t *testing.T) {
+	parser := NewRDFXMLParser()
+	cpt := 0
+	base := "http://www.w3.org/2001/sw/RDFCore/"
+	subject := rdf.NewURI(base + "ntriples")
+	statement := rdf.NewURI(base + "#source")
+	prefixes := [][]string{
+		[]string{"rdf", "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+		[]string{"dc", "http://purl.org/dc/terms/"},
+		[]string{"foaf", "http://xmlns.com/foaf/0.1/"},
+	}
+	datas := []rdf.Triple{
+		rdf.NewTriple(subject, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI("http://xmlns.com/foaf/0.1/Document")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("N-Triples")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLangLiteral("N-Triples", "en")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/title"),
+			rdf.NewTypedLiteral("Turtle", "http://www.w3.org/2001/XMLSchema#string")),
+		rdf.NewTriple(subject, rdf.NewURI("http://xmlns.com/foaf/0.1/maker"), rdf.NewBlankNode("genid1")),
+		rdf.NewTriple(rdf.NewBlankNode("genid1"), rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("My Title")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/source"), rdf.NewURI(base+"#spec")),
+		rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI(rdfNamespace+"Statement")),
+		rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"subject"), subject),
+		rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"predicate"), rdf.NewURI("http://purl.org/dc/terms/source")),
+		rdf.NewTriple(statement, rdf.NewURI(rdfNamespace+"object"), rdf.NewURI(base+"#spec")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/description"),
+			rdf.NewTypedLiteral("<b>bold</b> text", rdfNamespace+"XMLLiteral")),
+		rdf.NewTriple(subject, rdf.NewURI("http://purl.org/dc/terms/hasPart"), rdf.NewBlankNode("genid2")),
+		rdf.NewTriple(rdf.NewBlankNode("genid2"), rdf.NewURI(rdfNamespace+"first"), rdf.NewURI(base+"part1")),
+		rdf.NewTriple(rdf.NewBlankNode("genid2"), rdf.NewURI(rdfNamespace+"rest"), rdf.NewBlankNode("genid3")),
+		rdf.NewTriple(rdf.NewBlankNode("genid3"), rdf.NewURI(rdfNamespace+"first"), rdf.NewBlankNode("part2")),
+		rdf.NewTriple(rdf.NewBlankNode("genid3"), rdf.NewURI(rdfNamespace+"rest"), rdf.NewURI(rdfNamespace+"nil")),
+		rdf.NewTriple(rdf.NewURI(base+"authors"), rdf.NewURI(rdfNamespace+"type"), rdf.NewURI(rdfNamespace+"Seq")),
+		rdf.NewTriple(rdf.NewURI(base+"authors"), rdf.NewURI(rdfNamespace+"_1"), rdf.NewLangLiteral("Dave", "fr")),
+		rdf.NewTriple(rdf.NewURI(base+"authors"), rdf.NewURI(rdfNamespace+"_2"), rdf.NewLangLiteral("Art", "fr")),
+	}
+
+	// check for triples
+	for elt := range parser.Read("datas/test.rdf") {
+		if cpt >= len(datas) {
+			t.Error("unexpected triple", elt)
+		} else if test, err := elt.Equals(datas[cpt]); !test || (err != nil) {
+			t.Error(elt, "should be equal to", datas[cpt])
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("read", cpt, "nodes of the file instead of", len(datas))
+	}
+
+	// check for prefixes
+	parserPrefixes := parser.Prefixes()
+	for _, prefix := range prefixes {
+		value, inPrefixes := parserPrefixes[prefix[0]]
+		if !inPrefixes {
+			t.Error("the prefix", prefix[0], "hasn't been read by the parser")
+		}
+		if value != prefix[1] {
+			t.Error("for key", prefix[0], "expected value", prefix[1], "but got", value)
+		}
+	}
+}
+
+func TestBaseRDFXMLParser(t *testing.T) {
+	input := "<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:ex=\"http://example.org/\">" +
+		"<rdf:Description rdf:about=\"#a\"><ex:p rdf:resource=\"../b\"/></rdf:Description>" +
+		"<rdf:Description xml:base=\"http://example.com/\" rdf:about=\"c\"><ex:p rdf:resource=\"#d\"/></rdf:Description>" +
+		"</rdf:RDF>"
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewURI("http://example.org/docs/doc#a"), rdf.NewURI("http://example.org/p"), rdf.NewURI("http://example.org/b")),
+		rdf.NewTriple(rdf.NewURI("http://example.com/c"), rdf.NewURI("http://example.org/p"), rdf.NewURI("http://example.com/#d")),
+	}
+	out := make(chan rdf.Triple, bufferSize)
+	if err := newRDFXMLReader([]byte(input), make(map[string]string), "http://example.org/docs/doc", out).read(); err != nil {
+		t.Fatal("reading the document shouldn't produce the error :", err)
+	}
+	close(out)
+	cpt := 0
+	for elt := range out {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected")
+		}
+		if test, err := elt.Equals(datas[cpt]); !test || err != nil {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+}
+
+func TestIllegalRDFXMLParser(t *testing.T) {
+	inputs := []string{
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\"><rdf:Description>illegal text</rdf:Description></rdf:RDF>",
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\"><rdf:Description>",
+	}
+
+	for _, input := range inputs {
+		out := make(chan rdf.Triple, bufferSize)
+		if err := newRDFXMLReader([]byte(input), make(map[string]string), "", out).read(); err == nil {
+			t.Error("reading the malformed document", input, "should produce an error")
+		}
+	}
+}
+
+func TestDirLangRDFXMLParser(t *testing.T) {
+	input := "<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:its=\"http://www.w3.org/2005/11/its\"" +
+		" xmlns:ex=\"http://example.org/\" rdf:version=\"1.2\" its:version=\"2.0\">" +
+		"<rdf:Description rdf:about=\"http://example.org/a\" xml:lang=\"ar\" its:dir=\"rtl\" ex:label=\"attr\">" +
+		"<ex:title>title</ex:title><ex:name its:dir=\"ltr\">name</ex:name><ex:note xml:lang=\"\">note</ex:note>" +
+		"</rdf:Description></rdf:RDF>"
+	subject := rdf.NewURI("http://example.org/a")
+	datas := []rdf.Triple{
+		rdf.NewTriple(subject, rdf.NewURI("http://example.org/label"), rdf.NewDirLangLiteral("attr", "ar", "rtl")),
+		rdf.NewTriple(subject, rdf.NewURI("http://example.org/title"), rdf.NewDirLangLiteral("title", "ar", "rtl")),
+		rdf.NewTriple(subject, rdf.NewURI("http://example.org/name"), rdf.NewDirLangLiteral("name", "ar", "ltr")),
+		rdf.NewTriple(subject, rdf.NewURI("http://example.org/note"), rdf.NewLiteral("note")),
+	}
+	out := make(chan rdf.Triple, bufferSize)
+	if err := newRDFXMLReader([]byte(input), make(map[string]string), "", out).read(); err != nil {
+		t.Fatal("reading the document shouldn't produce the error :", err)
+	}
+	close(out)
+	cpt := 0
+	for elt := range out {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected :", elt)
+		}
+		if elt.Object != datas[cpt].Object {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"bufio"
 	"github.com/Callidon/joseki/rdf"
 	"io"
-	"os"
 	"strings"
 )
 
@@ -16,19 +15,23 @@ import (
 //
 // Turtle reference : https://www.w3.org/TR/turtle/
 type TurtleParser struct {
-	prefixes map[string]string
+	prefixes *rdf.PrefixMap
 	cutter   *lineCutter
+	base     string
 }
 
 // scanTurtle read a file in Turtle format, identify and extract token with their values.
+// The relative IRIs are resolved against the base IRI, which is updated by the base directives of the file.
 //
 // The results are sent through a channel, which is closed when the scan of the file has been completed.
-func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
+func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter, base string) {
 	// walk through the file using a goroutine
 	go func() {
 		defer close(out)
 		var prefixName, prefixValue string
-		var scanPrefixesDone bool
+		var scanPrefixesDone, sparqlPrefix bool
+		// the blank nodes introduced by [ are labelled genid1, genid2, etc, in the order of the document
+		labels := newBlankLabeller()
 
 		scanner := bufio.NewScanner(reader)
 		lineNumber := 1
@@ -40,7 +43,24 @@ func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 				lineNumber++
 				continue
 			}
-			scanPrefixesDone = (line[0] != "@prefix")
+			// read the base directives, e.g. @base <http://example.org/> . or BASE <http://example.org/>
+			if line[0] == "@base" || strings.EqualFold(line[0], "BASE") {
+				sparqlBase := line[0] != "@base"
+				if len(line) < 2 || string(line[1][0]) != "<" || string(line[1][len(line[1])-1]) != ">" || (!sparqlBase && (len(line) < 3 || line[2] != ".")) {
+					out <- newTokenIllegal("Unexpected token : "+strings.Join(line, " ")+", expected a base definition", lineNumber, rowNumber)
+					return
+				}
+				value, err := resolveIRI(base, line[1][1:len(line[1])-1])
+				if err != nil {
+					out <- newTokenIllegal(err.Error(), lineNumber, rowNumber)
+					return
+				}
+				base = value
+				lineNumber++
+				continue
+			}
+			scanPrefixesDone = (line[0] != "@prefix") && !strings.EqualFold(line[0], "PREFIX")
+			sparqlPrefix = strings.EqualFold(line[0], "PREFIX")
 			// scan elements of the line
 			for _, elt := range line {
 				// skip comments
@@ -49,7 +69,7 @@ func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 				}
 				if !scanPrefixesDone {
 					switch {
-					case elt == "@prefix" || elt == ":":
+					case elt == "@prefix" || strings.EqualFold(elt, "PREFIX") || elt == ":":
 						continue
 					case elt == ".":
 						out <- newTokenPrefix(prefixName, prefixValue)
@@ -65,7 +85,17 @@ func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 							out <- newTokenIllegal("Unexpected token : "+elt, lineNumber, rowNumber)
 							return
 						}
-						prefixValue = elt[1 : len(elt)-1]
+						value, err := resolveIRI(base, elt[1:len(elt)-1])
+						if err != nil {
+							out <- newTokenIllegal(err.Error(), lineNumber, rowNumber)
+							return
+						}
+						prefixValue = value
+						// the SPARQL-like prefix directives don't end with a dot
+						if sparqlPrefix {
+							out <- newTokenPrefix(prefixName, prefixValue)
+							prefixName, prefixValue = "", ""
+						}
 					default:
 						out <- newTokenIllegal("Unexpected token when scanning '"+elt+"', expected a prefix definition", lineNumber, rowNumber)
 					}
@@ -73,20 +103,41 @@ func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 					switch {
 					case elt == ".", elt == "]":
 						out <- newTokenEnd(lineNumber, rowNumber)
-					case elt == ";", elt == ",", elt == "[":
+					case elt == ";", elt == ",":
 						out <- newTokenSep(elt, lineNumber, rowNumber)
+					case elt == "<<":
+						out <- newTokenQuotedStart(lineNumber, rowNumber)
+					case elt == ">>":
+						out <- newTokenQuotedEnd(lineNumber, rowNumber)
+					case elt == "[":
+						out <- newTokenBlankSep(labels.generate(), lineNumber, rowNumber)
 					case string(elt[0]) == "<" && string(elt[len(elt)-1]) == ">":
-						out <- newTokenURI(elt[1 : len(elt)-1])
+						value, err := resolveIRI(base, elt[1:len(elt)-1])
+						if err != nil {
+							out <- newTokenIllegal(err.Error(), lineNumber, rowNumber)
+							return
+						}
+						out <- newTokenURI(value)
 					case string(elt[0]) == "\"" && string(elt[len(elt)-1]) == "\"", string(elt[0]) == "'" && string(elt[len(elt)-1]) == "'":
-						out <- newTokenLiteral(elt[1 : len(elt)-1])
+						value, err := unescapeString(elt[1 : len(elt)-1])
+						if err != nil {
+							out <- newTokenIllegal(err.Error(), lineNumber, rowNumber)
+							return
+						}
+						out <- newTokenLiteral(value)
 					case len(elt) >= 2 && elt[0:2] == "^^":
-						out <- newTokenType(elt[2:], lineNumber, rowNumber)
+						datatype, err := resolveDatatype(base, elt[2:])
+						if err != nil {
+							out <- newTokenIllegal(err.Error(), lineNumber, rowNumber)
+							return
+						}
+						out <- newTokenType(datatype, lineNumber, rowNumber)
 					case string(elt[0]) == "@":
 						out <- newTokenLang(elt[1:], lineNumber, rowNumber)
 					case string(elt[0]) == "_" && string(elt[1]) == ":":
-						out <- newTokenBlankNode(elt[2:])
+						out <- newTokenBlankNode(labels.label(elt[2:]))
 					case string(elt[0]) == "?":
-						out <- newTokenBlankNode(elt[1:])
+						out <- newTokenBlankNode(labels.label(elt[1:]))
 					case strings.Index(elt, ":") > -1:
 						out <- newTokenPrefixedURI(elt, lineNumber, rowNumber)
 					default:
@@ -102,12 +153,18 @@ func scanTurtle(reader io.Reader, out chan<- rdfToken, l *lineCutter) {
 
 // NewTurtleParser creates a new TurtleParser
 func NewTurtleParser() *TurtleParser {
-	return &TurtleParser{make(map[string]string), newLineCutter(wordRegexp)}
+	return &TurtleParser{rdf.NewPrefixMap(), newLineCutter(wordRegexp), ""}
+}
+
+// SetBase sets the base IRI used to resolve the relative IRIs of the documents.
+// It is replaced by the base directives found in a document.
+func (p *TurtleParser) SetBase(base string) {
+	p.base = base
 }
 
 // Prefixes returns the prefixes read by the parser during the last parsing.
 func (p TurtleParser) Prefixes() map[string]string {
-	return p.prefixes
+	return p.prefixes.Map()
 }
 
 // Read a file containg RDF triples in Turtle format & convert them in triples.
@@ -121,13 +178,13 @@ func (p *TurtleParser) Read(filename string) chan rdf.Triple {
 	// scan the file & analyse the tokens using a goroutine
 	go func() {
 		defer close(out)
-		f, err := os.Open(filename)
+		f, err := Open(filename)
 		check(err)
 		defer f.Close()
 		// launch the scan, then interpret each token produced
-		go scanTurtle(bufio.NewReader(f), tokenPipe, p.cutter)
+		go scanTurtle(bufio.NewReader(f), tokenPipe, p.cutter, p.base)
 		for token := range tokenPipe {
-			err = token.Interpret(stack, &p.prefixes, out)
+			err = token.Interpret(stack, p.prefixes, out)
 			check(err)
 		}
 	}()
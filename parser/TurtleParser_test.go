@@ -35,8 +35,8 @@ func TestReadTurtleParser(t *testing.T) {
 			rdf.NewBlankNode("a")),
 		rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
 			rdf.NewURI("http://xmlns.com/foaf/0.1/maker"),
-			rdf.NewVariable("v0")),
-		rdf.NewTriple(rdf.NewVariable("v0"),
+			rdf.NewBlankNode("genid1")),
+		rdf.NewTriple(rdf.NewBlankNode("genid1"),
 			rdf.NewURI("http://purl.org/dc/terms/title"),
 			rdf.NewLiteral("My Title")),
 	}
@@ -65,24 +65,102 @@ func TestReadTurtleParser(t *testing.T) {
 	}
 }
 
+func TestBaseTurtleParser(t *testing.T) {
+	parser := NewTurtleParser()
+	parser.SetBase("http://example.com/ignored/")
+	cpt := 0
+	datas := []rdf.Triple{
+		rdf.NewTriple(rdf.NewURI("http://example.org/people/#alice"),
+			rdf.NewURI("http://xmlns.com/foaf/0.1/knows"),
+			rdf.NewURI("http://example.org/people/bob")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/people/#alice"),
+			rdf.NewURI("http://example.org/vocab#age"),
+			rdf.NewLiteral("42")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/people/#alice"),
+			rdf.NewURI("http://example.org/vocab#height"),
+			rdf.NewTypedLiteral("1.8", "<http://example.org/vocab#metre>")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/docs/c?q"),
+			rdf.NewURI("http://xmlns.com/foaf/0.1/maker"),
+			rdf.NewURI("http://example.org/people/#alice")),
+	}
+
+	for elt := range parser.Read("datas/base.ttl") {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected")
+		}
+		if test, err := elt.Equals(datas[cpt]); !test || err != nil {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+	if value := parser.Prefixes()["ex"]; value != "http://example.org/vocab#" {
+		t.Error("expected the prefix ex to be resolved against the base IRI, but got", value)
+	}
+}
+
+func TestTypesTurtleParser(t *testing.T) {
+	parser := NewTurtleParser()
+	bob := rdf.NewURI("http://example.org/bob")
+	datas := []rdf.Triple{
+		rdf.NewTriple(bob, rdf.NewURI("http://example.org/age"), rdf.NewTypedLiteral("5", "<http://www.w3.org/2001/XMLSchema#integer>")),
+		rdf.NewTriple(bob, rdf.NewURI("http://example.org/height"), rdf.NewTypedLiteral("1.8", "<http://example.org/metre>")),
+		rdf.NewTriple(bob, rdf.NewURI("http://example.org/name"), rdf.NewLiteral("Bob")),
+	}
+	cpt := 0
+	for elt := range parser.Read("datas/types.ttl") {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected")
+		}
+		if test, err := elt.Equals(datas[cpt]); !test || err != nil {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+}
+
+func TestGeneratedLabelsTurtleParser(t *testing.T) {
+	parser := NewTurtleParser()
+	name := rdf.NewURI("http://example.org/name")
+	// the blank nodes introduced by [ never take the labels written in the document
+	labels := make(map[string]bool)
+	for triple := range parser.Read("datas/genid.ttl") {
+		if test, _ := triple.Predicate.Equals(name); test {
+			labels[triple.Subject.(rdf.BlankNode).Value] = true
+		}
+	}
+	if len(labels) != 3 || !labels["genid1"] {
+		t.Error("expected 3 distinct blank nodes & the label genid1 to be kept, but instead got", labels)
+	}
+}
+
 func TestIllegalTokenTurtleParser(t *testing.T) {
 	inputs := []string{
 		"@prefix incorrect_uri",
 		"@prefix <http://example.org> :",
 		"@prefix <http://example.org> : illegal_value",
 		"illegal_token",
+		"@base <http://example.org/>",
+		"@base <a/> .\n<b> <p> <o> .",
 	}
 	expectedMsg := []string{
 		"Unexpected token : incorrect_uri at line : 1 row : 1",
 		"Unexpected token : <http://example.org> at line : 1 row : 1",
 		"Unexpected token : <http://example.org> at line : 1 row : 1",
 		"Unexpected token when scanning 'illegal_token' at line : 1 row : 1",
+		"Unexpected token : @base <http://example.org/>, expected a base definition at line : 1 row : 1",
+		"Error : cannot resolve an IRI against the relative IRI a/ at line : 2 row : 1",
 	}
 	cpt := 0
 
 	for _, input := range inputs {
 		out := make(chan rdfToken, bufferSize)
-		scanTurtle(strings.NewReader(input), out, newLineCutter(wordRegexp))
+		scanTurtle(strings.NewReader(input), out, newLineCutter(wordRegexp), "")
 		token := <-out
 		tokenErr := token.Interpret(nil, nil, nil).Error()
 		if tokenErr != expectedMsg[cpt] {
@@ -91,3 +169,33 @@ func TestIllegalTokenTurtleParser(t *testing.T) {
 		cpt++
 	}
 }
+
+func TestQuotedTriplesTurtleParser(t *testing.T) {
+	parser := NewTurtleParser()
+	knows := rdf.NewURI("http://xmlns.com/foaf/0.1/knows")
+	alice, bob, carol := rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://example.org/bob"), rdf.NewURI("http://example.org/carol")
+	source, certainty := rdf.NewURI("http://example.org/source"), rdf.NewURI("http://example.org/certainty")
+	quoted := rdf.NewQuotedTriple(rdf.NewTriple(alice, knows, bob))
+	datas := []rdf.Triple{
+		rdf.NewTriple(quoted, source, rdf.NewLiteral("facebook")),
+		rdf.NewTriple(quoted, certainty, rdf.NewLiteral("0.9")),
+		rdf.NewTriple(carol, rdf.NewURI("http://example.org/says"),
+			rdf.NewQuotedTriple(rdf.NewTriple(bob, rdf.NewURI("http://xmlns.com/foaf/0.1/age"), rdf.NewLiteral("23")))),
+		rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(
+			rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewBlankNode("b0"), knows, bob)), certainty, rdf.NewLiteral("0.5"))),
+			source, carol),
+	}
+	cpt := 0
+	for elt := range parser.Read("datas/quoted.ttl") {
+		if cpt >= len(datas) {
+			t.Fatal("the parser has read more triples than expected")
+		}
+		if test, err := elt.Equals(datas[cpt]); !test || err != nil {
+			t.Error(datas[cpt], "should be equal to", elt)
+		}
+		cpt++
+	}
+	if cpt != len(datas) {
+		t.Error("expected", len(datas), "triples but got", cpt)
+	}
+}
@@ -7,6 +7,7 @@ package parser
 import (
 	"errors"
 	"github.com/Callidon/joseki/rdf"
+	"strings"
 )
 
 // tokenURI represent a RDF URI
@@ -21,7 +22,7 @@ func newTokenURI(value string) *tokenURI {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenURI, it push a URI on top of the stack
-func (t tokenURI) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenURI) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	nodeStack.Push(rdf.NewURI(t.value))
 	return nil
 }
@@ -38,7 +39,7 @@ func newTokenLiteral(value string) *tokenLiteral {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenLiteral, it push a Literal on top of the stack
-func (t tokenLiteral) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenLiteral) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	nodeStack.Push(rdf.NewLiteral(t.value))
 	return nil
 }
@@ -57,15 +58,26 @@ func newTokenType(value string, line int, row int) *tokenType {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenType, it push a typed Literal on top of the stack
-func (t tokenType) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenType) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	if nodeStack.Len() < 1 {
 		return errors.New("encountered a malformed literal at " + t.position())
 	}
 	literal, isLiteral := nodeStack.Pop().(rdf.Literal)
 	if !isLiteral {
 		return errors.New("A XML type can only be associated with a RDF Literal, at " + t.position())
+	} else if literal.Type != "" || literal.Lang != "" {
+		return errors.New("Error : a literal cannot have more than one type or language, at " + t.position())
 	}
-	nodeStack.Push(rdf.NewTypedLiteral(literal.Value, t.value))
+	datatype := t.value
+	// the prefixed datatypes, e.g. xsd:integer, are expanded with the prefixes of the document
+	if prefixes != nil && !strings.HasPrefix(datatype, "<") {
+		iri, err := prefixes.Expand(datatype)
+		if err != nil {
+			return errors.New(err.Error() + " at " + t.position())
+		}
+		datatype = iri
+	}
+	nodeStack.Push(rdf.NewTypedLiteral(literal.Value, datatype))
 	return nil
 }
 
@@ -82,16 +94,29 @@ func newTokenLang(value string, line int, row int) *tokenLang {
 }
 
 // Interpret evaluate the token & produce an action.
-// In the case of a tokenLang, it push a Literal with its associated language on top of the stack
-func (t tokenLang) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+// In the case of a tokenLang, it push a Literal with its associated language on top of the stack.
+// The language can be followed by a base direction, e.g. @ar--rtl.
+func (t tokenLang) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	if nodeStack.Len() < 1 {
 		return errors.New("encountered a malformed literal at " + t.position())
 	}
 	literal, isLiteral := nodeStack.Pop().(rdf.Literal)
 	if !isLiteral {
 		return errors.New("A localization information can only be associated with a RDF Literal, at " + t.position())
+	} else if literal.Type != "" || literal.Lang != "" {
+		return errors.New("Error : a literal cannot have more than one type or language, at " + t.position())
+	}
+	lang, dir := t.value, ""
+	if index := strings.Index(t.value, "--"); index >= 0 {
+		lang, dir = t.value[:index], t.value[index+2:]
+		if !rdf.ValidDirection(dir) {
+			return errors.New("Error : invalid base direction " + dir + " at " + t.position())
+		}
+	}
+	if !rdf.ValidLangTag(lang) {
+		return errors.New("Error : invalid language tag " + lang + " at " + t.position())
 	}
-	nodeStack.Push(rdf.NewLangLiteral(literal.Value, t.value))
+	nodeStack.Push(rdf.NewDirLangLiteral(literal.Value, lang, dir))
 	return nil
 }
 
@@ -107,7 +132,7 @@ func newTokenBlankNode(value string) *tokenBlankNode {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenBlankNode, it push a Blank Node on top of the stack
-func (t tokenBlankNode) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenBlankNode) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	nodeStack.Push(rdf.NewBlankNode(t.value))
 	return nil
 }
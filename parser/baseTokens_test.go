@@ -77,6 +77,12 @@ func TestInterpretErrorsTokenType(t *testing.T) {
 	if err := token.Interpret(stack, nil, nil); err == nil {
 		t.Error("interpretation of a tokenType when the top of the stack is a non-Literal node should produce an error")
 	}
+
+	token = newTokenType("ex:unit", 1, 1)
+	stack.Push(rdf.NewLiteral("1"))
+	if err := token.Interpret(stack, rdf.NewPrefixMap(), nil); err == nil {
+		t.Error("interpretation of a tokenType with an unknown prefix should produce an error")
+	}
 }
 
 func TestInterpretTokenLang(t *testing.T) {
@@ -111,6 +117,44 @@ func TestInterpretErrorsTokenLang(t *testing.T) {
 	if err := token.Interpret(stack, nil, nil); err == nil {
 		t.Error("interpretation of a tokenLang when the top of the stack is a non-Literal node should produce an error")
 	}
+
+	stack.Push(rdf.NewLiteral("Harry Potter"))
+	if err := newTokenLang("en_GB", 1, 1).Interpret(stack, nil, nil); err == nil {
+		t.Error("interpretation of a tokenLang with a malformed language tag should produce an error")
+	}
+
+	stack.Push(rdf.NewLiteral("Harry Potter"))
+	if err := newTokenLang("en--auto", 1, 1).Interpret(stack, nil, nil); err == nil {
+		t.Error("interpretation of a tokenLang with an invalid base direction should produce an error")
+	}
+
+	stack.Push(rdf.NewTypedLiteral("Harry Potter", "http://example.org/type"))
+	if err := token.Interpret(stack, nil, nil); err == nil {
+		t.Error("interpretation of a tokenLang when the literal is already typed should produce an error")
+	}
+}
+
+func TestInterpretTokenLangDirection(t *testing.T) {
+	stack := newStack()
+	stack.Push(rdf.NewLiteral("Harry Potter"))
+	if err := newTokenLang("ar--rtl", 1, 1).Interpret(stack, nil, nil); err != nil {
+		t.Error("interpretation of a tokenLang with a base direction shouldn't produce the error :", err)
+	}
+	expectedNode := rdf.NewDirLangLiteral("Harry Potter", "ar", "rtl")
+	if literal, _ := stack.Pop().(rdf.Literal); literal != expectedNode {
+		t.Error(expectedNode, "produced by tokenLang.Interpret should be equals to", literal)
+	}
+}
+
+func TestNormalizeTokenLang(t *testing.T) {
+	stack := newStack()
+	stack.Push(rdf.NewLiteral("colour"))
+	if err := newTokenLang("EN-gb", 1, 1).Interpret(stack, nil, nil); err != nil {
+		t.Error("interpretation of a correct tokenLang shouldn't produce the error :", err)
+	}
+	if literal, _ := stack.Pop().(rdf.Literal); literal.Lang != "en-GB" {
+		t.Error("expected the language tag to be normalized into en-GB but instead got", literal.Lang)
+	}
 }
 
 func TestInterpretTokenBlankNode(t *testing.T) {
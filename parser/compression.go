@@ -0,0 +1,130 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// compression is a compression format, which can be decompressed on the fly when reading a file.
+type compression struct {
+	extensions []string
+	// match reports whether the first bytes of a file are compressed with this format
+	match  func(head []byte) bool
+	reader func(r io.Reader) (io.Reader, error)
+}
+
+// compressions contains the compression formats supported by the parsers.
+var compressions = []compression{
+	{
+		extensions: []string{"gz", "gzip"},
+		match: func(head []byte) bool {
+			return bytes.HasPrefix(head, []byte{0x1f, 0x8b})
+		},
+		reader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	{
+		extensions: []string{"bz2", "bzip2"},
+		match: func(head []byte) bool {
+			return bytes.HasPrefix(head, []byte("BZh"))
+		},
+		reader: func(r io.Reader) (io.Reader, error) {
+			return bzip2.NewReader(r), nil
+		},
+	},
+	{
+		extensions: []string{"zz", "zlib"},
+		match: func(head []byte) bool {
+			// deflate method with a window of at most 32K, without preset dictionary & with a valid header checksum
+			return len(head) >= 2 && head[0]&0x0f == 8 && head[0]>>4 <= 7 && head[1]&0x20 == 0 &&
+				(int(head[0])<<8|int(head[1]))%31 == 0
+		},
+		reader: func(r io.Reader) (io.Reader, error) {
+			return zlib.NewReader(r)
+		},
+	},
+}
+
+// compressionByExtension returns the compression format used by a file, according to its extension.
+func compressionByExtension(filename string) (compression, bool) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	for _, c := range compressions {
+		for _, extension := range c.extensions {
+			if extension == ext {
+				return c, true
+			}
+		}
+	}
+	return compression{}, false
+}
+
+// trimCompressionExt removes the extension of a compressed file, e.g. data.nt.gz becomes data.nt
+func trimCompressionExt(filename string) string {
+	if _, compressed := compressionByExtension(filename); compressed {
+		return strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	return filename
+}
+
+// fileReader is the content of a file, which closes the underlying file & decompressor when closed.
+type fileReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes the decompressor & the file.
+func (f *fileReader) Close() error {
+	var err error
+	for _, closer := range f.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Open opens a file for reading. If the file is compressed with gzip, bzip2 or zlib, its content
+// is decompressed on the fly. The compression is detected using the extension of the file,
+// or else its first bytes, unless the extension is the one of a RDF format, e.g. data.nt.
+func Open(filename string) (io.ReadCloser, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(f)
+	c, compressed := compressionByExtension(filename)
+	if _, isFormat := FormatByExtension(filename); !compressed && !isFormat {
+		head, _ := reader.Peek(4)
+		for _, candidate := range compressions {
+			if candidate.match(head) {
+				c, compressed = candidate, true
+				break
+			}
+		}
+	}
+	if !compressed {
+		return &fileReader{reader, []io.Closer{f}}, nil
+	}
+	decompressed, err := c.reader(reader)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	closers := []io.Closer{f}
+	if closer, isCloser := decompressed.(io.Closer); isCloser {
+		closers = append([]io.Closer{closer}, closers...)
+	}
+	return &fileReader{decompressed, closers}, nil
+}
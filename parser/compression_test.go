@@ -0,0 +1,141 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// compressFile writes a compressed copy of a file, for testing purpose
+func compressFile(src, dst string, compress func(w io.Writer) io.WriteCloser, t *testing.T) {
+	content, err := ioutil.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w := compress(&buf)
+	w.Write(content)
+	w.Close()
+	if err = ioutil.WriteFile(dst, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func gzipWriter(w io.Writer) io.WriteCloser {
+	return gzip.NewWriter(w)
+}
+
+func zlibWriter(w io.Writer) io.WriteCloser {
+	return zlib.NewWriter(w)
+}
+
+func TestOpenCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	expected, err := ioutil.ReadFile("datas/test.ttl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressFile("datas/test.ttl", filepath.Join(dir, "test.ttl.gz"), gzipWriter, t)
+	compressFile("datas/test.ttl", filepath.Join(dir, "test.ttl.zz"), zlibWriter, t)
+	// the compression of files without a known extension is detected using their first bytes
+	compressFile("datas/test.ttl", filepath.Join(dir, "gzipped"), gzipWriter, t)
+	compressFile("datas/test.ttl", filepath.Join(dir, "zlibbed"), zlibWriter, t)
+	files := []string{
+		"datas/test.ttl",
+		"datas/test.ttl.bz2",
+		filepath.Join(dir, "test.ttl.gz"),
+		filepath.Join(dir, "test.ttl.zz"),
+		filepath.Join(dir, "gzipped"),
+		filepath.Join(dir, "zlibbed"),
+	}
+
+	for _, file := range files {
+		f, err := Open(file)
+		if err != nil {
+			t.Error("opening", file, "shouldn't produce the error :", err)
+			continue
+		}
+		content, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			t.Error("reading", file, "shouldn't produce the error :", err)
+		} else if !bytes.Equal(content, expected) {
+			t.Error("expected the decompressed content of", file, "to be\n", string(expected), "\nbut instead got\n", string(content))
+		}
+	}
+
+	if _, err := Open(filepath.Join(dir, "missing.nt.gz")); err == nil {
+		t.Error("opening a missing file should produce an error")
+	}
+	ioutil.WriteFile(filepath.Join(dir, "corrupted.gz"), []byte("not compressed"), 0644)
+	if _, err := Open(filepath.Join(dir, "corrupted.gz")); err == nil {
+		t.Error("opening a corrupted gzip file should produce an error")
+	}
+
+	// plain files which look like compressed files are read as they are
+	plain := map[string]string{
+		"plain":      "x <http://example.org/a> .",
+		"data.nt":    "\x1f\x8b is not a gzip file",
+		"scheme.ttl": "BZh is not a bzip2 file",
+	}
+	for name, content := range plain {
+		ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		f, err := Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Error("opening the plain file", name, "shouldn't produce the error :", err)
+			continue
+		}
+		read, _ := ioutil.ReadAll(f)
+		f.Close()
+		if string(read) != content {
+			t.Error("expected the content of the plain file", name, "to be", content, "but instead got", string(read))
+		}
+	}
+}
+
+func TestReadCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	compressFile("datas/test.nt", filepath.Join(dir, "test.nt.gz"), gzipWriter, t)
+	compressFile("datas/test.rdf", filepath.Join(dir, "test.rdf.zz"), zlibWriter, t)
+	files := []string{filepath.Join(dir, "test.nt.gz"), "datas/test.ttl.bz2", filepath.Join(dir, "test.rdf.zz")}
+	expectedFormats := []string{"nt", "turtle", "rdfxml"}
+	expected := []int{5, 7, 20}
+
+	for i, file := range files {
+		format, err := DetectFormat(file)
+		if err != nil || format.Name != expectedFormats[i] {
+			t.Error("expected the format of", file, "to be", expectedFormats[i], "but instead got", format.Name, err)
+			continue
+		}
+		cpt := 0
+		for range format.New().Read(file) {
+			cpt++
+		}
+		if cpt != expected[i] {
+			t.Error("expected", expected[i], "triples in", file, "but instead got", cpt)
+		}
+	}
+
+	// the format of a compressed file without a known extension is detected using its decompressed content
+	compressFile("datas/test.jsonld", filepath.Join(dir, "dump"), gzipWriter, t)
+	if format, err := DetectFormat(filepath.Join(dir, "dump")); err != nil || format.Name != "jsonld" {
+		t.Error("expected the format of a compressed JSON-LD file to be jsonld but instead got", format.Name, err)
+	}
+}
@@ -6,25 +6,143 @@
 package parser
 
 import (
+	"errors"
 	"github.com/Callidon/joseki/rdf"
 	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
 	// Max size for the buffer of this package
 	bufferSize = 100
-	// Regexp used to isolate triples and their elements
-	wordRegexp = "'.*?'|\".*?\"|\\S+"
+	// Regexp used to isolate triples and their elements.
+	// The delimiters of the quoted triples, << & >>, are isolated even when they aren't separated by whitespaces from the nodes.
+	// The quoted strings may contain escaped quotes, e.g. "Alice \"Al\"".
+	wordRegexp = "'(?:[^'\\\\]|\\\\.)*'|\"(?:[^\"\\\\]|\\\\.)*\"|<<|>>|<[^<>\\s]*>|[^<>\\s]+(?:<[^<>\\s]*>)?|\\S+"
 )
 
 // Parser represent a generic interface for parsing every RDF format.
 //
 // Package parser provides several implementations for this interface.
+// Files compressed with gzip, bzip2 or zlib are decompressed on the fly by all of them.
 type Parser interface {
 	Read(filename string) chan rdf.Triple
 	Prefixes() map[string]string
 }
 
+// BaseSetter is implemented by the parsers which can resolve the relative IRIs of a document against a base IRI.
+// All the parsers of this package implement it.
+type BaseSetter interface {
+	SetBase(base string)
+}
+
+// resolveIRI resolves an IRI read in a document against the base IRI of the document, if there is one.
+func resolveIRI(base, iri string) (string, error) {
+	if base == "" {
+		return iri, nil
+	}
+	return rdf.ResolveIRI(base, iri)
+}
+
+// resolveDatatype resolves a datatype written between angle brackets against the base IRI of the document.
+// The prefixed datatypes are kept as they are.
+func resolveDatatype(base, datatype string) (string, error) {
+	if len(datatype) < 2 || datatype[0] != '<' || datatype[len(datatype)-1] != '>' {
+		return datatype, nil
+	}
+	value, err := resolveIRI(base, datatype[1:len(datatype)-1])
+	if err != nil {
+		return "", err
+	}
+	return "<" + value + ">", nil
+}
+
+// echars are the characters escaped with a backslash in the strings of N-Triples & Turtle documents.
+var echars = map[byte]string{'t': "\t", 'b': "\b", 'n': "\n", 'r': "\r", 'f': "\f", '"': "\"", '\'': "'", '\\': "\\"}
+
+// unescapeString replaces the escape sequences of a string read in a N-Triples or Turtle document,
+// i.e. \n, \" or \\, and the unicode escapes \uXXXX & \UXXXXXXXX, by the characters they represent.
+func unescapeString(value string) (string, error) {
+	if strings.IndexByte(value, '\\') == -1 {
+		return value, nil
+	}
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			result.WriteByte(value[i])
+			continue
+		}
+		if i+1 >= len(value) {
+			return "", errors.New("Error : unterminated escape sequence in the string " + value)
+		}
+		i++
+		if char, isEchar := echars[value[i]]; isEchar {
+			result.WriteString(char)
+			continue
+		}
+		size := 0
+		switch value[i] {
+		case 'u':
+			size = 4
+		case 'U':
+			size = 8
+		default:
+			return "", errors.New("Error : invalid escape sequence \\" + string(value[i]) + " in the string " + value)
+		}
+		if i+size >= len(value) {
+			return "", errors.New("Error : unterminated unicode escape sequence in the string " + value)
+		}
+		code, err := strconv.ParseUint(value[i+1:i+1+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", errors.New("Error : invalid unicode escape sequence \\" + value[i:i+1+size] + " in the string " + value)
+		}
+		result.WriteRune(rune(code))
+		i += size
+	}
+	return result.String(), nil
+}
+
+// blankLabeller labels the blank nodes of a document, so the labels generated by a parser, e.g. genid1,
+// never collide with the labels written in the document.
+// A label of the document is only renamed if it has already been generated for another blank node.
+type blankLabeller struct {
+	counter   int
+	used      map[string]bool
+	generated map[string]bool
+	renamed   map[string]string
+}
+
+// newBlankLabeller creates a new blankLabeller.
+func newBlankLabeller() *blankLabeller {
+	return &blankLabeller{0, make(map[string]bool), make(map[string]bool), make(map[string]string)}
+}
+
+// generate creates a new label, which isn't used in the document so far.
+func (b *blankLabeller) generate() string {
+	for {
+		b.counter++
+		label := "genid" + strconv.Itoa(b.counter)
+		if !b.used[label] {
+			b.used[label], b.generated[label] = true, true
+			return label
+		}
+	}
+}
+
+// label returns the label of a blank node written in the document.
+func (b *blankLabeller) label(label string) string {
+	if renamed, exists := b.renamed[label]; exists {
+		return renamed
+	} else if b.generated[label] {
+		b.renamed[label] = b.generate()
+		return b.renamed[label]
+	}
+	b.used[label] = true
+	return label
+}
+
 // lineCutter wraps up the regexp used isolate triples and their elements in the RDF standard
 // It's main purpose is to ensure that the regexp is compiled only once, since it's a high cost operation.
 type lineCutter struct {
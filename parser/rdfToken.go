@@ -17,7 +17,7 @@ import (
 // and can be used to extract triple pattersn& prefiex when reading a file
 type rdfToken interface {
 	// Interpret evaluate the token & produce an action
-	Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error
+	Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error
 }
 
 // tokenPosition represent the position of a token
@@ -48,6 +48,6 @@ func newTokenIllegal(err string, line int, row int) *tokenIllegal {
 }
 
 // Interpret evaluate the token & produce an action. In the case of a tokenIllegal, it causes a panic.
-func (t tokenIllegal) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenIllegal) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	return errors.New(t.errMsg + " at " + t.position())
 }
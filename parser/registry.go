@@ -0,0 +1,254 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// Number of bytes read at the beginning of a file to detect its format
+	sniffSize = 1024
+)
+
+// Format describes a RDF format which can be read by a Parser.
+//
+// Formats are registered by name, and can be looked up by name, file extension or MIME type.
+type Format struct {
+	// Name of the format, e.g. "turtle"
+	Name string
+	// Other names of the format, e.g. "ttl"
+	Aliases []string
+	// File extensions used by the format, without the leading dot
+	Extensions []string
+	// MIME types used by the format
+	MIMETypes []string
+	// New creates a new parser for the format
+	New func() Parser
+	// Sniff reports whether the beginning of a file looks like the format. It can be nil.
+	Sniff func(head []byte) bool
+}
+
+// registry stores the registered formats, in the order of registration.
+type registry struct {
+	sync.RWMutex
+	formats []Format
+}
+
+var formats = &registry{}
+
+func init() {
+	RegisterFormat(Format{
+		Name:       "jsonld",
+		Aliases:    []string{"json-ld"},
+		Extensions: []string{"jsonld", "json"},
+		MIMETypes:  []string{"application/ld+json", "application/json"},
+		New:        func() Parser { return NewJSONLDParser() },
+		Sniff:      sniffJSONLD,
+	})
+	RegisterFormat(Format{
+		Name:       "rdfxml",
+		Aliases:    []string{"rdf/xml", "xml"},
+		Extensions: []string{"rdf", "owl", "xml"},
+		MIMETypes:  []string{"application/rdf+xml", "text/xml", "application/xml"},
+		New:        func() Parser { return NewRDFXMLParser() },
+		Sniff:      sniffRDFXML,
+	})
+	RegisterFormat(Format{
+		Name:       "turtle",
+		Aliases:    []string{"ttl"},
+		Extensions: []string{"ttl"},
+		MIMETypes:  []string{"text/turtle", "application/x-turtle"},
+		New:        func() Parser { return NewTurtleParser() },
+		Sniff:      sniffTurtle,
+	})
+	RegisterFormat(Format{
+		Name:       "nt",
+		Aliases:    []string{"n-triples", "ntriples"},
+		Extensions: []string{"nt"},
+		MIMETypes:  []string{"application/n-triples"},
+		New:        func() Parser { return NewNTParser() },
+		Sniff:      sniffNTriples,
+	})
+}
+
+// RegisterFormat registers a new format, so it can be used to load files into graphs.
+// An error is returned if a format is already registered under the same name or alias.
+func RegisterFormat(format Format) error {
+	if format.Name == "" || format.New == nil {
+		return errors.New("Error : a format must have a name and a parser constructor")
+	}
+	formats.Lock()
+	defer formats.Unlock()
+	for _, name := range append([]string{format.Name}, format.Aliases...) {
+		for _, registered := range formats.formats {
+			if registered.hasName(name) {
+				return errors.New("Error : a format named " + name + " is already registered")
+			}
+		}
+	}
+	formats.formats = append(formats.formats, format)
+	return nil
+}
+
+// Formats returns all the registered formats, in the order of registration.
+func Formats() []Format {
+	formats.RLock()
+	defer formats.RUnlock()
+	return append([]Format{}, formats.formats...)
+}
+
+// hasName returns True if a format is registered under a name or an alias.
+func (f Format) hasName(name string) bool {
+	if strings.EqualFold(f.Name, name) {
+		return true
+	}
+	for _, alias := range f.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the first registered format which satisfies a predicate.
+func find(predicate func(f Format) bool) (Format, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+	for _, format := range formats.formats {
+		if predicate(format) {
+			return format, true
+		}
+	}
+	return Format{}, false
+}
+
+// FormatByName returns the format registered under a name or an alias, ignoring case.
+func FormatByName(name string) (Format, bool) {
+	return find(func(f Format) bool {
+		return f.hasName(name)
+	})
+}
+
+// FormatByExtension returns the format using the extension of a filename, ignoring case.
+func FormatByExtension(filename string) (Format, bool) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	if ext == "" {
+		return Format{}, false
+	}
+	return find(func(f Format) bool {
+		for _, extension := range f.Extensions {
+			if strings.EqualFold(extension, ext) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FormatByMIME returns the format using a MIME type. The parameters of the MIME type are ignored.
+func FormatByMIME(mimeType string) (Format, bool) {
+	mimeType = strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0])
+	return find(func(f Format) bool {
+		for _, value := range f.MIMETypes {
+			if strings.EqualFold(value, mimeType) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SniffFormat returns the format of a content, by looking at its first bytes.
+func SniffFormat(head []byte) (Format, bool) {
+	return find(func(f Format) bool {
+		return f.Sniff != nil && f.Sniff(head)
+	})
+}
+
+// DetectFormat returns the format of a file, using its extension, or its content if the extension is unknown.
+// For compressed files, the extension of the compressed file & its decompressed content are used.
+func DetectFormat(filename string) (Format, error) {
+	if format, found := FormatByExtension(trimCompressionExt(filename)); found {
+		return format, nil
+	}
+	f, err := Open(filename)
+	if err != nil {
+		return Format{}, err
+	}
+	defer f.Close()
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return Format{}, err
+	}
+	if format, found := SniffFormat(head[:n]); found {
+		return format, nil
+	}
+	return Format{}, errors.New("Error : cannot detect the format of the file " + filename)
+}
+
+// sniffJSONLD detects JSON documents, i.e. an object or an array of objects.
+// A Turtle document can also start with a [, when its first subject is an anonymous blank node, e.g. [ :p :o ] or [] :p :o .
+func sniffJSONLD(head []byte) bool {
+	head = bytes.TrimSpace(head)
+	if len(head) == 0 || head[0] == '{' {
+		return len(head) > 0
+	} else if head[0] != '[' {
+		return false
+	}
+	head = bytes.TrimSpace(head[1:])
+	return bytes.HasPrefix(head, []byte("{")) || bytes.Equal(head, []byte("]"))
+}
+
+// sniffRDFXML detects XML documents containing RDF.
+func sniffRDFXML(head []byte) bool {
+	head = bytes.TrimSpace(head)
+	return bytes.HasPrefix(head, []byte("<?xml")) || bytes.Contains(head, []byte("<rdf:RDF"))
+}
+
+// sniffTurtle detects Turtle documents, using their prefix & base directives,
+// or their first subject when it is an anonymous blank node.
+func sniffTurtle(head []byte) bool {
+	if trimmed := bytes.TrimSpace(head); bytes.HasPrefix(trimmed, []byte("[")) && !sniffJSONLD(trimmed) {
+		return true
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(head))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if strings.HasPrefix(line, "@prefix") || strings.HasPrefix(line, "@base") ||
+			strings.HasPrefix(line, "prefix ") || strings.HasPrefix(line, "base ") {
+			return true
+		}
+	}
+	return false
+}
+
+// sniffNTriples detects N-Triples documents, where each line contains terms of triples (IRIs, blank nodes or literals).
+func sniffNTriples(head []byte) bool {
+	// the last line may have been truncated
+	if index := bytes.LastIndexByte(head, '\n'); index >= 0 && len(head) == sniffSize {
+		head = head[:index]
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(head))
+	hasTriple := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, "<") && !strings.HasPrefix(line, "_:") && !strings.HasPrefix(line, "\"") && line != "." {
+			return false
+		}
+		hasTriple = hasTriple || strings.HasSuffix(line, ".")
+	}
+	return hasTriple
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestFormatLookup(t *testing.T) {
+	names := []string{"nt", "N-Triples", "ttl", "Turtle", "rdf/xml", "json-ld"}
+	expectedNames := []string{"nt", "nt", "turtle", "turtle", "rdfxml", "jsonld"}
+	for i, name := range names {
+		if format, found := FormatByName(name); !found || format.Name != expectedNames[i] {
+			t.Error("expected the format named", name, "to be", expectedNames[i], "but instead got", format.Name)
+		}
+	}
+
+	filenames := []string{"datas/test.nt", "datas/test.TTL", "ontology.owl", "data.jsonld"}
+	expectedExts := []string{"nt", "turtle", "rdfxml", "jsonld"}
+	for i, filename := range filenames {
+		if format, found := FormatByExtension(filename); !found || format.Name != expectedExts[i] {
+			t.Error("expected the format of the file", filename, "to be", expectedExts[i], "but instead got", format.Name)
+		}
+	}
+
+	mimeTypes := []string{"text/turtle; charset=utf-8", "application/rdf+xml", "application/ld+json", "application/n-triples"}
+	expectedMIMEs := []string{"turtle", "rdfxml", "jsonld", "nt"}
+	for i, mimeType := range mimeTypes {
+		if format, found := FormatByMIME(mimeType); !found || format.Name != expectedMIMEs[i] {
+			t.Error("expected the format of the MIME type", mimeType, "to be", expectedMIMEs[i], "but instead got", format.Name)
+		}
+	}
+
+	if _, found := FormatByName("n3"); found {
+		t.Error("no format should be registered under the name n3")
+	}
+	if _, found := FormatByExtension("datas/test"); found {
+		t.Error("a file without extension shouldn't match any format")
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// copy the test files without their extension, so their content is used to detect their format
+	files := []string{"datas/test.nt", "datas/test.ttl", "datas/test.rdf", "datas/test.jsonld"}
+	expected := []string{"nt", "turtle", "rdfxml", "jsonld"}
+	for i, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filename := filepath.Join(dir, "data"+strconv.Itoa(i))
+		ioutil.WriteFile(filename, content, 0644)
+		if format, err := DetectFormat(filename); err != nil || format.Name != expected[i] {
+			t.Error("expected the content of", file, "to be detected as", expected[i], "but instead got", format.Name, err)
+		}
+	}
+
+	// documents starting with a [ can be JSON-LD or Turtle, with an anonymous blank node as subject
+	contents := []string{
+		"[ <http://example.org/p> \"o\" ] <http://example.org/q> <http://example.org/r> .",
+		"[] <http://example.org/p> \"o\" .",
+		"[\n  {\"@id\": \"http://example.org/s\"}\n]",
+		"[ ]",
+	}
+	expected = []string{"turtle", "turtle", "jsonld", "jsonld"}
+	for i, content := range contents {
+		filename := filepath.Join(dir, "bracket"+strconv.Itoa(i))
+		ioutil.WriteFile(filename, []byte(content), 0644)
+		if format, err := DetectFormat(filename); err != nil || format.Name != expected[i] {
+			t.Error("expected", content, "to be detected as", expected[i], "but instead got", format.Name, err)
+		}
+	}
+
+	filename := filepath.Join(dir, "unknown")
+	ioutil.WriteFile(filename, []byte("this is not RDF"), 0644)
+	if _, err := DetectFormat(filename); err == nil {
+		t.Error("detecting the format of a file which doesn't contain RDF should produce an error")
+	}
+	if _, err := DetectFormat(filepath.Join(dir, "missing")); err == nil {
+		t.Error("detecting the format of a missing file should produce an error")
+	}
+}
+
+// csvParser is a dummy parser, which reads a single triple describing the file
+type csvParser struct{}
+
+func (p csvParser) Prefixes() map[string]string {
+	return nil
+}
+
+func (p csvParser) Read(filename string) chan rdf.Triple {
+	out := make(chan rdf.Triple, bufferSize)
+	go func() {
+		defer close(out)
+		out <- rdf.NewTriple(rdf.NewURI(filename), rdf.NewURI("http://example.org/format"), rdf.NewLiteral("csv"))
+	}()
+	return out
+}
+
+func TestRegisterFormat(t *testing.T) {
+	err := RegisterFormat(Format{
+		Name:       "test-csv",
+		Extensions: []string{"test-csv"},
+		MIMETypes:  []string{"text/x-test-csv"},
+		New:        func() Parser { return csvParser{} },
+	})
+	if err != nil {
+		t.Fatal("registering a new format shouldn't produce the error :", err)
+	}
+	format, found := FormatByExtension("data.test-csv")
+	if !found || format.Name != "test-csv" {
+		t.Fatal("expected the new format to be found using its extension")
+	}
+	cpt := 0
+	for range format.New().Read("data.test-csv") {
+		cpt++
+	}
+	if cpt != 1 {
+		t.Error("expected the parser of the new format to read 1 triple but instead got", cpt)
+	}
+
+	if err := RegisterFormat(Format{Name: "TTL", New: func() Parser { return csvParser{} }}); err == nil {
+		t.Error("registering a format with the name of another one should produce an error")
+	}
+	if err := RegisterFormat(Format{Name: "no-parser"}); err == nil {
+		t.Error("registering a format without parser should produce an error")
+	}
+}
@@ -7,8 +7,6 @@ package parser
 import (
 	"errors"
 	"github.com/Callidon/joseki/rdf"
-	"math/rand"
-	"strconv"
 )
 
 // tokenEnd represent a RDF URI
@@ -23,7 +21,7 @@ func newTokenEnd(line, row int) *tokenEnd {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenEnd, it form a new triple using the nodes in the stack
-func (t tokenEnd) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenEnd) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	if nodeStack.Len() < 3 {
 		return errors.New("encountered a malformed triple pattern at " + t.position())
 	}
@@ -40,17 +38,24 @@ func (t tokenEnd) Interpret(nodeStack *stack, prefixes *map[string]string, out c
 // tokenSep represent a Turtle separator
 type tokenSep struct {
 	value string
+	// label of the blank node introduced by a [ separator
+	label string
 	*tokenPosition
 }
 
 // newTokenSep creates a new tokenSep
 func newTokenSep(value string, line int, row int) *tokenSep {
-	return &tokenSep{value, newTokenPosition(line, row)}
+	return &tokenSep{value, "", newTokenPosition(line, row)}
+}
+
+// newTokenBlankSep creates a new tokenSep for the [ separator, which introduces a blank node with a given label
+func newTokenBlankSep(label string, line int, row int) *tokenSep {
+	return &tokenSep{"[", label, newTokenPosition(line, row)}
 }
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenSep, it form a new triple based on the separator, using the nodes in the stack
-func (t tokenSep) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
+func (t tokenSep) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
 	// case of a object separator
 	if t.value == "[" {
 		if nodeStack.Len() < 2 {
@@ -58,7 +63,7 @@ func (t tokenSep) Interpret(nodeStack *stack, prefixes *map[string]string, out c
 		}
 		predicate, predIsNode := nodeStack.Pop().(rdf.Node)
 		subject, subjIsNode := nodeStack.Pop().(rdf.Node)
-		object := rdf.NewBlankNode("v" + strconv.Itoa(rand.Int()))
+		object := rdf.NewBlankNode(t.label)
 		if !predIsNode || !subjIsNode {
 			return errors.New("expected a Node in stack but doesn't found it")
 		}
@@ -90,3 +95,52 @@ func (t tokenSep) Interpret(nodeStack *stack, prefixes *map[string]string, out c
 	}
 	return nil
 }
+
+// quotedMarker marks the start of a quoted triple in the stack
+type quotedMarker struct{}
+
+// tokenQuotedStart represent the << opening a quoted triple
+type tokenQuotedStart struct {
+	*tokenPosition
+}
+
+// newTokenQuotedStart creates a new tokenQuotedStart
+func newTokenQuotedStart(line, row int) *tokenQuotedStart {
+	return &tokenQuotedStart{newTokenPosition(line, row)}
+}
+
+// Interpret evaluate the token & produce an action.
+// In the case of a tokenQuotedStart, it push a marker on top of the stack, which is removed when the quoted triple is closed
+func (t tokenQuotedStart) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
+	nodeStack.Push(quotedMarker{})
+	return nil
+}
+
+// tokenQuotedEnd represent the >> closing a quoted triple
+type tokenQuotedEnd struct {
+	*tokenPosition
+}
+
+// newTokenQuotedEnd creates a new tokenQuotedEnd
+func newTokenQuotedEnd(line, row int) *tokenQuotedEnd {
+	return &tokenQuotedEnd{newTokenPosition(line, row)}
+}
+
+// Interpret evaluate the token & produce an action.
+// In the case of a tokenQuotedEnd, it form a quoted triple using the nodes in the stack & push it on top of the stack
+func (t tokenQuotedEnd) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
+	if nodeStack.Len() < 4 {
+		return errors.New("encountered a malformed quoted triple at " + t.position())
+	}
+	object, objIsNode := nodeStack.Pop().(rdf.Node)
+	predicate, predIsNode := nodeStack.Pop().(rdf.Node)
+	subject, subjIsNode := nodeStack.Pop().(rdf.Node)
+	if !objIsNode || !predIsNode || !subjIsNode {
+		return errors.New("encountered a malformed quoted triple at " + t.position())
+	}
+	if _, isMarker := nodeStack.Pop().(quotedMarker); !isMarker {
+		return errors.New("encountered a malformed quoted triple at " + t.position())
+	}
+	nodeStack.Push(rdf.NewQuotedTriple(rdf.NewTriple(subject, predicate, object)))
+	return nil
+}
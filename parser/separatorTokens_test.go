@@ -155,3 +155,37 @@ func TestInterpretErrorsTokenSep(t *testing.T) {
 		t.Error("interpretation of a TokenSep with an incorrect element in the stack should produce an error")
 	}
 }
+
+func TestInterpretTokenQuoted(t *testing.T) {
+	stack := newStack()
+	out := make(chan rdf.Triple, 1)
+	subject := rdf.NewURI("http://example.org/subject")
+	predicate := rdf.NewURI("http://example.org/predicate")
+	object := rdf.NewURI("http://example.org/object")
+	expected := rdf.NewQuotedTriple(rdf.NewTriple(subject, predicate, object))
+
+	if err := newTokenQuotedStart(1, 1).Interpret(stack, nil, out); err != nil {
+		t.Error("interpretation of a correct tokenQuotedStart shouldn't produce the error :", err)
+	}
+	stack.Push(subject)
+	stack.Push(predicate)
+	stack.Push(object)
+	if err := newTokenQuotedEnd(1, 1).Interpret(stack, nil, out); err != nil {
+		t.Error("interpretation of a correct tokenQuotedEnd shouldn't produce the error :", err)
+	}
+	if stack.Len() != 1 {
+		t.Fatal("after the interpretation of a quoted triple, the stack should only contain the quoted triple")
+	}
+	if quoted := stack.Pop(); quoted != expected {
+		t.Error(quoted, "produced by tokenQuotedEnd.Interpret should be equals to", expected)
+	}
+
+	// a quoted triple must be opened by a tokenQuotedStart
+	stack.Push(subject)
+	stack.Push(subject)
+	stack.Push(predicate)
+	stack.Push(object)
+	if err := newTokenQuotedEnd(1, 1).Interpret(stack, nil, out); err == nil {
+		t.Error("interpretation of a tokenQuotedEnd without a tokenQuotedStart should produce an error")
+	}
+}
@@ -7,7 +7,6 @@ package parser
 import (
 	"errors"
 	"github.com/Callidon/joseki/rdf"
-	"strings"
 )
 
 // tokenPrefixedURI represent a prefixed RDF URI
@@ -23,14 +22,12 @@ func newTokenPrefixedURI(value string, line int, row int) *tokenPrefixedURI {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenPrefixedURI, it push a URI to the stack
-func (t tokenPrefixedURI) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
-	sepIndex := strings.Index(t.value, ":")
-	prefixValue := string(t.value[0:sepIndex])
-	prefixURI, inPrefixes := (*prefixes)[prefixValue]
-	if !inPrefixes {
-		return errors.New("unkown prefix " + prefixValue + " at " + t.position())
+func (t tokenPrefixedURI) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
+	uri, err := prefixes.Expand(t.value)
+	if err != nil {
+		return errors.New(err.Error() + " at " + t.position())
 	}
-	nodeStack.Push(rdf.NewURI(prefixURI + t.value[sepIndex+1:]))
+	nodeStack.Push(rdf.NewURI(uri))
 	return nil
 }
 
@@ -47,7 +44,7 @@ func newTokenPrefix(name, value string) *tokenPrefix {
 
 // Interpret evaluate the token & produce an action.
 // In the case of a tokenPrefix, it register a new prefix
-func (t tokenPrefix) Interpret(nodeStack *stack, prefixes *map[string]string, out chan rdf.Triple) error {
-	(*prefixes)[t.name] = t.value
+func (t tokenPrefix) Interpret(nodeStack *stack, prefixes *rdf.PrefixMap, out chan rdf.Triple) error {
+	prefixes.Set(t.name, t.value)
 	return nil
 }
@@ -12,12 +12,12 @@ import (
 func TestInterpretTokenPrefixedURI(t *testing.T) {
 	token := newTokenPrefixedURI("example:subject", 1, 1)
 	stack := newStack()
-	prefixes := make(map[string]string)
-	prefixes["example"] = "http://example.org/"
+	prefixes := rdf.NewPrefixMap()
+	prefixes.Set("example", "http://example.org/")
 	expectedNode := rdf.NewURI("http://example.org/subject")
 
 	// Test for correct interpretation of the token
-	if err := token.Interpret(stack, &prefixes, nil); err != nil {
+	if err := token.Interpret(stack, prefixes, nil); err != nil {
 		t.Error("interpretation of a correct tokenPrefixedURI shouldn't produce the error :", err)
 	}
 	node, _ := stack.Pop().(rdf.Node)
@@ -32,10 +32,10 @@ func TestInterpretTokenPrefixedURI(t *testing.T) {
 func TestInterpretErrorsTokenPrefixedURI(t *testing.T) {
 	token := newTokenPrefixedURI("example:subject", 1, 1)
 	stack := newStack()
-	prefixes := make(map[string]string)
+	prefixes := rdf.NewPrefixMap()
 
 	// Test for incorrect interpretation of the token
-	if err := token.Interpret(stack, &prefixes, nil); err == nil {
+	if err := token.Interpret(stack, prefixes, nil); err == nil {
 		t.Error("interpretation of a tokenPrefixedURI with an unknown prefix should produce an error")
 	}
 
@@ -47,13 +47,13 @@ func TestInterpretErrorsTokenPrefixedURI(t *testing.T) {
 func TestInterpretTokenPrefix(t *testing.T) {
 	key, expectedValue := "example", "http://example.org/"
 	token := newTokenPrefix(key, expectedValue)
-	prefixes := make(map[string]string)
+	prefixes := rdf.NewPrefixMap()
 
 	// Test for correct interpretation of the token
-	if err := token.Interpret(nil, &prefixes, nil); err != nil {
+	if err := token.Interpret(nil, prefixes, nil); err != nil {
 		t.Error("interpretation of a correct tokenPrefix shouldn't produce the error :", err)
 	}
-	if value, inPrefixes := prefixes[key]; !inPrefixes || value != expectedValue {
+	if value, inPrefixes := prefixes.Namespace(key); !inPrefixes || value != expectedValue {
 		t.Error("after tokenPrefix.Interpet, the prefix", key, "should exist and have", expectedValue, "as value")
 	}
 }
@@ -0,0 +1,329 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package patch
+
+import (
+	"bufio"
+	"errors"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// rowScanner extracts the keyword & the terms of a row of a patch.
+type rowScanner struct {
+	line     string
+	pos      int
+	prefixes map[string]string
+}
+
+// newRowScanner creates a new rowScanner for a line, which expands prefixed names using the given prefixes.
+func newRowScanner(line string, prefixes map[string]string) *rowScanner {
+	return &rowScanner{line, 0, prefixes}
+}
+
+// skipSpaces moves to the next character which isn't a whitespace.
+func (s *rowScanner) skipSpaces() {
+	for s.pos < len(s.line) && unicode.IsSpace(rune(s.line[s.pos])) {
+		s.pos++
+	}
+}
+
+// word reads characters until the next whitespace.
+func (s *rowScanner) word() string {
+	s.skipSpaces()
+	start := s.pos
+	for s.pos < len(s.line) && !unicode.IsSpace(rune(s.line[s.pos])) {
+		s.pos++
+	}
+	return s.line[start:s.pos]
+}
+
+// iri reads an IRI between angle brackets.
+func (s *rowScanner) iri() (string, error) {
+	end := strings.IndexByte(s.line[s.pos:], '>')
+	if end < 0 {
+		return "", errors.New("Error : unterminated IRI " + s.line[s.pos:])
+	}
+	value := s.line[s.pos+1 : s.pos+end]
+	s.pos += end + 1
+	return value, nil
+}
+
+// prefixedName reads a prefixed name, e.g. rdf:type, and returns the IRI it represents.
+func (s *rowScanner) prefixedName() (string, error) {
+	name := s.word()
+	sep := strings.IndexByte(name, ':')
+	if sep < 0 {
+		return "", errors.New("Error : unexpected term " + name)
+	}
+	namespace, declared := s.prefixes[name[:sep]]
+	if !declared {
+		return "", errors.New("Error : the prefix " + name[:sep] + " hasn't been declared")
+	}
+	return namespace + name[sep+1:], nil
+}
+
+// str reads a string between double quotes, and unescapes it.
+func (s *rowScanner) str() (string, error) {
+	value := make([]rune, 0)
+	s.pos++
+	for s.pos < len(s.line) {
+		c := s.line[s.pos]
+		switch {
+		case c == '"':
+			s.pos++
+			return string(value), nil
+		case c == '\\' && s.pos+1 < len(s.line):
+			escaped := s.line[s.pos+1]
+			s.pos += 2
+			switch escaped {
+			case 't':
+				value = append(value, '\t')
+			case 'n':
+				value = append(value, '\n')
+			case 'r':
+				value = append(value, '\r')
+			case 'b':
+				value = append(value, '\b')
+			case 'f':
+				value = append(value, '\f')
+			case 'u', 'U':
+				size := 4
+				if escaped == 'U' {
+					size = 8
+				}
+				if s.pos+size > len(s.line) {
+					return "", errors.New("Error : invalid unicode escape sequence in " + s.line)
+				}
+				code, err := strconv.ParseUint(s.line[s.pos:s.pos+size], 16, 32)
+				if err != nil {
+					return "", errors.New("Error : invalid unicode escape sequence in " + s.line)
+				}
+				value = append(value, rune(code))
+				s.pos += size
+			default:
+				value = append(value, rune(escaped))
+			}
+		default:
+			r := []rune(s.line[s.pos:])[0]
+			value = append(value, r)
+			s.pos += len(string(r))
+		}
+	}
+	return "", errors.New("Error : unterminated string in " + s.line)
+}
+
+// term reads a RDF term: an IRI, a prefixed name, a blank node, a literal or a quoted triple.
+func (s *rowScanner) term() (rdf.Node, error) {
+	s.skipSpaces()
+	switch {
+	case s.pos >= len(s.line):
+		return nil, errors.New("Error : missing term at the end of " + s.line)
+	case strings.HasPrefix(s.line[s.pos:], "<<"):
+		s.pos += 2
+		nodes := make([]rdf.Node, 3)
+		for i := range nodes {
+			node, err := s.term()
+			if err != nil {
+				return nil, err
+			}
+			nodes[i] = node
+		}
+		if s.word() != ">>" {
+			return nil, errors.New("Error : unterminated quoted triple in " + s.line)
+		}
+		return rdf.NewQuotedTriple(rdf.NewTriple(nodes[0], nodes[1], nodes[2])), nil
+	case s.line[s.pos] == '<':
+		value, err := s.iri()
+		return rdf.NewURI(value), err
+	case strings.HasPrefix(s.line[s.pos:], "_:"):
+		return rdf.NewBlankNode(s.word()[2:]), nil
+	case s.line[s.pos] == '"':
+		value, err := s.str()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(s.line[s.pos:], "@") {
+			lang, dir := s.word()[1:], ""
+			if index := strings.Index(lang, "--"); index >= 0 {
+				lang, dir = lang[:index], lang[index+2:]
+				if !rdf.ValidDirection(dir) {
+					return nil, errors.New("Error : invalid base direction " + dir)
+				}
+			}
+			if !rdf.ValidLangTag(lang) {
+				return nil, errors.New("Error : invalid language tag " + lang)
+			}
+			return rdf.NewDirLangLiteral(value, lang, dir), nil
+		} else if strings.HasPrefix(s.line[s.pos:], "^^") {
+			s.pos += 2
+			var datatype string
+			if strings.HasPrefix(s.line[s.pos:], "<") {
+				datatype, err = s.iri()
+			} else {
+				datatype, err = s.prefixedName()
+			}
+			return rdf.NewTypedLiteral(value, datatype), err
+		}
+		return rdf.NewLiteral(value), nil
+	}
+	value, err := s.prefixedName()
+	return rdf.NewURI(value), err
+}
+
+// prefix reads the name of a prefix, written as a string or as a prefixed name without local part, e.g. "rdf" or rdf:
+func (s *rowScanner) prefix() (string, error) {
+	s.skipSpaces()
+	if strings.HasPrefix(s.line[s.pos:], "\"") {
+		return s.str()
+	}
+	name := s.word()
+	if !strings.HasSuffix(name, ":") {
+		return "", errors.New("Error : invalid prefix name " + name)
+	}
+	return strings.TrimSuffix(name, ":"), nil
+}
+
+// end reads the dot at the end of a row.
+func (s *rowScanner) end() error {
+	if s.word() != "." {
+		return errors.New("Error : expected a dot at the end of " + s.line)
+	}
+	if rest := s.word(); rest != "" && !strings.HasPrefix(rest, "#") {
+		return errors.New("Error : unexpected term " + rest + " after the end of the row")
+	}
+	return nil
+}
+
+// applier applies the rows of a patch to a graph.
+type applier struct {
+	graph    graph.Graph
+	tx       *graph.Tx
+	prefixes map[string]string
+}
+
+// modify adds or deletes a triple, in the current transaction if there's one.
+func (a *applier) modify(keyword string, triple rdf.Triple) {
+	switch {
+	case keyword == "A" && a.tx != nil:
+		a.tx.Add(triple)
+	case keyword == "A":
+		a.graph.Add(triple)
+	case a.tx != nil:
+		a.tx.Delete(triple.Subject, triple.Predicate, triple.Object)
+	default:
+		a.graph.DeleteTriples([]rdf.Triple{triple})
+	}
+}
+
+// apply applies a row of a patch.
+func (a *applier) apply(line string) error {
+	s := newRowScanner(line, a.prefixes)
+	keyword := s.word()
+	switch keyword {
+	case "", "#":
+		return nil
+	case "H":
+		s.word()
+		if _, err := s.term(); err != nil {
+			return err
+		}
+	case "TX":
+		if a.tx != nil {
+			return errors.New("Error : a transaction cannot be started inside another transaction")
+		}
+		a.tx = a.graph.Begin()
+	case "TC", "TA":
+		if a.tx == nil {
+			return errors.New("Error : " + keyword + " used outside of a transaction")
+		}
+		if keyword == "TC" {
+			a.tx.Commit()
+		} else {
+			a.tx.Rollback()
+		}
+		a.tx = nil
+	case "PA":
+		prefix, err := s.prefix()
+		if err != nil {
+			return err
+		}
+		s.skipSpaces()
+		var iri string
+		if strings.HasPrefix(s.line[s.pos:], "\"") {
+			iri, err = s.str()
+		} else if strings.HasPrefix(s.line[s.pos:], "<") {
+			iri, err = s.iri()
+		} else {
+			err = errors.New("Error : expected the IRI of the prefix " + prefix)
+		}
+		if err != nil {
+			return err
+		}
+		a.prefixes[prefix] = iri
+	case "PD":
+		prefix, err := s.prefix()
+		if err != nil {
+			return err
+		}
+		delete(a.prefixes, prefix)
+	case "A", "D":
+		nodes := make([]rdf.Node, 3)
+		for i := range nodes {
+			node, err := s.term()
+			if err != nil {
+				return err
+			}
+			nodes[i] = node
+		}
+		if err := s.end(); err != nil {
+			return err
+		}
+		a.modify(keyword, rdf.NewTriple(nodes[0], nodes[1], nodes[2]))
+		return nil
+	default:
+		if strings.HasPrefix(keyword, "#") {
+			return nil
+		}
+		return errors.New("Error : unknown row " + keyword)
+	}
+	return s.end()
+}
+
+// Apply reads a patch & applies its modifications to a graph.
+//
+// The rows inside a transaction are applied all at once when the transaction is committed (TC),
+// or discarded if it is aborted (TA). The rows outside of a transaction are applied immediately.
+// The prefixes declared in the patch are used to read the prefixed names of the next rows.
+//
+// If a row cannot be read, the current transaction is aborted & an error is returned.
+// The transactions which precede the invalid row are still applied.
+func Apply(g graph.Graph, in io.Reader) error {
+	a := &applier{g, nil, make(map[string]string)}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 1
+	for scanner.Scan() {
+		if err := a.apply(scanner.Text()); err != nil {
+			if a.tx != nil {
+				a.tx.Rollback()
+			}
+			return errors.New(err.Error() + " at line " + strconv.Itoa(lineNumber))
+		}
+		lineNumber++
+	}
+	if a.tx != nil {
+		a.tx.Rollback()
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return errors.New("Error : the patch ends inside a transaction")
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package patch
+
+import (
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+	patch := `# a comment
+PA "ex" <http://example.org/> .
+PA foaf: "http://xmlns.com/foaf/0.1/" .
+TX .
+A ex:subject ex:name "Thomas"@fr .
+A _:b0 foaf:age "22"^^<http://www.w3.org/2001/XMLSchema#integer> .
+A <http://example.org/subject> <http://example.org/name> "a \"quoted\"\nvalue\\ é" .
+TC .
+TX .
+A ex:subject ex:name "Arnaud" .
+TA .
+D ex:subject ex:name "Thomas"@fr .
+`
+
+	for _, g := range []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph()} {
+		if err := Apply(g, strings.NewReader(patch)); err != nil {
+			t.Fatal(err)
+		}
+		if cpt := countTriples(g); cpt != 2 {
+			t.Error("expected 2 triples in the graph but instead got", cpt)
+		}
+		expected := []rdf.Triple{
+			rdf.NewTriple(rdf.NewBlankNode("b0"), rdf.NewURI("http://xmlns.com/foaf/0.1/age"), rdf.NewTypedLiteral("22", "http://www.w3.org/2001/XMLSchema#integer")),
+			rdf.NewTriple(subj, name, rdf.NewLiteral("a \"quoted\"\nvalue\\ é")),
+		}
+		for _, triple := range expected {
+			if !containsTriple(g, triple) {
+				t.Error("expected the triple", triple, "to be in the graph")
+			}
+		}
+		if containsTriple(g, rdf.NewTriple(subj, name, rdf.NewLiteral("Arnaud"))) {
+			t.Error("the triples of an aborted transaction shouldn't be added to the graph")
+		}
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	invalidPatches := []string{
+		"TX .\nA <http://example.org/s> <http://example.org/p> \"o\" .\nA ex:s <http://example.org/p> \"o\" .\nTC .\n",
+		"TX .\nA <http://example.org/s> <http://example.org/p> \"o\" .\n",
+		"TX .\nA <http://example.org/s> <http://example.org/p> \"o\"\nTC .\n",
+		"TX .\nTX .\n",
+		"TC .\n",
+		"X <http://example.org/s> .\n",
+		"A <http://example.org/s> <http://example.org/p> \"o\"@not_a_tag .\n",
+	}
+
+	for _, patch := range invalidPatches {
+		g := graph.NewTreeGraph()
+		if err := Apply(g, strings.NewReader(patch)); err == nil {
+			t.Error("expected an error when applying the patch\n", patch)
+		}
+		// the invalid transactions are aborted
+		if cpt := countTriples(g); cpt != 0 {
+			t.Error("expected an invalid transaction to be aborted, but", cpt, "triples have been added")
+		}
+	}
+}
+
+func TestApplyTransactionOrder(t *testing.T) {
+	patch := `TX .
+A <http://example.org/s> <http://example.org/p> "1" .
+D <http://example.org/s> <http://example.org/p> "1" .
+A <http://example.org/s> <http://example.org/p> "2" .
+D <http://example.org/s> <http://example.org/p> "2" .
+A <http://example.org/s> <http://example.org/p> "2" .
+TC .
+`
+	for _, g := range []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph()} {
+		if err := Apply(g, strings.NewReader(patch)); err != nil {
+			t.Fatal(err)
+		}
+		expected := rdf.NewTriple(rdf.NewURI("http://example.org/s"), rdf.NewURI("http://example.org/p"), rdf.NewLiteral("2"))
+		if cpt := countTriples(g); cpt != 1 || !containsTriple(g, expected) {
+			t.Error("expected the graph to only contain", expected, "but instead got", cpt, "triples")
+		}
+	}
+}
+
+// transactionsPatch creates a patch with n transactions, each one adding a triple & deleting the previous one.
+func transactionsPatch(n int) string {
+	rows := make([]string, 0, n*4)
+	for i := 0; i < n; i++ {
+		rows = append(rows, "TX .", "A <http://example.org/s> <http://example.org/p> \""+strconv.Itoa(i)+"\" .")
+		if i > 0 {
+			rows = append(rows, "D <http://example.org/s> <http://example.org/p> \""+strconv.Itoa(i-1)+"\" .")
+		}
+		rows = append(rows, "TC .")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Replaying four times more transactions must take about four times longer, not sixteen.
+func BenchmarkApplyTransactions(b *testing.B) {
+	small, large := transactionsPatch(1000), transactionsPatch(4000)
+	replay := func(g graph.Graph, patch string) time.Duration {
+		start := time.Now()
+		if err := Apply(g, strings.NewReader(patch)); err != nil {
+			b.Fatal(err)
+		}
+		return time.Since(start)
+	}
+	for i := 0; i < b.N; i++ {
+		graphs := []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph(), graph.NewListGraph(), graph.NewTreeGraph()}
+		for j := 0; j < 2; j++ {
+			smallTime, largeTime := replay(graphs[j], small), replay(graphs[j+2], large)
+			if largeTime > 10*smallTime {
+				b.Fatal("the replay of the transactions is quadratic:", smallTime, "for 1000 transactions but", largeTime, "for 4000")
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package patch
+
+import (
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"sort"
+)
+
+// prefixer is implemented by the graphs which know the prefixes of the files loaded into them.
+type prefixer interface {
+	Prefixes() map[string]string
+}
+
+// writePrefixes writes the prefixes of a graph, if it has some.
+func writePrefixes(w *Writer, g graph.Graph) error {
+	p, ok := g.(prefixer)
+	if !ok || len(p.Prefixes()) == 0 {
+		return nil
+	}
+	prefixes := p.Prefixes()
+	names := make([]string, 0, len(prefixes))
+	for name := range prefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.AddPrefix(name, prefixes[name])
+	}
+	return w.Flush()
+}
+
+// Recorder writes the modifications of a graph into a patch, as they happen.
+//
+// Each operation on the graph (a call to Add, AddAll, Delete, etc, or the commit of a transaction)
+// is written as a transaction of the patch.
+type Recorder struct {
+	subscription *graph.Subscription
+	writer       *Writer
+	done         chan bool
+}
+
+// Record starts recording the modifications of a graph into an output, in RDF Patch format.
+// The prefixes of the graph are written first.
+func Record(g graph.Graph, out io.Writer) (*Recorder, error) {
+	writer := NewWriter(out)
+	if err := writePrefixes(writer, g); err != nil {
+		return nil, err
+	}
+	all := rdf.NewVariable("v")
+	r := &Recorder{g.Subscribe(all, all, all), writer, make(chan bool)}
+	go r.record()
+	return r, nil
+}
+
+// record writes the events sent by the graph, until the subscription is closed.
+func (r *Recorder) record() {
+	defer close(r.done)
+	inTransaction := false
+	// on error, keep reading the events so the subscription can be closed
+	for event := range r.subscription.Events() {
+		if !inTransaction {
+			r.writer.Begin()
+			inTransaction = true
+		}
+		if event.Type == graph.TripleAdded {
+			r.writer.Add(event.Triple)
+		} else {
+			r.writer.Delete(event.Triple)
+		}
+		if event.Last {
+			r.writer.Commit()
+			r.writer.Flush()
+			inTransaction = false
+		}
+	}
+}
+
+// Close stops recording the modifications of the graph, once the pending ones have been written.
+// It returns the first error which occurred while writing the patch.
+func (r *Recorder) Close() error {
+	r.subscription.Close()
+	<-r.done
+	return r.writer.Flush()
+}
+
+// Dump writes the whole content of a graph into an output, as a patch made of a single transaction.
+// Applying this patch to an empty graph creates a copy of the graph.
+func Dump(g graph.Graph, out io.Writer) error {
+	writer := NewWriter(out)
+	if err := writePrefixes(writer, g); err != nil {
+		return err
+	}
+	snapshot := g.Snapshot()
+	defer snapshot.Release()
+	all := rdf.NewVariable("v")
+	writer.Begin()
+	for triple := range snapshot.Filter(all, all, all) {
+		writer.Add(triple)
+	}
+	writer.Commit()
+	return writer.Flush()
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package patch
+
+import (
+	"bytes"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	all := rdf.NewVariable("v")
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+	triples := make([]rdf.Triple, 0)
+	for i := 0; i < 100; i++ {
+		triples = append(triples, rdf.NewTriple(subj, name, rdf.NewLiteral("name "+strconv.Itoa(i))))
+	}
+
+	for _, primary := range []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph()} {
+		var out bytes.Buffer
+		recorder, err := Record(primary, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		primary.Add(triples[0])
+		primary.AddAll(triples[1:50])
+		primary.DeleteTriples(triples[10:20])
+		tx := primary.Begin()
+		tx.Add(triples[50])
+		tx.Delete(subj, name, triples[0].Object)
+		tx.Commit()
+		tx = primary.Begin()
+		tx.Add(triples[99])
+		tx.Rollback()
+		if err := recorder.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// each operation is a transaction of the patch
+		if cpt := strings.Count(out.String(), "TX .\n"); cpt != 4 {
+			t.Error("expected 4 transactions in the patch but instead got", cpt)
+		}
+
+		// the patch replicates the primary graph
+		replica := graph.NewListGraph()
+		if err := Apply(replica, &out); err != nil {
+			t.Fatal(err)
+		}
+		if expected, cpt := countTriples(primary), countTriples(replica); cpt != expected {
+			t.Error("expected", expected, "triples in the replica but instead got", cpt)
+		}
+		for triple := range primary.Filter(all, all, all) {
+			if !containsTriple(replica, triple) {
+				t.Error("expected the triple", triple, "to be in the replica")
+			}
+		}
+
+		// the modifications made after the recording has stopped are not written
+		primary.Add(triples[99])
+		if out.Len() != 0 {
+			t.Error("expected nothing to be written after the recorder has been closed")
+		}
+	}
+}
+
+func TestRecordAppliedTransactions(t *testing.T) {
+	patch := `TX .
+A <http://example.org/s> <http://example.org/p> "c" .
+D <http://example.org/s> <http://example.org/p> "c" .
+A <http://example.org/s> <http://example.org/p> "d" .
+TC .
+TX .
+A <http://example.org/s> <http://example.org/p> "e" .
+TA .
+A <http://example.org/s> <http://example.org/p> "f" .
+`
+	for _, replica := range []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph()} {
+		var out bytes.Buffer
+		recorder, err := Record(replica, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(replica, strings.NewReader(patch)); err != nil {
+			t.Fatal(err)
+		}
+		if err := recorder.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// the committed transaction & the row outside of a transaction are each recorded as one transaction
+		if cpt := strings.Count(out.String(), "TX .\n"); cpt != 2 {
+			t.Error("expected 2 transactions in the recorded patch but instead got", cpt, ":\n", out.String())
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	all := rdf.NewVariable("v")
+	list, tree := graph.NewListGraph(), graph.NewTreeGraph()
+	list.LoadFromFile("../parser/datas/test.ttl", "turtle")
+	tree.LoadFromFile("../parser/datas/test.ttl", "turtle")
+	copies := []graph.Graph{graph.NewListGraph(), graph.NewTreeGraph()}
+	for i, g := range []graph.Graph{list, tree} {
+		var out bytes.Buffer
+		if err := Dump(g, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(out.String(), "PA ") {
+			t.Error("expected the patch to start with the prefixes of the graph, but instead got\n", out.String())
+		}
+
+		replica := copies[i]
+		if err := Apply(replica, &out); err != nil {
+			t.Fatal(err)
+		}
+		if expected, cpt := countTriples(g), countTriples(replica); cpt != expected || cpt == 0 {
+			t.Error("expected", expected, "triples in the copy of the graph but instead got", cpt)
+		}
+		for triple := range g.Filter(all, all, all) {
+			// the parser keeps the angle brackets around the datatypes, but not the patch
+			if literal, isLiteral := triple.Object.(rdf.Literal); isLiteral && literal.Type != "" {
+				triple.Object = rdf.NewTypedLiteral(literal.Value, strings.Trim(literal.Type, "<>"))
+			}
+			if !containsTriple(replica, triple) {
+				t.Error("expected the triple", triple, "to be in the copy of the graph")
+			}
+		}
+	}
+}
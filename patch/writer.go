@@ -0,0 +1,150 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Package patch provides tools to record & replay the modifications of RDF Graphs in RDF Patch format.
+//
+// A patch is a log of changes: rows which add (A) or delete (D) triples, grouped in transactions (TX, TC, TA),
+// with headers (H) and prefix declarations (PA, PD). It can be used to back up a graph incrementally,
+// or to replicate a graph by applying the patch recorded on a primary graph to a replica.
+//
+// RDF Patch reference : https://afs.github.io/rdf-patch/
+package patch
+
+import (
+	"bufio"
+	"errors"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"strings"
+)
+
+// literalEscaper escapes the special characters of the literals
+var literalEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t")
+
+// Writer writes rows in RDF Patch format.
+//
+// Writing errors are sticky: once a row cannot be written, the next calls do nothing & return the same error.
+type Writer struct {
+	out *bufio.Writer
+	err error
+}
+
+// NewWriter creates a new Writer, which writes rows into a given output.
+func NewWriter(out io.Writer) *Writer {
+	return &Writer{bufio.NewWriter(out), nil}
+}
+
+// formatTerm converts a RDF node to a term of a row.
+func formatTerm(node rdf.Node) (string, error) {
+	switch n := node.(type) {
+	case rdf.URI:
+		return "<" + n.Value + ">", nil
+	case rdf.BlankNode:
+		return "_:" + n.Value, nil
+	case rdf.Literal:
+		term := "\"" + literalEscaper.Replace(n.Value) + "\""
+		if n.Lang != "" && n.Dir != "" {
+			term += "@" + n.Lang + "--" + n.Dir
+		} else if n.Lang != "" {
+			term += "@" + n.Lang
+		} else if n.Datatype() != rdf.XSDString {
+			term += "^^<" + n.Datatype() + ">"
+		}
+		return term, nil
+	case rdf.QuotedTriple:
+		term := "<<"
+		for _, nested := range []rdf.Node{n.Subject, n.Predicate, n.Object} {
+			value, err := formatTerm(nested)
+			if err != nil {
+				return "", err
+			}
+			term += " " + value
+		}
+		return term + " >>", nil
+	}
+	return "", errors.New("Error : cannot write the node " + node.String() + " in a patch")
+}
+
+// row writes a row made of a keyword & several terms.
+func (w *Writer) row(keyword string, terms ...string) error {
+	if w.err != nil {
+		return w.err
+	}
+	line := keyword
+	for _, term := range terms {
+		line += " " + term
+	}
+	_, w.err = w.out.WriteString(line + " .\n")
+	return w.err
+}
+
+// triple writes a row which adds or deletes a triple.
+func (w *Writer) triple(keyword string, triple rdf.Triple) error {
+	if w.err != nil {
+		return w.err
+	}
+	terms := make([]string, 0, 3)
+	for _, node := range []rdf.Node{triple.Subject, triple.Predicate, triple.Object} {
+		term, err := formatTerm(node)
+		if err != nil {
+			w.err = err
+			return err
+		}
+		terms = append(terms, term)
+	}
+	return w.row(keyword, terms...)
+}
+
+// Header writes a header row, e.g. H id <uuid:...> .
+func (w *Writer) Header(name string, value rdf.Node) error {
+	term, err := formatTerm(value)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return w.row("H", name, term)
+}
+
+// Begin writes a row which starts a transaction.
+func (w *Writer) Begin() error {
+	return w.row("TX")
+}
+
+// Commit writes a row which commits the current transaction.
+func (w *Writer) Commit() error {
+	return w.row("TC")
+}
+
+// Abort writes a row which aborts the current transaction.
+func (w *Writer) Abort() error {
+	return w.row("TA")
+}
+
+// AddPrefix writes a row which declares a prefix.
+func (w *Writer) AddPrefix(prefix, iri string) error {
+	return w.row("PA", "\""+literalEscaper.Replace(prefix)+"\"", "<"+iri+">")
+}
+
+// DeletePrefix writes a row which removes the declaration of a prefix.
+func (w *Writer) DeletePrefix(prefix string) error {
+	return w.row("PD", "\""+literalEscaper.Replace(prefix)+"\"")
+}
+
+// Add writes a row which adds a triple.
+func (w *Writer) Add(triple rdf.Triple) error {
+	return w.triple("A", triple)
+}
+
+// Delete writes a row which deletes a triple.
+func (w *Writer) Delete(triple rdf.Triple) error {
+	return w.triple("D", triple)
+}
+
+// Flush writes the buffered rows into the output.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.out.Flush()
+	return w.err
+}
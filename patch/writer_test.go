@@ -0,0 +1,125 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package patch
+
+import (
+	"bytes"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"strings"
+	"testing"
+)
+
+// containsTriple returns True if a graph contains a triple
+func containsTriple(g graph.Graph, triple rdf.Triple) bool {
+	found := false
+	for range g.Filter(triple.Subject, triple.Predicate, triple.Object) {
+		found = true
+	}
+	return found
+}
+
+// countTriples returns the number of triples in a graph
+func countTriples(g graph.Graph) int {
+	all := rdf.NewVariable("v")
+	cpt := 0
+	for range g.Filter(all, all, all) {
+		cpt++
+	}
+	return cpt
+}
+
+func TestWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	subj := rdf.NewURI("http://example.org/subject")
+	name := rdf.NewURI("http://example.org/name")
+
+	w.Header("id", rdf.NewURI("uuid:0686c69d"))
+	w.AddPrefix("ex", "http://example.org/")
+	w.Begin()
+	w.Add(rdf.NewTriple(subj, name, rdf.NewLangLiteral("Thomas", "fr")))
+	w.Add(rdf.NewTriple(rdf.NewBlankNode("b0"), name, rdf.NewTypedLiteral("22", "<http://www.w3.org/2001/XMLSchema#integer>")))
+	w.Delete(rdf.NewTriple(subj, name, rdf.NewLiteral("a \"quoted\"\nvalue\\")))
+	w.Commit()
+	w.DeletePrefix("ex")
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `H id <uuid:0686c69d> .
+PA "ex" <http://example.org/> .
+TX .
+A <http://example.org/subject> <http://example.org/name> "Thomas"@fr .
+A _:b0 <http://example.org/name> "22"^^<http://www.w3.org/2001/XMLSchema#integer> .
+D <http://example.org/subject> <http://example.org/name> "a \"quoted\"\nvalue\\" .
+TC .
+PD "ex" .
+`
+	if out.String() != expected {
+		t.Error("expected the patch\n", expected, "but instead got\n", out.String())
+	}
+
+	// variables cannot be written, and the error is sticky
+	if err := w.Add(rdf.NewTriple(rdf.NewVariable("s"), name, subj)); err == nil {
+		t.Error("expected an error when writing a variable in a patch")
+	}
+	if err := w.Commit(); err == nil {
+		t.Error("expected the writing error to be returned by the next calls")
+	}
+}
+
+func TestWriterQuotedTriples(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	quoted := rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://xmlns.com/foaf/0.1/knows"), rdf.NewBlankNode("b0")))
+	triple := rdf.NewTriple(quoted, rdf.NewURI("http://example.org/source"), rdf.NewLiteral("facebook"))
+	w.Add(triple)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "A << <http://example.org/alice> <http://xmlns.com/foaf/0.1/knows> _:b0 >> <http://example.org/source> \"facebook\" .\n"
+	if out.String() != expected {
+		t.Error("expected the patch\n", expected, "but instead got\n", out.String())
+	}
+
+	// read back the patch
+	g := graph.NewTreeGraph()
+	if err := Apply(g, bytes.NewReader(out.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if !containsTriple(g, triple) {
+		t.Error("expected the triple", triple, "to be in the graph")
+	}
+	if err := Apply(g, strings.NewReader("A << <http://example.org/a> <http://example.org/b> <http://example.org/c> <http://example.org/d> .\n")); err == nil {
+		t.Error("applying a patch with an unterminated quoted triple should produce an error")
+	}
+}
+
+func TestWriterDirLangLiterals(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	triple := rdf.NewTriple(rdf.NewURI("http://example.org/book"), rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewDirLangLiteral("Joseki", "ar", "rtl"))
+	w.Add(triple)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "A <http://example.org/book> <http://purl.org/dc/terms/title> \"Joseki\"@ar--rtl .\n"
+	if out.String() != expected {
+		t.Error("expected the patch\n", expected, "but instead got\n", out.String())
+	}
+
+	// read back the patch
+	g := graph.NewTreeGraph()
+	if err := Apply(g, bytes.NewReader(out.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if !containsTriple(g, triple) {
+		t.Error("expected the triple", triple, "to be in the graph")
+	}
+	if err := Apply(g, strings.NewReader("A <http://example.org/a> <http://example.org/b> \"c\"@en--up .\n")); err == nil {
+		t.Error("applying a patch with an invalid base direction should produce an error")
+	}
+}
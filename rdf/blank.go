@@ -0,0 +1,133 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WellKnownGenid is the path under which the IRIs of skolemized blank nodes are minted.
+//
+// Skolemization reference : https://www.w3.org/TR/rdf11-concepts/#section-skolemization
+const WellKnownGenid = "/.well-known/genid/"
+
+// BlankNodeGenerator generates blank nodes with deterministic labels, made of a prefix & a counter, e.g. b0, b1, etc.
+//
+// It is safe for concurrent use.
+type BlankNodeGenerator struct {
+	prefix  string
+	counter int
+	// used returns True if a label is already used, so it must be skipped. It can be nil.
+	used func(node BlankNode) bool
+	*sync.Mutex
+}
+
+// NewBlankNodeGenerator creates a new BlankNodeGenerator, which generates labels starting with a prefix.
+func NewBlankNodeGenerator(prefix string) *BlankNodeGenerator {
+	return &BlankNodeGenerator{prefix, 0, nil, &sync.Mutex{}}
+}
+
+// NewFreeBlankNodeGenerator creates a new BlankNodeGenerator, which skips the labels already used,
+// e.g. the labels of the blank nodes of a graph.
+func NewFreeBlankNodeGenerator(prefix string, used func(node BlankNode) bool) *BlankNodeGenerator {
+	return &BlankNodeGenerator{prefix, 0, used, &sync.Mutex{}}
+}
+
+// New generates a new blank node, whose label has never been generated by the generator.
+func (g *BlankNodeGenerator) New() BlankNode {
+	g.Lock()
+	defer g.Unlock()
+	for {
+		node := NewBlankNode(g.prefix + strconv.Itoa(g.counter))
+		g.counter++
+		if g.used == nil || !g.used(node) {
+			return node
+		}
+	}
+}
+
+// BlankNodeScope maps the blank node labels of a document to new blank nodes.
+//
+// The labels of blank nodes are only meaningful inside a document, so two documents can use the same label
+// for different blank nodes. Using a scope per document, with a generator shared between the scopes,
+// ensures that the blank nodes of two documents never share a label.
+type BlankNodeScope struct {
+	generator *BlankNodeGenerator
+	nodes     map[string]BlankNode
+	*sync.Mutex
+}
+
+// NewBlankNodeScope creates a new BlankNodeScope, which uses a generator to create the blank nodes.
+func NewBlankNodeScope(generator *BlankNodeGenerator) *BlankNodeScope {
+	return &BlankNodeScope{generator, make(map[string]BlankNode), &sync.Mutex{}}
+}
+
+// Node returns the blank node associated with a label of the document, and generates it if needed.
+func (s *BlankNodeScope) Node(label string) BlankNode {
+	s.Lock()
+	defer s.Unlock()
+	node, exists := s.nodes[label]
+	if !exists {
+		node = s.generator.New()
+		s.nodes[label] = node
+	}
+	return node
+}
+
+// Triple replaces the blank nodes of a triple with the blank nodes associated with their labels in the scope.
+func (s *BlankNodeScope) Triple(triple Triple) Triple {
+	return mapNodes(triple, func(node Node) Node {
+		if blank, isBlank := node.(BlankNode); isBlank {
+			return s.Node(blank.Value)
+		}
+		return node
+	})
+}
+
+// Skolemize replaces a blank node with a skolem IRI, minted under the /.well-known/genid/ path of an authority,
+// e.g. the blank node _:b0 with the authority http://example.org becomes <http://example.org/.well-known/genid/b0>.
+//
+// The authority should identify the graph or the dataset of the blank node, since the skolem IRIs must be unique.
+func Skolemize(node BlankNode, authority string) URI {
+	return NewURI(strings.TrimSuffix(authority, "/") + WellKnownGenid + node.Value)
+}
+
+// Deskolemize replaces a skolem IRI with a blank node, labelled with the last segment of the IRI.
+// It returns False if the IRI isn't a skolem IRI, i.e. it hasn't been minted under a /.well-known/genid/ path.
+func Deskolemize(uri URI) (BlankNode, bool) {
+	index := strings.Index(uri.Value, WellKnownGenid)
+	if index < 0 || strings.Count(uri.Value[:index], "/") != 2 {
+		return BlankNode{}, false
+	}
+	label := uri.Value[index+len(WellKnownGenid):]
+	if label == "" || strings.ContainsAny(label, "/?#") {
+		return BlankNode{}, false
+	}
+	return NewBlankNode(label), true
+}
+
+// SkolemizeTriple replaces the blank nodes of a triple with skolem IRIs, minted under the path of an authority.
+func SkolemizeTriple(triple Triple, authority string) Triple {
+	return mapNodes(triple, func(node Node) Node {
+		if blank, isBlank := node.(BlankNode); isBlank {
+			return Skolemize(blank, authority)
+		}
+		return node
+	})
+}
+
+// DeskolemizeTriple replaces the skolem IRIs of a triple with blank nodes.
+func DeskolemizeTriple(triple Triple) Triple {
+	return mapNodes(triple, func(node Node) Node {
+		if uri, isURI := node.(URI); isURI {
+			if blank, isSkolem := Deskolemize(uri); isSkolem {
+				return blank
+			}
+		}
+		return node
+	})
+}
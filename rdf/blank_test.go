@@ -0,0 +1,64 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"testing"
+)
+
+func TestBlankNodeScope(t *testing.T) {
+	generator := NewBlankNodeGenerator("b")
+	first, second := NewBlankNodeScope(generator), NewBlankNodeScope(generator)
+	if node := first.Node("a"); node.Value != "b0" {
+		t.Error("expected the first blank node to be labelled b0 but instead got", node.Value)
+	}
+	if node := first.Node("a"); node.Value != "b0" {
+		t.Error("expected a label to be mapped to the same blank node in a scope, but instead got", node.Value)
+	}
+	if node := second.Node("a"); node.Value != "b1" {
+		t.Error("expected a label to be mapped to a new blank node in another scope, but instead got", node.Value)
+	}
+	if node := generator.New(); node.Value != "b2" {
+		t.Error("expected the generator to generate the label b2 but instead got", node.Value)
+	}
+
+	// the labels already used are skipped
+	free := NewFreeBlankNodeGenerator("b", func(node BlankNode) bool { return node.Value == "b0" || node.Value == "b2" })
+	if first, second := free.New(), free.New(); first.Value != "b1" || second.Value != "b3" {
+		t.Error("expected the generator to skip the labels b0 & b2 but instead got", first.Value, "&", second.Value)
+	}
+
+	triple := first.Triple(NewTriple(NewBlankNode("a"), NewURI("http://example.org/p"), NewBlankNode("c")))
+	expected := NewTriple(NewBlankNode("b0"), NewURI("http://example.org/p"), NewBlankNode("b3"))
+	if test, err := triple.Equals(expected); !test || err != nil {
+		t.Error(expected, "should be equal to", triple)
+	}
+}
+
+func TestSkolemize(t *testing.T) {
+	blank := NewBlankNode("b0")
+	skolem := Skolemize(blank, "http://example.org/")
+	if skolem.Value != "http://example.org/.well-known/genid/b0" {
+		t.Error("expected the skolem IRI http://example.org/.well-known/genid/b0 but instead got", skolem.Value)
+	}
+	if node, isSkolem := Deskolemize(skolem); !isSkolem || node.Value != "b0" {
+		t.Error("expected the skolem IRI", skolem, "to be replaced with _:b0 but instead got", node)
+	}
+	for _, iri := range []string{"http://example.org/b0", "http://example.org/data/.well-known/genid/b0", "http://example.org/.well-known/genid/", "http://example.org/.well-known/genid/b0#x"} {
+		if node, isSkolem := Deskolemize(NewURI(iri)); isSkolem {
+			t.Error("the IRI", iri, "isn't a skolem IRI, but it has been replaced with", node)
+		}
+	}
+
+	triple := NewTriple(blank, NewURI("http://example.org/p"), NewBlankNode("b1"))
+	skolemized := SkolemizeTriple(triple, "http://example.org")
+	expected := NewTriple(skolem, NewURI("http://example.org/p"), NewURI("http://example.org/.well-known/genid/b1"))
+	if test, err := skolemized.Equals(expected); !test || err != nil {
+		t.Error(expected, "should be equal to", skolemized)
+	}
+	if test, err := DeskolemizeTriple(skolemized).Equals(triple); !test || err != nil {
+		t.Error(triple, "should be equal to", DeskolemizeTriple(skolemized))
+	}
+}
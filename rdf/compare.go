@@ -0,0 +1,181 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// order of the categories of literals, when they are sorted
+var literalCategories = map[string]int{
+	"numeric":  0,
+	"boolean":  1,
+	"dateTime": 2,
+	"date":     3,
+	"time":     4,
+	"duration": 5,
+	"string":   6,
+}
+
+const (
+	// category of the literals with a language
+	langCategory = 7
+	// category of the literals with an unsupported datatype or an invalid value
+	otherCategory = 8
+)
+
+// nodeRank returns the rank of a node in the SPARQL ordering: unbound, blank nodes, IRIs, literals then quoted triples.
+func nodeRank(node Node) int {
+	switch node.(type) {
+	case nil, Variable:
+		return 0
+	case BlankNode:
+		return 1
+	case URI:
+		return 2
+	case Literal:
+		return 3
+	case QuotedTriple:
+		return 4
+	}
+	return 5
+}
+
+// compareInts compares two integers.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// literalCategory returns the category of a literal & its value, if it has a supported datatype & a valid value.
+func literalCategory(l Literal) (int, interface{}) {
+	if l.Lang != "" {
+		return langCategory, nil
+	}
+	dt, supported := datatypes[l.Datatype()]
+	if !supported {
+		return otherCategory, nil
+	}
+	value, err := dt.parse(l.Value)
+	if err != nil {
+		return otherCategory, nil
+	}
+	return literalCategories[dt.kind], value
+}
+
+// compareLiterals compares two literals by category, then by value, then by lexical form, datatype, language and base direction.
+func compareLiterals(a, b Literal) int {
+	categoryA, valueA := literalCategory(a)
+	categoryB, valueB := literalCategory(b)
+	if categoryA != categoryB {
+		return compareInts(categoryA, categoryB)
+	}
+	cmp := 0
+	switch categoryA {
+	case literalCategories["numeric"]:
+		// NaN is lower than all the other numbers
+		nanA, nanB := isNaN(valueA), isNaN(valueB)
+		if nanA || nanB {
+			cmp = compareBools(!nanA, !nanB)
+		} else {
+			cmp, _ = compareNumbers(valueA, valueB)
+		}
+	case literalCategories["boolean"]:
+		cmp = compareBools(valueA.(bool), valueB.(bool))
+	case literalCategories["dateTime"], literalCategories["date"], literalCategories["time"]:
+		cmp = compareTimes(valueA.(time.Time), valueB.(time.Time))
+	case literalCategories["duration"]:
+		// durations are only partially ordered, so they are sorted by months, then by days & time
+		durationA, durationB := valueA.(Duration), valueB.(Duration)
+		if cmp = compareInts(int(durationA.Months), int(durationB.Months)); cmp == 0 {
+			cmp = compareInts(int(durationA.DayTime), int(durationB.DayTime))
+		}
+	}
+	if cmp != 0 {
+		return cmp
+	}
+	if cmp = strings.Compare(a.Value, b.Value); cmp != 0 {
+		return cmp
+	}
+	if cmp = strings.Compare(a.Datatype(), b.Datatype()); cmp != 0 {
+		return cmp
+	}
+	if cmp = strings.Compare(strings.ToLower(a.Lang), strings.ToLower(b.Lang)); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(a.Dir, b.Dir)
+}
+
+// isNaN returns True if a numeric value is NaN.
+func isNaN(value interface{}) bool {
+	f, isFloat := value.(float64)
+	return isFloat && math.IsNaN(f)
+}
+
+// compareBools compares two booleans, where False is lower than True.
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	}
+	return 1
+}
+
+// Compare compares two RDF nodes, and returns -1, 0 or 1 if the first is lower than, equal to or greater than the second one.
+//
+// It defines a total ordering of the RDF terms, which extends the ordering of SPARQL ORDER BY:
+// unbound values (nil or variables) are lower than blank nodes, which are lower than IRIs, which are lower than literals.
+// Blank nodes, IRIs & variables are sorted by their labels.
+// Literals are sorted by category (numbers, booleans, dates & times, durations, strings, literals with a language, others),
+// then by value for supported datatypes, so "2"^^xsd:integer is lower than "10"^^xsd:decimal,
+// and finally by lexical form, datatype & language to separate the literals with the same value.
+//
+// SPARQL ORDER BY reference : https://www.w3.org/TR/sparql11-query/#modOrderBy
+func Compare(a, b Node) int {
+	rankA, rankB := nodeRank(a), nodeRank(b)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+	switch nodeA := a.(type) {
+	case nil:
+		if b == nil {
+			return 0
+		}
+		return -1
+	case Variable:
+		if b == nil {
+			return 1
+		}
+		return strings.Compare(nodeA.Value, b.(Variable).Value)
+	case BlankNode:
+		return strings.Compare(nodeA.Value, b.(BlankNode).Value)
+	case URI:
+		return strings.Compare(nodeA.Value, b.(URI).Value)
+	case Literal:
+		return compareLiterals(nodeA, b.(Literal))
+	case QuotedTriple:
+		return CompareTriples(nodeA.Triple(), b.(QuotedTriple).Triple())
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// CompareTriples compares two triples by subject, predicate then object, using Compare.
+func CompareTriples(a, b Triple) int {
+	if cmp := Compare(a.Subject, b.Subject); cmp != 0 {
+		return cmp
+	} else if cmp = Compare(a.Predicate, b.Predicate); cmp != 0 {
+		return cmp
+	}
+	return Compare(a.Object, b.Object)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	// the nodes, in ascending order
+	datas := []Node{
+		nil,
+		NewVariable("x"),
+		NewBlankNode("a"),
+		NewBlankNode("b"),
+		NewURI("http://example.org/a"),
+		NewURI("http://example.org/b"),
+		NewTypedLiteral("NaN", XSDDouble),
+		NewTypedLiteral("-INF", XSDDouble),
+		NewTypedLiteral("-5", XSDInteger),
+		NewTypedLiteral("1", XSDInteger),
+		NewTypedLiteral("1.0", XSDDecimal),
+		NewTypedLiteral("1.0E0", XSDDouble),
+		NewTypedLiteral("2", XSDInteger),
+		NewTypedLiteral("10", XSDInteger),
+		NewTypedLiteral("false", XSDBoolean),
+		NewTypedLiteral("true", XSDBoolean),
+		NewTypedLiteral("2016-04-05T10:00:00+02:00", XSDDateTime),
+		NewTypedLiteral("2016-04-05T09:00:00Z", XSDDateTime),
+		NewTypedLiteral("2015-12-31", XSDDate),
+		NewTypedLiteral("PT10H", XSDDuration),
+		NewTypedLiteral("P1M", XSDDuration),
+		NewLiteral("abc"),
+		NewLiteral("abd"),
+		NewDirLangLiteral("abc", "ar", "ltr"),
+		NewDirLangLiteral("abc", "ar", "rtl"),
+		NewLangLiteral("abc", "en"),
+		NewLangLiteral("abc", "fr"),
+		NewTypedLiteral("a", "http://example.org/type"),
+		NewTypedLiteral("abc", XSDInteger),
+	}
+
+	for i, a := range datas {
+		for j, b := range datas {
+			expected := compareInts(i, j)
+			if cmp := Compare(a, b); cmp != expected {
+				t.Error("expected the comparison of", a, "and", b, "to be", expected, "but instead got", cmp)
+			}
+		}
+	}
+
+	// sorting a shuffled list gives the same order
+	shuffled := make([]Node, len(datas))
+	copy(shuffled, datas)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	sort.Slice(shuffled, func(i, j int) bool {
+		return Compare(shuffled[i], shuffled[j]) < 0
+	})
+	for i := range datas {
+		if Compare(shuffled[i], datas[i]) != 0 {
+			t.Error("expected", datas[i], "at position", i, "but instead got", shuffled[i])
+		}
+	}
+}
+
+func TestCompareTriples(t *testing.T) {
+	a, b := NewURI("http://example.org/a"), NewURI("http://example.org/b")
+	datas := []Triple{
+		NewTriple(NewBlankNode("z"), b, b),
+		NewTriple(a, a, NewTypedLiteral("9", XSDInteger)),
+		NewTriple(a, a, NewTypedLiteral("10", XSDInteger)),
+		NewTriple(a, b, a),
+		NewTriple(b, a, a),
+	}
+	for i, x := range datas {
+		for j, y := range datas {
+			if cmp := CompareTriples(x, y); cmp != compareInts(i, j) {
+				t.Error("expected the comparison of", x, "and", y, "to be", compareInts(i, j), "but instead got", cmp)
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// splits an IRI reference into its components, as in RFC 3986, appendix B
+	iriRegexp    = regexp.MustCompile(`^(([^:/?#]+):)?(//([^/?#]*))?([^?#]*)(\?([^#]*))?(#(.*))?$`)
+	schemeRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*$`)
+)
+
+// IRI is an Internationalized Resource Identifier, split into its components.
+//
+// IRI reference : https://www.ietf.org/rfc/rfc3987.txt
+// URI Generic Syntax reference : https://www.ietf.org/rfc/rfc3986.txt
+type IRI struct {
+	Scheme    string
+	Authority string
+	Path      string
+	Query     string
+	Fragment  string
+	// an authority, a query or a fragment can be defined but empty, e.g. file:///tmp or http://example.org/?
+	HasAuthority bool
+	HasQuery     bool
+	HasFragment  bool
+}
+
+// isHex returns True if a byte is an hexadecimal digit.
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// validateChars checks that a component of an IRI only contains allowed characters & valid percent-encodings.
+func validateChars(component, iri string) error {
+	for i, c := range component {
+		switch {
+		case c <= 0x20 || c == 0x7f || strings.ContainsRune("<>\"{}|\\^`", c):
+			return errors.New("Error : the IRI " + iri + " contains an invalid character")
+		case c == '%' && (i+2 >= len(component) || !isHex(component[i+1]) || !isHex(component[i+2])):
+			return errors.New("Error : the IRI " + iri + " contains an invalid percent-encoding")
+		}
+	}
+	return nil
+}
+
+// ParseIRI parses an IRI reference, which can be absolute (e.g. http://example.org/a#b) or relative (e.g. ../a#b),
+// and checks that it contains only valid characters.
+func ParseIRI(value string) (IRI, error) {
+	parts := iriRegexp.FindStringSubmatch(value)
+	iri := IRI{parts[2], parts[4], parts[5], parts[7], parts[9], parts[3] != "", parts[6] != "", parts[8] != ""}
+	if parts[1] != "" && !schemeRegexp.MatchString(iri.Scheme) {
+		return iri, errors.New("Error : the IRI " + value + " has an invalid scheme")
+	}
+	if strings.Contains(iri.Fragment, "#") {
+		return iri, errors.New("Error : the IRI " + value + " contains several fragments")
+	}
+	for _, component := range []string{iri.Authority, iri.Path, iri.Query, iri.Fragment} {
+		if err := validateChars(component, value); err != nil {
+			return iri, err
+		}
+	}
+	// without authority, the path cannot start with //
+	if !iri.HasAuthority && strings.HasPrefix(iri.Path, "//") {
+		return iri, errors.New("Error : the path of the IRI " + value + " cannot start with //")
+	}
+	return iri, nil
+}
+
+// IsAbsolute returns True if the IRI has a scheme, i.e. it doesn't need to be resolved against a base IRI.
+func (i IRI) IsAbsolute() bool {
+	return i.Scheme != ""
+}
+
+// String recomposes the IRI from its components.
+func (i IRI) String() string {
+	value := ""
+	if i.Scheme != "" {
+		value += i.Scheme + ":"
+	}
+	if i.HasAuthority {
+		value += "//" + i.Authority
+	}
+	value += i.Path
+	if i.HasQuery {
+		value += "?" + i.Query
+	}
+	if i.HasFragment {
+		value += "#" + i.Fragment
+	}
+	return value
+}
+
+// removeDotSegments removes the . and .. segments of a path, as in RFC 3986, section 5.2.4.
+func removeDotSegments(path string) string {
+	output := make([]string, 0)
+	input := path
+	for input != "" {
+		switch {
+		case strings.HasPrefix(input, "../"):
+			input = input[3:]
+		case strings.HasPrefix(input, "./"):
+			input = input[2:]
+		case strings.HasPrefix(input, "/./"):
+			input = input[2:]
+		case input == "/.":
+			input = "/"
+		case strings.HasPrefix(input, "/../"):
+			input = input[3:]
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case input == "/..":
+			input = "/"
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case input == "." || input == "..":
+			input = ""
+		default:
+			// move the first segment, with its leading slash, to the output
+			end := strings.IndexByte(input[1:], '/')
+			if end < 0 {
+				output = append(output, input)
+				input = ""
+			} else {
+				output = append(output, input[:end+1])
+				input = input[end+1:]
+			}
+		}
+	}
+	return strings.Join(output, "")
+}
+
+// Resolve resolves an IRI reference against the IRI, used as base IRI, as in RFC 3986, section 5.2.2.
+// The base IRI must be absolute.
+func (i IRI) Resolve(ref IRI) (IRI, error) {
+	if !i.IsAbsolute() {
+		return ref, errors.New("Error : cannot resolve an IRI against the relative IRI " + i.String())
+	}
+	var target IRI
+	switch {
+	case ref.Scheme != "":
+		target = ref
+		target.Path = removeDotSegments(ref.Path)
+	case ref.HasAuthority:
+		target = ref
+		target.Scheme = i.Scheme
+		target.Path = removeDotSegments(ref.Path)
+	default:
+		target = IRI{i.Scheme, i.Authority, ref.Path, ref.Query, "", i.HasAuthority, ref.HasQuery, false}
+		if ref.Path == "" {
+			target.Path = i.Path
+			if !ref.HasQuery {
+				target.Query, target.HasQuery = i.Query, i.HasQuery
+			}
+		} else if strings.HasPrefix(ref.Path, "/") {
+			target.Path = removeDotSegments(ref.Path)
+		} else if i.HasAuthority && i.Path == "" {
+			target.Path = removeDotSegments("/" + ref.Path)
+		} else {
+			target.Path = removeDotSegments(i.Path[:strings.LastIndex(i.Path, "/")+1] + ref.Path)
+		}
+	}
+	target.Fragment, target.HasFragment = ref.Fragment, ref.HasFragment
+	return target, nil
+}
+
+// normalizePercents uppercases the percent-encodings of a component, and decodes those of unreserved characters.
+func normalizePercents(component string) string {
+	if !strings.Contains(component, "%") {
+		return component
+	}
+	var res strings.Builder
+	for i := 0; i < len(component); i++ {
+		if component[i] == '%' && i+2 < len(component) && isHex(component[i+1]) && isHex(component[i+2]) {
+			hex := strings.ToUpper(component[i+1 : i+3])
+			var c byte
+			for _, digit := range hex {
+				c *= 16
+				if digit >= 'A' {
+					c += byte(digit-'A') + 10
+				} else {
+					c += byte(digit - '0')
+				}
+			}
+			if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || strings.IndexByte("-._~", c) >= 0 {
+				res.WriteByte(c)
+			} else {
+				res.WriteString("%" + hex)
+			}
+			i += 2
+		} else {
+			res.WriteByte(component[i])
+		}
+	}
+	return res.String()
+}
+
+// Normalize returns the IRI in normal form, as in RFC 3986, section 6: the scheme & the host are lowercased,
+// the percent-encodings are normalized, the dot segments are removed and the default ports of HTTP(S) are removed.
+func (i IRI) Normalize() IRI {
+	res := i
+	res.Scheme = strings.ToLower(i.Scheme)
+	if i.HasAuthority {
+		userinfo, host := "", normalizePercents(i.Authority)
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			userinfo, host = host[:at+1], host[at+1:]
+		}
+		host = strings.ToLower(host)
+		if (res.Scheme == "http" && strings.HasSuffix(host, ":80")) || (res.Scheme == "https" && strings.HasSuffix(host, ":443")) {
+			host = host[:strings.LastIndex(host, ":")]
+		}
+		res.Authority = userinfo + strings.TrimSuffix(host, ":")
+	}
+	res.Path = normalizePercents(i.Path)
+	if i.IsAbsolute() {
+		res.Path = removeDotSegments(res.Path)
+	}
+	if res.Path == "" && i.HasAuthority && (res.Scheme == "http" || res.Scheme == "https") {
+		res.Path = "/"
+	}
+	res.Query = normalizePercents(i.Query)
+	res.Fragment = normalizePercents(i.Fragment)
+	return res
+}
+
+// ResolveIRI resolves an IRI reference against a base IRI, and returns the resulting IRI.
+// If the base IRI is empty, the reference is only validated.
+func ResolveIRI(base, ref string) (string, error) {
+	refIRI, err := ParseIRI(ref)
+	if err != nil {
+		return ref, err
+	}
+	if base == "" {
+		return ref, nil
+	}
+	baseIRI, err := ParseIRI(base)
+	if err != nil {
+		return ref, err
+	}
+	target, err := baseIRI.Resolve(refIRI)
+	return target.String(), err
+}
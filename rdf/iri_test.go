@@ -0,0 +1,114 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import "testing"
+
+func TestResolveIRI(t *testing.T) {
+	// examples from RFC 3986, section 5.4
+	base := "http://a/b/c/d;p?q"
+	datas := [][]string{
+		{"g:h", "g:h"},
+		{"g", "http://a/b/c/g"},
+		{"./g", "http://a/b/c/g"},
+		{"g/", "http://a/b/c/g/"},
+		{"/g", "http://a/g"},
+		{"//g", "http://g"},
+		{"?y", "http://a/b/c/d;p?y"},
+		{"g?y", "http://a/b/c/g?y"},
+		{"#s", "http://a/b/c/d;p?q#s"},
+		{"g#s", "http://a/b/c/g#s"},
+		{"g?y#s", "http://a/b/c/g?y#s"},
+		{";x", "http://a/b/c/;x"},
+		{"g;x", "http://a/b/c/g;x"},
+		{"g;x?y#s", "http://a/b/c/g;x?y#s"},
+		{"", "http://a/b/c/d;p?q"},
+		{".", "http://a/b/c/"},
+		{"./", "http://a/b/c/"},
+		{"..", "http://a/b/"},
+		{"../", "http://a/b/"},
+		{"../g", "http://a/b/g"},
+		{"../..", "http://a/"},
+		{"../../", "http://a/"},
+		{"../../g", "http://a/g"},
+		{"../../../g", "http://a/g"},
+		{"../../../../g", "http://a/g"},
+		{"/./g", "http://a/g"},
+		{"/../g", "http://a/g"},
+		{"g.", "http://a/b/c/g."},
+		{".g", "http://a/b/c/.g"},
+		{"g..", "http://a/b/c/g.."},
+		{"..g", "http://a/b/c/..g"},
+		{"./../g", "http://a/b/g"},
+		{"./g/.", "http://a/b/c/g/"},
+		{"g/./h", "http://a/b/c/g/h"},
+		{"g/../h", "http://a/b/c/h"},
+		{"g;x=1/./y", "http://a/b/c/g;x=1/y"},
+		{"g;x=1/../y", "http://a/b/c/y"},
+		{"g?y/./x", "http://a/b/c/g?y/./x"},
+		{"g#s/../x", "http://a/b/c/g#s/../x"},
+		{"http:g", "http:g"},
+		{"#é", "http://a/b/c/d;p?q#é"},
+	}
+	for _, data := range datas {
+		if res, err := ResolveIRI(base, data[0]); err != nil || res != data[1] {
+			t.Error("expected", data[0], "to be resolved to", data[1], "but instead got", res, err)
+		}
+	}
+	if res, err := ResolveIRI("http://example.org", "a"); err != nil || res != "http://example.org/a" {
+		t.Error("expected a relative path to be resolved against a base IRI without path, but instead got", res, err)
+	}
+	if _, err := ResolveIRI("../relative", "a"); err == nil {
+		t.Error("expected an error when resolving an IRI against a relative IRI")
+	}
+}
+
+func TestParseIRI(t *testing.T) {
+	iri, err := ParseIRI("http://user@example.org:8080/a/b?q=1#frag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := IRI{"http", "user@example.org:8080", "/a/b", "q=1", "frag", true, true, true}
+	if iri != expected || iri.String() != "http://user@example.org:8080/a/b?q=1#frag" || !iri.IsAbsolute() {
+		t.Error("expected", expected, "but instead got", iri)
+	}
+	if iri, err = ParseIRI("file:///tmp/data.ttl?"); err != nil || iri.String() != "file:///tmp/data.ttl?" {
+		t.Error("expected an empty authority & an empty query to be kept, but instead got", iri, err)
+	}
+
+	invalids := []string{
+		"http://example.org/a b",
+		"http://example.org/<a>",
+		"http://example.org/%2",
+		"http://example.org/%zz",
+		"1http://example.org/",
+		"http://example.org/#a#b",
+		"//\\",
+	}
+	for _, invalid := range invalids {
+		if _, err := ParseIRI(invalid); err == nil {
+			t.Error("expected an error when parsing the invalid IRI", invalid)
+		}
+	}
+}
+
+func TestNormalizeIRI(t *testing.T) {
+	datas := [][]string{
+		{"HTTP://Example.ORG:80", "http://example.org/"},
+		{"https://User@Example.org:443/a/./b/../c", "https://User@example.org/a/c"},
+		{"http://example.org/%7euser/%2f%41?q=%7e#%7E", "http://example.org/~user/%2FA?q=~#~"},
+		{"http://example.org:/a", "http://example.org/a"},
+		{"urn:ISBN:0-486", "urn:ISBN:0-486"},
+	}
+	for _, data := range datas {
+		iri, err := ParseIRI(data[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res := iri.Normalize().String(); res != data[1] {
+			t.Error("expected", data[0], "to be normalized to", data[1], "but instead got", res)
+		}
+	}
+}
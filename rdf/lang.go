@@ -0,0 +1,234 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"sort"
+	"strings"
+)
+
+// grandfathered language tags, which are well-formed even if they don't follow the syntax of the other tags
+var grandfathered = map[string]bool{
+	"en-gb-oed": true, "i-ami": true, "i-bnn": true, "i-default": true, "i-enochian": true, "i-hak": true,
+	"i-klingon": true, "i-lux": true, "i-mingo": true, "i-navajo": true, "i-pwn": true, "i-tao": true,
+	"i-tay": true, "i-tsu": true, "sgn-be-fr": true, "sgn-be-nl": true, "sgn-ch-de": true,
+	"art-lojban": true, "cel-gaulish": true, "no-bok": true, "no-nyn": true, "zh-guoyu": true,
+	"zh-hakka": true, "zh-min": true, "zh-min-nan": true, "zh-xiang": true,
+}
+
+// isAlpha returns True if a subtag only contains ASCII letters.
+func isAlpha(subtag string) bool {
+	for _, r := range subtag {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits returns True if a subtag only contains ASCII digits.
+func isDigits(subtag string) bool {
+	for _, r := range subtag {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanum returns True if a subtag only contains ASCII letters & digits.
+func isAlphanum(subtag string) bool {
+	for _, r := range subtag {
+		if !isAlpha(string(r)) && !isDigits(string(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isVariant returns True if a subtag has the syntax of a variant subtag, e.g. 1996 or rozaj.
+func isVariant(subtag string) bool {
+	return len(subtag) >= 5 || (len(subtag) == 4 && isDigits(subtag[:1]))
+}
+
+// ValidLangTag returns True if a language tag is well-formed according to BCP 47, e.g. en, en-GB or zh-Hant-TW.
+// The registry of the subtags isn't checked, so an unknown language with a valid syntax is accepted.
+//
+// BCP 47 reference : https://www.rfc-editor.org/rfc/rfc5646#section-2.1
+func ValidLangTag(tag string) bool {
+	if grandfathered[strings.ToLower(tag)] {
+		return true
+	}
+	subtags := strings.Split(tag, "-")
+	for _, subtag := range subtags {
+		if subtag == "" || len(subtag) > 8 || !isAlphanum(subtag) {
+			return false
+		}
+	}
+	// private use tag, e.g. x-whatever
+	if strings.EqualFold(subtags[0], "x") {
+		return len(subtags) > 1
+	}
+	// language, with up to three extended language subtags
+	if len(subtags[0]) < 2 || !isAlpha(subtags[0]) {
+		return false
+	}
+	i := 1
+	if len(subtags[0]) <= 3 {
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+			i++
+		}
+	}
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		i++
+	}
+	// region
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigits(subtags[i]))) {
+		i++
+	}
+	for i < len(subtags) && isVariant(subtags[i]) {
+		i++
+	}
+	// extensions, made of a singleton followed by subtags of at least two characters
+	for i < len(subtags) && len(subtags[i]) == 1 && !strings.EqualFold(subtags[i], "x") {
+		i++
+		start := i
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	// private use subtags
+	if i < len(subtags) && strings.EqualFold(subtags[i], "x") {
+		return i+1 < len(subtags)
+	}
+	return i == len(subtags)
+}
+
+// NormalizeLangTag normalizes the case of a language tag, following the conventions of BCP 47:
+// the language is in lowercase, the script in titlecase & the region in uppercase, e.g. zh-hant-tw becomes zh-Hant-TW.
+// The subtags of the extensions & of the private use are in lowercase.
+//
+// Since the language tags are case-insensitive, two equivalent tags are always normalized into the same tag.
+func NormalizeLangTag(tag string) string {
+	subtags := strings.Split(strings.ToLower(tag), "-")
+	for i := 1; i < len(subtags); i++ {
+		subtag := subtags[i]
+		if len(subtag) == 1 {
+			break
+		}
+		if len(subtag) == 2 {
+			subtags[i] = strings.ToUpper(subtag)
+		} else if len(subtag) == 4 && isAlpha(subtag) {
+			subtags[i] = strings.ToUpper(subtag[:1]) + subtag[1:]
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+// LangMatches returns True if a language tag matches a basic language range, like the SPARQL function langMatches,
+// i.e. if the range is the tag or a prefix of the tag, e.g. fr matches fr-BE, or if the range is * and the tag isn't empty.
+// The comparison is case-insensitive.
+//
+// Basic filtering reference : https://www.rfc-editor.org/rfc/rfc4647#section-3.3.1
+func LangMatches(tag, langRange string) bool {
+	if langRange == "*" {
+		return tag != ""
+	}
+	tag, langRange = strings.ToLower(tag), strings.ToLower(langRange)
+	return tag == langRange || (langRange != "" && strings.HasPrefix(tag, langRange+"-"))
+}
+
+// ExtendedLangMatches returns True if a language tag matches an extended language range,
+// where the wildcard * matches any sequence of subtags, e.g. de-*-DE matches de-Latn-DE.
+// The comparison is case-insensitive.
+//
+// Extended filtering reference : https://www.rfc-editor.org/rfc/rfc4647#section-3.3.2
+func ExtendedLangMatches(tag, langRange string) bool {
+	if tag == "" || langRange == "" {
+		return false
+	}
+	tags := strings.Split(strings.ToLower(tag), "-")
+	ranges := strings.Split(strings.ToLower(langRange), "-")
+	if ranges[0] != "*" && ranges[0] != tags[0] {
+		return false
+	}
+	t, r := 1, 1
+	for r < len(ranges) {
+		switch {
+		case ranges[r] == "*":
+			r++
+		case t >= len(tags):
+			return false
+		case ranges[r] == tags[t]:
+			r++
+			t++
+		case len(tags[t]) == 1:
+			// the subtags of the range cannot match the subtags following a singleton
+			return false
+		default:
+			t++
+		}
+	}
+	return true
+}
+
+// truncateRange removes the last subtag of a language range, and the singleton which precedes it, e.g. zh-Hant-CN-x-private becomes zh-Hant-CN.
+func truncateRange(langRange string) string {
+	index := strings.LastIndex(langRange, "-")
+	if index < 0 {
+		return ""
+	}
+	langRange = langRange[:index]
+	if index = strings.LastIndex(langRange, "-"); index >= 0 && index == len(langRange)-2 {
+		langRange = langRange[:index]
+	}
+	return langRange
+}
+
+// BestLiteral selects the literal which best matches a list of preferred languages, in order of preference,
+// e.g. to choose the label of a resource.
+//
+// For each language, the literals whose tag matches it are selected, then the literals matching the language
+// with its last subtag removed, etc, so en-GB selects en-GB, then en or en-US. The literal whose tag is the language is preferred.
+// If no literal matches the languages, the literals without a language are preferred, then the other literals.
+// The ties are broken using the order defined by Compare, so the selection is deterministic.
+//
+// It returns False if there is no literal.
+func BestLiteral(literals []Literal, languages ...string) (Literal, bool) {
+	if len(literals) == 0 {
+		return Literal{}, false
+	}
+	sorted := make([]Literal, len(literals))
+	copy(sorted, literals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareLiterals(sorted[i], sorted[j]) < 0
+	})
+	for _, language := range languages {
+		for langRange := language; langRange != ""; langRange = truncateRange(langRange) {
+			var best *Literal
+			for i, literal := range sorted {
+				if !LangMatches(literal.Lang, langRange) {
+					continue
+				}
+				if best == nil || (strings.EqualFold(literal.Lang, langRange) && !strings.EqualFold(best.Lang, langRange)) {
+					best = &sorted[i]
+				}
+			}
+			if best != nil {
+				return *best, true
+			}
+		}
+	}
+	for _, literal := range sorted {
+		if literal.Lang == "" {
+			return literal, true
+		}
+	}
+	return sorted[0], true
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"testing"
+)
+
+func TestValidLangTag(t *testing.T) {
+	valid := []string{"en", "en-GB", "zh-Hant-TW", "zh-yue-HK", "sl-rozaj-biske", "de-CH-1901", "es-419",
+		"en-US-u-islamcal", "de-CH-x-phonebk", "x-whatever", "i-klingon", "EN-gb", "qaa-Qaaa-QM-x-southern"}
+	invalid := []string{"", "e", "en-", "-en", "en--GB", "en_GB", "abcdefghi", "en-a", "en-x", "1en", "en-GB-a-", "fr-é"}
+	for _, tag := range valid {
+		if !ValidLangTag(tag) {
+			t.Error("the language tag", tag, "should be well-formed")
+		}
+	}
+	for _, tag := range invalid {
+		if ValidLangTag(tag) {
+			t.Error("the language tag", tag, "shouldn't be well-formed")
+		}
+	}
+}
+
+func TestNormalizeLangTag(t *testing.T) {
+	datas := [][]string{
+		[]string{"EN", "en"},
+		[]string{"en-gb", "en-GB"},
+		[]string{"ZH-HANT-tw", "zh-Hant-TW"},
+		[]string{"sgn-be-fr", "sgn-BE-FR"},
+		[]string{"en-US-x-Twain-AB", "en-US-x-twain-ab"},
+		[]string{"es-419", "es-419"},
+	}
+	for _, data := range datas {
+		if tag := NormalizeLangTag(data[0]); tag != data[1] {
+			t.Error("expected", data[0], "to be normalized into", data[1], "but instead got", tag)
+		}
+	}
+	literal := NewLangLiteral("colour", "EN-gb")
+	if literal.Lang != "en-GB" {
+		t.Error("expected the language of a new literal to be normalized, but instead got", literal.Lang)
+	}
+	if test, err := literal.Equals(Literal{"colour", "", "en-gb", ""}); !test || err != nil {
+		t.Error("the languages of the literals should be compared case-insensitively")
+	}
+	if Compare(literal, Literal{"colour", "", "EN-GB", ""}) != 0 {
+		t.Error("the languages of the literals should be sorted case-insensitively")
+	}
+}
+
+func TestLangMatches(t *testing.T) {
+	datas := []struct {
+		tag, langRange string
+		basic          bool
+		extended       bool
+	}{
+		{"fr", "fr", true, true},
+		{"fr-BE", "FR", true, true},
+		{"fr", "fr-BE", false, false},
+		{"fra", "fr", false, false},
+		{"en", "*", true, true},
+		{"", "*", false, false},
+		{"", "", true, false},
+		{"de-Latn-DE", "de-DE", false, true},
+		{"de-Latn-DE-1996", "de-*-DE", false, true},
+		{"de-x-DE", "de-DE", false, false},
+		{"de-Deva", "de-DE", false, false},
+		{"de-DE", "*-DE", false, true},
+	}
+	for _, data := range datas {
+		if LangMatches(data.tag, data.langRange) != data.basic {
+			t.Error("expected the basic matching of", data.tag, "with", data.langRange, "to be", data.basic)
+		}
+		if ExtendedLangMatches(data.tag, data.langRange) != data.extended {
+			t.Error("expected the extended matching of", data.tag, "with", data.langRange, "to be", data.extended)
+		}
+	}
+}
+
+func TestBestLiteral(t *testing.T) {
+	literals := []Literal{
+		NewLangLiteral("Farbe", "de"),
+		NewLangLiteral("color", "en-US"),
+		NewLangLiteral("colour", "en"),
+		NewLangLiteral("colour", "en-GB"),
+		NewLiteral("color"),
+	}
+	datas := []struct {
+		languages []string
+		expected  Literal
+	}{
+		{[]string{"en-GB"}, NewLangLiteral("colour", "en-GB")},
+		{[]string{"en-AU"}, NewLangLiteral("colour", "en")},
+		{[]string{"en"}, NewLangLiteral("colour", "en")},
+		{[]string{"fr", "de-CH"}, NewLangLiteral("Farbe", "de")},
+		{[]string{"fr"}, NewLiteral("color")},
+		{[]string{}, NewLiteral("color")},
+	}
+	for _, data := range datas {
+		best, found := BestLiteral(literals, data.languages...)
+		if test, err := best.Equals(data.expected); !found || !test || err != nil {
+			t.Error("expected the best literal for", data.languages, "to be", data.expected, "but instead got", best)
+		}
+	}
+	if best, found := BestLiteral(literals[:2], "fr"); !found || best.Value != "Farbe" {
+		t.Error("expected the first literal to be selected when no literal matches, but instead got", best)
+	}
+	if _, found := BestLiteral(nil, "en"); found {
+		t.Error("no literal should be selected in an empty list")
+	}
+}
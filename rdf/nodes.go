@@ -5,7 +5,10 @@
 // Package rdf provides primitives to work with RDF
 package rdf
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // Node represents a generic node in a RDF Grapg
 //
@@ -24,13 +27,26 @@ type URI struct {
 
 // Literal represents a Literal node in a RDF Graph.
 //
+// The literals without datatype nor language have the datatype xsd:string, and the literals with a language
+// have the datatype rdf:langString, or rdf:dirLangString if they also have a base direction (ltr or rtl).
+// The Type of a literal with a language is ignored.
+//
 // RDF Literal reference : https://www.w3.org/TR/rdf11-concepts/#section-Graph-Literal
+// RDF 1.2 base direction reference : https://www.w3.org/TR/rdf12-concepts/#section-text-direction
 type Literal struct {
 	Value string
 	Type  string
 	Lang  string
+	Dir   string
 }
 
+const (
+	// RDFLangString is the datatype of the literals with a language
+	RDFLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+	// RDFDirLangString is the datatype of the literals with a language & a base direction
+	RDFDirLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#dirLangString"
+)
+
 // BlankNode represents a Blank Node in a RDF Graph.
 //
 // RDF Blank Node reference : https://www.w3.org/TR/rdf11-concepts/#section-blank-nodes
@@ -65,39 +81,72 @@ func NewURI(value string) URI {
 }
 
 // Equals is a function that compare a Literal with another RDF Node and return True if they are equals, False otherwise.
+// The literals are compared using their datatypes, so "a" & "a"^^xsd:string are equals,
+// and the languages of the literals are compared case-insensitively.
 func (l Literal) Equals(n Node) (bool, error) {
 	other, ok := n.(Literal)
 	if ok {
-		return (l.Value == other.Value) && (l.Type == other.Type) && (l.Lang == other.Lang), nil
+		return (l.Value == other.Value) && (l.Datatype() == other.Datatype()) && strings.EqualFold(l.Lang, other.Lang) && (l.Dir == other.Dir), nil
 	} else if _, isVar := n.(Variable); isVar {
 		return true, nil
 	}
 	return false, errors.New("Error : mismatch type, can only compare two Literals")
 }
 
+// Datatype returns the IRI of the datatype of a Literal.
+func (l Literal) Datatype() string {
+	switch {
+	case l.Lang != "" && l.Dir != "":
+		return RDFDirLangString
+	case l.Lang != "":
+		return RDFLangString
+	case l.Type == "":
+		return XSDString
+	}
+	return l.Type
+}
+
 // Serialize a Literal to string and return it.
 func (l Literal) String() string {
-	if l.Type != "" {
-		return "\"" + l.Value + "\"^^<" + l.Type + ">"
+	if l.Lang != "" && l.Dir != "" {
+		return "\"" + l.Value + "\"@" + l.Lang + "--" + l.Dir
 	} else if l.Lang != "" {
 		return "\"" + l.Value + "\"@" + l.Lang
+	} else if l.Type != "" {
+		return "\"" + l.Value + "\"^^<" + l.Datatype() + ">"
 	}
 	return "\"" + l.Value + "\""
 }
 
 // NewLiteral creates a new Literal.
 func NewLiteral(value string) Literal {
-	return Literal{value, "", ""}
+	return Literal{value, "", "", ""}
 }
 
-// NewTypedLiteral returns a new Literal with a type.
+// NewTypedLiteral returns a new Literal with a type, which can be written with or without angle brackets.
+// Since xsd:string is the implicit datatype of the literals, a Literal with this type is the same as a Literal without type.
 func NewTypedLiteral(value, xmlType string) Literal {
-	return Literal{value, xmlType, ""}
+	xmlType = strings.TrimSuffix(strings.TrimPrefix(xmlType, "<"), ">")
+	if xmlType == XSDString {
+		return NewLiteral(value)
+	}
+	return Literal{value, xmlType, "", ""}
 }
 
-// NewLangLiteral returns a new Literal with a language.
+// NewLangLiteral returns a new Literal with a language, whose case is normalized with NormalizeLangTag.
 func NewLangLiteral(value, lang string) Literal {
-	return Literal{value, "", lang}
+	return Literal{value, "", NormalizeLangTag(lang), ""}
+}
+
+// NewDirLangLiteral returns a new Literal with a language & a base direction, which is ltr or rtl.
+// The case of the language is normalized with NormalizeLangTag.
+func NewDirLangLiteral(value, lang, dir string) Literal {
+	return Literal{value, "", NormalizeLangTag(lang), dir}
+}
+
+// ValidDirection returns True if a base direction is valid, i.e. it is ltr or rtl.
+func ValidDirection(dir string) bool {
+	return dir == "ltr" || dir == "rtl"
 }
 
 // Equals is a function that compare a Blank Node with another RDF Node and return True if they are equals, False otherwise.
@@ -75,11 +75,13 @@ func TestLiteralEquals(t *testing.T) {
 
 func TestLiteralString(t *testing.T) {
 	literal := NewLiteral("22")
-	typedLiteral := NewTypedLiteral("22", "http://www.w3.org/2001/XMLSchema#string")
+	typedLiteral := NewTypedLiteral("22", "http://www.w3.org/2001/XMLSchema#integer")
 	langLiteral := NewLangLiteral("World of Warcraft", "en")
+	dirLiteral := NewDirLangLiteral("World of Warcraft", "en", "ltr")
 	expectedLiteral := "\"22\""
-	expectedTypedLiteral := "\"22\"^^<http://www.w3.org/2001/XMLSchema#string>"
+	expectedTypedLiteral := "\"22\"^^<http://www.w3.org/2001/XMLSchema#integer>"
 	expectedLangLiteral := "\"World of Warcraft\"@en"
+	expectedDirLiteral := "\"World of Warcraft\"@en--ltr"
 
 	if literal.String() != expectedLiteral {
 		t.Error(literal.String(), "should be equals to", expectedLiteral)
@@ -92,6 +94,53 @@ func TestLiteralString(t *testing.T) {
 	if langLiteral.String() != expectedLangLiteral {
 		t.Error(langLiteral.String(), "should be equals to", expectedLangLiteral)
 	}
+
+	if dirLiteral.String() != expectedDirLiteral {
+		t.Error(dirLiteral.String(), "should be equals to", expectedDirLiteral)
+	}
+
+	// the angle brackets of the datatypes are only written once
+	if typedLiteral = NewTypedLiteral("22", "<http://www.w3.org/2001/XMLSchema#integer>"); typedLiteral.String() != expectedTypedLiteral {
+		t.Error(typedLiteral.String(), "should be equals to", expectedTypedLiteral)
+	}
+
+	// xsd:string is the implicit datatype of the literals
+	if stringLiteral := NewTypedLiteral("22", "<"+XSDString+">"); stringLiteral.String() != expectedLiteral {
+		t.Error(stringLiteral.String(), "should be equals to", expectedLiteral)
+	}
+}
+
+func TestLiteralDatatype(t *testing.T) {
+	datas := []struct {
+		literal  Literal
+		expected string
+	}{
+		{NewLiteral("22"), XSDString},
+		{NewTypedLiteral("22", XSDString), XSDString},
+		{NewTypedLiteral("22", "<"+XSDInteger+">"), XSDInteger},
+		{NewLangLiteral("Joseki", "en"), RDFLangString},
+		{NewDirLangLiteral("Joseki", "en", "rtl"), RDFDirLangString},
+		{Literal{"Joseki", XSDInteger, "en", ""}, RDFLangString},
+	}
+	for _, data := range datas {
+		if datatype := data.literal.Datatype(); datatype != data.expected {
+			t.Error("expected the datatype of", data.literal, "to be", data.expected, "but instead got", datatype)
+		}
+	}
+
+	// literals are compared using their datatypes
+	if test, err := NewLiteral("22").Equals(Literal{"22", XSDString, "", ""}); !test || err != nil {
+		t.Error("a simple literal should be equal to a xsd:string with the same lexical form")
+	}
+	if test, err := NewLangLiteral("22", "en").Equals(NewDirLangLiteral("22", "en", "ltr")); test || err != nil {
+		t.Error("literals with different base directions should be different")
+	}
+	if test, err := NewDirLangLiteral("22", "en", "ltr").Equals(NewDirLangLiteral("22", "en", "rtl")); test || err != nil {
+		t.Error("literals with different base directions should be different")
+	}
+	if !ValidDirection("ltr") || !ValidDirection("rtl") || ValidDirection("LTR") || ValidDirection("auto") {
+		t.Error("only ltr & rtl should be valid base directions")
+	}
 }
 
 // Test the Equals operator of the BlankNode struct
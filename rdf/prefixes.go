@@ -0,0 +1,174 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// well-known prefixes, available in the prefix maps created with DefaultPrefixMap
+var wellKnownPrefixes = map[string]string{
+	"rdf":    "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs":   "http://www.w3.org/2000/01/rdf-schema#",
+	"xsd":    XSDNamespace,
+	"owl":    "http://www.w3.org/2002/07/owl#",
+	"skos":   "http://www.w3.org/2004/02/skos/core#",
+	"foaf":   "http://xmlns.com/foaf/0.1/",
+	"dc":     "http://purl.org/dc/terms/",
+	"schema": "http://schema.org/",
+}
+
+var (
+	// a prefix name, as in the PN_PREFIX rule of Turtle, with an ASCII subset of the allowed characters
+	prefixRegexp = regexp.MustCompile(`^([A-Za-z]([A-Za-z0-9_\-.]*[A-Za-z0-9_\-])?)?$`)
+	// a local name, as in the PN_LOCAL rule of Turtle, without the escaped characters
+	localRegexp = regexp.MustCompile(`^([A-Za-z0-9_:]|[^\x00-\x7f]|%[0-9A-Fa-f]{2})(([A-Za-z0-9_\-.:]|[^\x00-\x7f]|%[0-9A-Fa-f]{2})*([A-Za-z0-9_\-:]|[^\x00-\x7f]|%[0-9A-Fa-f]{2}))?$`)
+)
+
+// PrefixMap associates prefixes to namespaces, to expand & compact IRIs written as CURIEs, e.g. foaf:name.
+//
+// It is safe for concurrent use, so it can be shared by parsers & serializers.
+type PrefixMap struct {
+	namespaces map[string]string
+	*sync.RWMutex
+}
+
+// NewPrefixMap creates a new empty PrefixMap.
+func NewPrefixMap() *PrefixMap {
+	return &PrefixMap{make(map[string]string), &sync.RWMutex{}}
+}
+
+// DefaultPrefixMap creates a new PrefixMap with the well-known prefixes rdf, rdfs, xsd, owl, skos, foaf, dc & schema.
+func DefaultPrefixMap() *PrefixMap {
+	p := NewPrefixMap()
+	for prefix, namespace := range wellKnownPrefixes {
+		p.namespaces[prefix] = namespace
+	}
+	return p
+}
+
+// Bind associates a prefix to a namespace.
+// An error is returned if the prefix isn't valid, or if it is already bound to another namespace.
+func (p *PrefixMap) Bind(prefix, namespace string) error {
+	if !prefixRegexp.MatchString(prefix) {
+		return errors.New("Error : " + prefix + " is not a valid prefix")
+	}
+	p.Lock()
+	defer p.Unlock()
+	if value, bound := p.namespaces[prefix]; bound && value != namespace {
+		return errors.New("Error : the prefix " + prefix + " is already bound to the namespace " + value)
+	}
+	p.namespaces[prefix] = namespace
+	return nil
+}
+
+// Set associates a prefix to a namespace, replacing the namespace previously bound to the prefix, if any.
+// It follows the semantics of the prefix declarations of Turtle, where a prefix can be redefined.
+func (p *PrefixMap) Set(prefix, namespace string) {
+	p.Lock()
+	defer p.Unlock()
+	p.namespaces[prefix] = namespace
+}
+
+// Remove removes a prefix from the map.
+func (p *PrefixMap) Remove(prefix string) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.namespaces, prefix)
+}
+
+// Merge binds all the prefixes of a map which don't conflict with the prefixes already bound.
+// The conflicting prefixes keep their current namespace, and are returned in alphabetical order.
+func (p *PrefixMap) Merge(prefixes map[string]string) []string {
+	conflicts := make([]string, 0)
+	for prefix, namespace := range prefixes {
+		if err := p.Bind(prefix, namespace); err != nil {
+			conflicts = append(conflicts, prefix)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// Namespace returns the namespace bound to a prefix, and a boolean to indicate if it has been found.
+func (p *PrefixMap) Namespace(prefix string) (string, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	namespace, bound := p.namespaces[prefix]
+	return namespace, bound
+}
+
+// Prefix returns the prefix bound to a namespace, and a boolean to indicate if it has been found.
+// If several prefixes are bound to the namespace, the first one in alphabetical order is returned.
+func (p *PrefixMap) Prefix(namespace string) (string, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	res, found := "", false
+	for prefix, value := range p.namespaces {
+		if value == namespace && (!found || prefix < res) {
+			res, found = prefix, true
+		}
+	}
+	return res, found
+}
+
+// Len returns the number of prefixes in the map.
+func (p *PrefixMap) Len() int {
+	p.RLock()
+	defer p.RUnlock()
+	return len(p.namespaces)
+}
+
+// Map returns a copy of the prefixes of the map, associated to their namespaces.
+func (p *PrefixMap) Map() map[string]string {
+	p.RLock()
+	defer p.RUnlock()
+	res := make(map[string]string, len(p.namespaces))
+	for prefix, namespace := range p.namespaces {
+		res[prefix] = namespace
+	}
+	return res
+}
+
+// Expand converts a CURIE, e.g. foaf:name, into an IRI, using the namespace bound to its prefix.
+// An error is returned if the CURIE has no prefix, or if its prefix isn't bound.
+func (p *PrefixMap) Expand(curie string) (string, error) {
+	sepIndex := strings.Index(curie, ":")
+	if sepIndex < 0 {
+		return "", errors.New("Error : " + curie + " is not a CURIE")
+	}
+	namespace, bound := p.Namespace(curie[0:sepIndex])
+	if !bound {
+		return "", errors.New("Error : unknown prefix " + curie[0:sepIndex])
+	}
+	return namespace + curie[sepIndex+1:], nil
+}
+
+// Compact converts an IRI into a CURIE, using the longest namespace which matches the IRI.
+// The namespaces are only used if the rest of the IRI is a valid local name, so the CURIE can be written in Turtle.
+// If several prefixes are bound to the namespace, the first one in alphabetical order is used.
+//
+// It returns False if the IRI cannot be compacted.
+func (p *PrefixMap) Compact(iri string) (string, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	res, namespace, found := "", "", false
+	for prefix, value := range p.namespaces {
+		if value == "" || !strings.HasPrefix(iri, value) {
+			continue
+		}
+		if local := iri[len(value):]; local != "" && !localRegexp.MatchString(local) {
+			continue
+		}
+		if !found || len(value) > len(namespace) || (len(value) == len(namespace) && prefix < res[:strings.Index(res, ":")]) {
+			res, namespace, found = prefix+":"+iri[len(value):], value, true
+		}
+	}
+	return res, found
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"testing"
+)
+
+func TestExpandPrefixMap(t *testing.T) {
+	prefixes := DefaultPrefixMap()
+	prefixes.Set("", "http://example.org/")
+	datas := [][]string{
+		[]string{"foaf:name", "http://xmlns.com/foaf/0.1/name"},
+		[]string{"xsd:integer", XSDInteger},
+		[]string{"schema:", "http://schema.org/"},
+		[]string{":alice", "http://example.org/alice"},
+		[]string{"dc:a:b", "http://purl.org/dc/terms/a:b"},
+	}
+	for _, data := range datas {
+		if iri, err := prefixes.Expand(data[0]); err != nil || iri != data[1] {
+			t.Error("expected", data[0], "to be expanded to", data[1], "but instead got", iri, err)
+		}
+	}
+	for _, curie := range []string{"unknown:name", "name"} {
+		if _, err := prefixes.Expand(curie); err == nil {
+			t.Error("expanding", curie, "should produce an error")
+		}
+	}
+}
+
+func TestCompactPrefixMap(t *testing.T) {
+	prefixes := DefaultPrefixMap()
+	prefixes.Set("ex", "http://example.org/")
+	prefixes.Set("people", "http://example.org/people/")
+	prefixes.Set("alias", "http://example.org/people/")
+	datas := [][]string{
+		[]string{"http://xmlns.com/foaf/0.1/name", "foaf:name"},
+		[]string{"http://example.org/thing", "ex:thing"},
+		[]string{"http://example.org/people/alice", "alias:alice"},
+		[]string{"http://example.org/people/", "alias:"},
+		[]string{"http://example.org/people.html", "ex:people.html"},
+	}
+	for _, data := range datas {
+		if curie, found := prefixes.Compact(data[0]); !found || curie != data[1] {
+			t.Error("expected", data[0], "to be compacted to", data[1], "but instead got", curie)
+		}
+	}
+	for _, iri := range []string{"http://unknown.org/name", "http://example.org/a/b", "http://example.org/a.", "http://example.org/a b"} {
+		if curie, found := prefixes.Compact(iri); found {
+			t.Error("the IRI", iri, "shouldn't be compacted, but instead got", curie)
+		}
+	}
+}
+
+func TestBindPrefixMap(t *testing.T) {
+	prefixes := DefaultPrefixMap()
+	if err := prefixes.Bind("foaf", "http://xmlns.com/foaf/0.1/"); err != nil {
+		t.Error("binding a prefix to its own namespace shouldn't produce the error :", err)
+	}
+	if err := prefixes.Bind("foaf", "http://example.org/foaf/"); err == nil {
+		t.Error("binding a prefix to another namespace should produce an error")
+	}
+	if err := prefixes.Bind("1ex", "http://example.org/"); err == nil {
+		t.Error("binding an invalid prefix should produce an error")
+	}
+	if namespace, _ := prefixes.Namespace("foaf"); namespace != "http://xmlns.com/foaf/0.1/" {
+		t.Error("a conflicting binding shouldn't replace the namespace of a prefix, but instead got", namespace)
+	}
+
+	conflicts := prefixes.Merge(map[string]string{
+		"rdf":  "http://example.org/rdf#",
+		"ex":   "http://example.org/",
+		"owl":  "http://example.org/owl#",
+		"skos": "http://www.w3.org/2004/02/skos/core#",
+	})
+	if len(conflicts) != 2 || conflicts[0] != "owl" || conflicts[1] != "rdf" {
+		t.Error("expected the prefixes owl & rdf to be conflicting, but instead got", conflicts)
+	}
+	if prefix, found := prefixes.Prefix("http://example.org/"); !found || prefix != "ex" {
+		t.Error("expected the prefix ex to be bound by the merge, but instead got", prefix)
+	}
+
+	prefixes.Set("foaf", "http://example.org/foaf/")
+	if namespace, _ := prefixes.Namespace("foaf"); namespace != "http://example.org/foaf/" {
+		t.Error("expected Set to replace the namespace of a prefix, but instead got", namespace)
+	}
+	prefixes.Remove("foaf")
+	if _, found := prefixes.Namespace("foaf"); found {
+		t.Error("the prefix foaf should have been removed")
+	}
+	if prefixes.Len() != len(wellKnownPrefixes) {
+		t.Error("expected", len(wellKnownPrefixes), "prefixes but instead got", prefixes.Len())
+	}
+}
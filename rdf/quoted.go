@@ -0,0 +1,81 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import "errors"
+
+// QuotedTriple represents a triple used as a node of another triple, e.g. << :alice :knows :bob >> :source :facebook .
+// It allows to make statements about statements, like their source or their confidence, without reification.
+//
+// A quoted triple isn't asserted, i.e. it isn't in the graph unless it is added as a triple.
+// RDF-star reference : https://www.w3.org/2021/12/rdf-star.html
+type QuotedTriple struct {
+	Subject   Node
+	Predicate Node
+	Object    Node
+}
+
+// NewQuotedTriple creates a new QuotedTriple from a triple.
+func NewQuotedTriple(triple Triple) QuotedTriple {
+	return QuotedTriple{triple.Subject, triple.Predicate, triple.Object}
+}
+
+// Triple returns the triple which is quoted.
+func (q QuotedTriple) Triple() Triple {
+	return NewTriple(q.Subject, q.Predicate, q.Object)
+}
+
+// Equals is a function that compare a Quoted Triple with another RDF Node and return True if they are equals, False otherwise.
+// The nodes of the quoted triples are compared using their Equals function, so variables match any node.
+func (q QuotedTriple) Equals(n Node) (bool, error) {
+	other, ok := n.(QuotedTriple)
+	if ok {
+		return q.Triple().Equals(other.Triple())
+	} else if _, isVar := n.(Variable); isVar {
+		return true, nil
+	}
+	return false, errors.New("Error : mismatch type, can only compare two Quoted Triples")
+}
+
+// Serialize a Quoted Triple to string and return it.
+func (q QuotedTriple) String() string {
+	return "<< " + q.Subject.String() + " " + q.Predicate.String() + " " + q.Object.String() + " >>"
+}
+
+// IsPattern returns True if a node is a variable, or a quoted triple which contains a variable, at any depth.
+func IsPattern(node Node) bool {
+	switch n := node.(type) {
+	case Variable:
+		return true
+	case QuotedTriple:
+		return IsPattern(n.Subject) || IsPattern(n.Predicate) || IsPattern(n.Object)
+	}
+	return false
+}
+
+// Matches returns True if a node matches a pattern, where the variables match any node,
+// including the variables used inside the quoted triples of the pattern.
+func Matches(pattern, node Node) bool {
+	switch p := pattern.(type) {
+	case Variable:
+		return true
+	case QuotedTriple:
+		quoted, isQuoted := node.(QuotedTriple)
+		return isQuoted && Matches(p.Subject, quoted.Subject) && Matches(p.Predicate, quoted.Predicate) && Matches(p.Object, quoted.Object)
+	}
+	test, err := pattern.Equals(node)
+	return test && err == nil
+}
+
+// mapNodes applies a function to the nodes of a triple, including the nodes of its quoted triples.
+func mapNodes(triple Triple, f func(Node) Node) Triple {
+	replace := func(node Node) Node {
+		if quoted, isQuoted := node.(QuotedTriple); isQuoted {
+			return NewQuotedTriple(mapNodes(quoted.Triple(), f))
+		}
+		return f(node)
+	}
+	return NewTriple(replace(triple.Subject), replace(triple.Predicate), replace(triple.Object))
+}
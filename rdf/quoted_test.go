@@ -0,0 +1,70 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"testing"
+)
+
+func TestQuotedTriple(t *testing.T) {
+	alice, knows, bob := NewURI("http://example.org/alice"), NewURI("http://example.org/knows"), NewURI("http://example.org/bob")
+	quoted := NewQuotedTriple(NewTriple(alice, knows, bob))
+	if quoted.String() != "<< <http://example.org/alice> <http://example.org/knows> <http://example.org/bob> >>" {
+		t.Error("unexpected serialization of a quoted triple :", quoted)
+	}
+	if test, err := quoted.Equals(NewQuotedTriple(NewTriple(alice, knows, bob))); !test || err != nil {
+		t.Error(quoted, "should be equal to itself")
+	}
+	if test, err := quoted.Equals(NewQuotedTriple(NewTriple(bob, knows, alice))); test || err != nil {
+		t.Error(quoted, "shouldn't be equal to another quoted triple")
+	}
+	if test, err := quoted.Equals(NewVariable("v")); !test || err != nil {
+		t.Error("a quoted triple should be equal to a variable")
+	}
+	if _, err := quoted.Equals(alice); err == nil {
+		t.Error("comparing a quoted triple with an URI should produce an error")
+	}
+
+	// the triples must be usable as keys, since the graphs store their nodes in maps
+	nodes := map[Node]bool{quoted: true}
+	if !nodes[NewQuotedTriple(NewTriple(alice, knows, bob))] {
+		t.Error("two identical quoted triples should have the same key in a map")
+	}
+}
+
+func TestMatchesQuotedTriple(t *testing.T) {
+	alice, knows, bob := NewURI("http://example.org/alice"), NewURI("http://example.org/knows"), NewURI("http://example.org/bob")
+	quoted := NewQuotedTriple(NewTriple(alice, knows, NewQuotedTriple(NewTriple(bob, knows, alice))))
+	datas := []struct {
+		pattern  Node
+		expected bool
+	}{
+		{NewVariable("v"), true},
+		{quoted, true},
+		{NewQuotedTriple(NewTriple(NewVariable("s"), knows, NewVariable("o"))), true},
+		{NewQuotedTriple(NewTriple(alice, knows, NewQuotedTriple(NewTriple(NewVariable("s"), knows, alice)))), true},
+		{NewQuotedTriple(NewTriple(alice, knows, NewQuotedTriple(NewTriple(NewVariable("s"), knows, bob)))), false},
+		{NewQuotedTriple(NewTriple(bob, NewVariable("p"), NewVariable("o"))), false},
+		{alice, false},
+	}
+	for _, data := range datas {
+		if Matches(data.pattern, quoted) != data.expected {
+			t.Error("expected the matching of", data.pattern, "with", quoted, "to be", data.expected)
+		}
+	}
+	if IsPattern(quoted) || !IsPattern(datas[2].pattern) || !IsPattern(datas[3].pattern) {
+		t.Error("a quoted triple is a pattern only if it contains a variable")
+	}
+
+	// the blank nodes of the quoted triples are scoped like the other ones
+	scope := NewBlankNodeScope(NewBlankNodeGenerator("b"))
+	triple := scope.Triple(NewTriple(NewQuotedTriple(NewTriple(NewBlankNode("x"), knows, bob)), knows, NewBlankNode("x")))
+	if nested := triple.Subject.(QuotedTriple).Subject; nested != triple.Object {
+		t.Error("expected the blank node of the quoted triple to be", triple.Object, "but instead got", nested)
+	}
+	if Compare(quoted, alice) <= 0 || Compare(quoted, quoted) != 0 {
+		t.Error("the quoted triples should be sorted after the other nodes")
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IRIs of the XML Schema datatypes supported by the package
+const (
+	XSDNamespace          = "http://www.w3.org/2001/XMLSchema#"
+	XSDString             = XSDNamespace + "string"
+	XSDBoolean            = XSDNamespace + "boolean"
+	XSDDecimal            = XSDNamespace + "decimal"
+	XSDInteger            = XSDNamespace + "integer"
+	XSDLong               = XSDNamespace + "long"
+	XSDInt                = XSDNamespace + "int"
+	XSDShort              = XSDNamespace + "short"
+	XSDByte               = XSDNamespace + "byte"
+	XSDNonNegativeInteger = XSDNamespace + "nonNegativeInteger"
+	XSDPositiveInteger    = XSDNamespace + "positiveInteger"
+	XSDNonPositiveInteger = XSDNamespace + "nonPositiveInteger"
+	XSDNegativeInteger    = XSDNamespace + "negativeInteger"
+	XSDUnsignedLong       = XSDNamespace + "unsignedLong"
+	XSDUnsignedInt        = XSDNamespace + "unsignedInt"
+	XSDUnsignedShort      = XSDNamespace + "unsignedShort"
+	XSDUnsignedByte       = XSDNamespace + "unsignedByte"
+	XSDDouble             = XSDNamespace + "double"
+	XSDFloat              = XSDNamespace + "float"
+	XSDDateTime           = XSDNamespace + "dateTime"
+	XSDDate               = XSDNamespace + "date"
+	XSDTime               = XSDNamespace + "time"
+	XSDDuration           = XSDNamespace + "duration"
+)
+
+// Duration is the value of a xsd:duration: a number of months & a duration of days, hours, minutes and seconds.
+// Both parts have the same sign.
+type Duration struct {
+	Months  int64
+	DayTime time.Duration
+}
+
+// datatype describes how to read, write & compare the values of a XML Schema datatype.
+type datatype struct {
+	// kind groups the datatypes whose values can be compared to each other
+	kind      string
+	parse     func(lexical string) (interface{}, error)
+	canonical func(value interface{}) string
+}
+
+var (
+	decimalRegexp  = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+	integerRegexp  = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	doubleRegexp   = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([Ee][+-]?[0-9]+)?$`)
+	durationRegexp = regexp.MustCompile(`^(-)?P(?:([0-9]+)Y)?(?:([0-9]+)M)?(?:([0-9]+)D)?(?:T(?:([0-9]+)H)?(?:([0-9]+)M)?(?:([0-9]+(?:\.[0-9]+)?)S)?)?$`)
+	dateTimeRegexp = regexp.MustCompile(`^(-?[0-9]{4,})-([0-9]{2})-([0-9]{2})T([0-9]{2}):([0-9]{2}):([0-9]{2}(?:\.[0-9]+)?)(Z|[+-][0-9]{2}:[0-9]{2})?$`)
+	dateRegexp     = regexp.MustCompile(`^(-?[0-9]{4,})-([0-9]{2})-([0-9]{2})(Z|[+-][0-9]{2}:[0-9]{2})?$`)
+	timeRegexp     = regexp.MustCompile(`^([0-9]{2}):([0-9]{2}):([0-9]{2}(?:\.[0-9]+)?)(Z|[+-][0-9]{2}:[0-9]{2})?$`)
+	// noTimezone is the location of the dates & times which don't have a timezone
+	noTimezone = time.FixedZone("", 0)
+)
+
+// datatypes contains the XML Schema datatypes supported by the package, indexed by IRI
+var datatypes = map[string]datatype{
+	XSDString:             {"string", func(lexical string) (interface{}, error) { return lexical, nil }, func(value interface{}) string { return value.(string) }},
+	XSDBoolean:            {"boolean", parseBoolean, func(value interface{}) string { return strconv.FormatBool(value.(bool)) }},
+	XSDDecimal:            {"numeric", parseDecimal, func(value interface{}) string { return formatDecimal(value.(*big.Rat)) }},
+	XSDInteger:            integerDatatype(nil, nil),
+	XSDLong:               integerDatatype(big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)),
+	XSDInt:                integerDatatype(big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)),
+	XSDShort:              integerDatatype(big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16)),
+	XSDByte:               integerDatatype(big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8)),
+	XSDNonNegativeInteger: integerDatatype(big.NewInt(0), nil),
+	XSDPositiveInteger:    integerDatatype(big.NewInt(1), nil),
+	XSDNonPositiveInteger: integerDatatype(nil, big.NewInt(0)),
+	XSDNegativeInteger:    integerDatatype(nil, big.NewInt(-1)),
+	XSDUnsignedLong:       integerDatatype(big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)),
+	XSDUnsignedInt:        integerDatatype(big.NewInt(0), big.NewInt(math.MaxUint32)),
+	XSDUnsignedShort:      integerDatatype(big.NewInt(0), big.NewInt(math.MaxUint16)),
+	XSDUnsignedByte:       integerDatatype(big.NewInt(0), big.NewInt(math.MaxUint8)),
+	XSDDouble:             {"numeric", floatParser(64), func(value interface{}) string { return formatFloat(value.(float64), 64) }},
+	XSDFloat:              {"numeric", floatParser(32), func(value interface{}) string { return formatFloat(value.(float64), 32) }},
+	XSDDateTime:           {"dateTime", parseDateTime, func(value interface{}) string { return formatDateTime(value.(time.Time)) }},
+	XSDDate:               {"date", parseDate, func(value interface{}) string { return formatDate(value.(time.Time)) }},
+	XSDTime:               {"time", parseTime, func(value interface{}) string { return formatTime(value.(time.Time)) }},
+	XSDDuration:           {"duration", parseDuration, func(value interface{}) string { return formatDuration(value.(Duration)) }},
+}
+
+// parseBoolean reads the value of a xsd:boolean.
+func parseBoolean(lexical string) (interface{}, error) {
+	switch lexical {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	}
+	return nil, errors.New("Error : " + lexical + " is not a valid xsd:boolean")
+}
+
+// parseDecimal reads the value of a xsd:decimal.
+func parseDecimal(lexical string) (interface{}, error) {
+	if !decimalRegexp.MatchString(lexical) {
+		return nil, errors.New("Error : " + lexical + " is not a valid xsd:decimal")
+	}
+	value, _ := new(big.Rat).SetString(lexical)
+	return value, nil
+}
+
+// formatDecimal writes the canonical form of a xsd:decimal, as defined in XML Schema 1.1:
+// integers don't have a decimal point, and the other values don't have trailing zeros.
+func formatDecimal(value *big.Rat) string {
+	if value.IsInt() {
+		return value.Num().String()
+	}
+	// the denominator is a product of 2s & 5s, so the decimal expansion is finite
+	twos, fives := 0, 0
+	denom := new(big.Int).Set(value.Denom())
+	two, five, zero := big.NewInt(2), big.NewInt(5), big.NewInt(0)
+	for mod := new(big.Int); mod.Mod(denom, two).Cmp(zero) == 0; twos++ {
+		denom.Div(denom, two)
+	}
+	for mod := new(big.Int); mod.Mod(denom, five).Cmp(zero) == 0; fives++ {
+		denom.Div(denom, five)
+	}
+	digits := twos
+	if fives > digits {
+		digits = fives
+	}
+	return value.FloatString(digits)
+}
+
+// integerDatatype creates a datatype derived from xsd:integer, whose values are within optional bounds.
+func integerDatatype(min, max *big.Int) datatype {
+	parse := func(lexical string) (interface{}, error) {
+		if !integerRegexp.MatchString(lexical) {
+			return nil, errors.New("Error : " + lexical + " is not a valid integer")
+		}
+		value, _ := new(big.Int).SetString(strings.TrimPrefix(lexical, "+"), 10)
+		if (min != nil && value.Cmp(min) < 0) || (max != nil && value.Cmp(max) > 0) {
+			return nil, errors.New("Error : " + lexical + " is out of the range of the datatype")
+		}
+		return value, nil
+	}
+	return datatype{"numeric", parse, func(value interface{}) string { return value.(*big.Int).String() }}
+}
+
+// floatParser creates a function which reads the value of a xsd:double (64 bits) or a xsd:float (32 bits).
+func floatParser(bitSize int) func(string) (interface{}, error) {
+	return func(lexical string) (interface{}, error) {
+		switch lexical {
+		case "INF", "+INF":
+			return math.Inf(1), nil
+		case "-INF":
+			return math.Inf(-1), nil
+		case "NaN":
+			return math.NaN(), nil
+		}
+		if !doubleRegexp.MatchString(lexical) {
+			return nil, errors.New("Error : " + lexical + " is not a valid floating point number")
+		}
+		// out of range values are rounded to infinity, as in XML Schema 1.1
+		value, _ := strconv.ParseFloat(lexical, bitSize)
+		return value, nil
+	}
+}
+
+// formatFloat writes the canonical form of a xsd:double or a xsd:float, e.g. 1.5E2.
+func formatFloat(value float64, bitSize int) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "INF"
+	case math.IsInf(value, -1):
+		return "-INF"
+	}
+	formatted := strconv.FormatFloat(value, 'E', -1, bitSize)
+	parts := strings.SplitN(formatted, "E", 2)
+	mantissa, exponent := parts[0], parts[1]
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(exp)
+}
+
+// parseTimezone reads a timezone, e.g. Z or +01:00. An empty timezone means that the value has no timezone.
+func parseTimezone(timezone string) (*time.Location, error) {
+	switch timezone {
+	case "":
+		return noTimezone, nil
+	case "Z":
+		return time.UTC, nil
+	}
+	hours, _ := strconv.Atoi(timezone[1:3])
+	minutes, _ := strconv.Atoi(timezone[4:6])
+	if hours > 14 || minutes > 59 || (hours == 14 && minutes > 0) {
+		return nil, errors.New("Error : " + timezone + " is not a valid timezone")
+	}
+	offset := hours*3600 + minutes*60
+	if timezone[0] == '-' {
+		offset = -offset
+	}
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	return time.FixedZone(timezone, offset), nil
+}
+
+// formatTimezone writes the timezone of a date or a time.
+func formatTimezone(t time.Time) string {
+	if t.Location() == noTimezone {
+		return ""
+	}
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "Z"
+	}
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return sign + twoDigits(offset/3600) + ":" + twoDigits((offset%3600)/60)
+}
+
+// twoDigits writes a number with at least two digits.
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// buildTime creates a time from its lexical parts, and checks that each part is in its range.
+func buildTime(year, month, day, hour, minute, seconds, timezone string) (time.Time, error) {
+	parts := []string{year, month, day, hour, minute}
+	names := []string{"year", "month", "day", "hour", "minute"}
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, errors.New("Error : " + part + " is not a valid " + names[i])
+		}
+		values[i] = value
+	}
+	y, mo, d, h, mi := values[0], values[1], values[2], values[3], values[4]
+	s, err := strconv.ParseFloat(seconds, 64)
+	if err != nil {
+		return time.Time{}, errors.New("Error : " + seconds + " are not valid seconds")
+	}
+	location, err := parseTimezone(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// the date is checked before 24:00:00 is moved to the first instant of the next day
+	if mo < 1 || mo > 12 || d < 1 || time.Date(y, time.Month(mo), d, 0, 0, 0, 0, location).Day() != d {
+		return time.Time{}, errors.New("Error : invalid day of month")
+	}
+	endOfDay := h == 24 && mi == 0 && s == 0
+	if (h > 23 && !endOfDay) || mi > 59 || s >= 60 {
+		return time.Time{}, errors.New("Error : invalid date or time")
+	}
+	nanos := int(math.Round((s - math.Floor(s)) * 1e9))
+	return time.Date(y, time.Month(mo), d, h, mi, int(s), nanos, location), nil
+}
+
+// parseDateTime reads the value of a xsd:dateTime.
+func parseDateTime(lexical string) (interface{}, error) {
+	parts := dateTimeRegexp.FindStringSubmatch(lexical)
+	if parts == nil {
+		return nil, errors.New("Error : " + lexical + " is not a valid xsd:dateTime")
+	}
+	return buildTime(parts[1], parts[2], parts[3], parts[4], parts[5], parts[6], parts[7])
+}
+
+// formatSeconds writes the seconds & the fractional seconds of a time, without trailing zeros.
+func formatSeconds(t time.Time) string {
+	seconds := twoDigits(t.Second())
+	if t.Nanosecond() > 0 {
+		seconds += strings.TrimRight("."+strconv.Itoa(1e9 + t.Nanosecond())[1:], "0")
+	}
+	return seconds
+}
+
+// formatDateTime writes the canonical form of a xsd:dateTime. The times with a timezone are written in UTC.
+func formatDateTime(t time.Time) string {
+	if t.Location() != noTimezone {
+		t = t.UTC()
+	}
+	return t.Format("2006-01-02T15:04:") + formatSeconds(t) + formatTimezone(t)
+}
+
+// parseDate reads the value of a xsd:date, as the first instant of the day.
+func parseDate(lexical string) (interface{}, error) {
+	parts := dateRegexp.FindStringSubmatch(lexical)
+	if parts == nil {
+		return nil, errors.New("Error : " + lexical + " is not a valid xsd:date")
+	}
+	return buildTime(parts[1], parts[2], parts[3], "00", "00", "00", parts[4])
+}
+
+// formatDate writes the canonical form of a xsd:date.
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02") + formatTimezone(t)
+}
+
+// parseTime reads the value of a xsd:time, as a time of the 1st January 1972 (the reference date of XML Schema).
+func parseTime(lexical string) (interface{}, error) {
+	parts := timeRegexp.FindStringSubmatch(lexical)
+	if parts == nil {
+		return nil, errors.New("Error : " + lexical + " is not a valid xsd:time")
+	}
+	return buildTime("1972", "01", "01", parts[1], parts[2], parts[3], parts[4])
+}
+
+// formatTime writes the canonical form of a xsd:time.
+func formatTime(t time.Time) string {
+	if t.Location() != noTimezone {
+		t = t.UTC()
+	}
+	return t.Format("15:04:") + formatSeconds(t) + formatTimezone(t)
+}
+
+// parseDuration reads the value of a xsd:duration.
+func parseDuration(lexical string) (interface{}, error) {
+	parts := durationRegexp.FindStringSubmatch(lexical)
+	if parts == nil || lexical == "P" || lexical == "-P" || strings.HasSuffix(lexical, "T") {
+		return nil, errors.New("Error : " + lexical + " is not a valid xsd:duration")
+	}
+	// the components are summed with big numbers, so the durations which overflow are rejected instead of wrapping around
+	months, dayTime := new(big.Rat), new(big.Rat)
+	units := []struct {
+		total *big.Rat
+		unit  int64
+	}{{months, 12}, {months, 1}, {dayTime, int64(24 * time.Hour)}, {dayTime, int64(time.Hour)}, {dayTime, int64(time.Minute)}, {dayTime, int64(time.Second)}}
+	for i, u := range units {
+		if n, isNumber := new(big.Rat).SetString(parts[i+2]); isNumber {
+			u.total.Add(u.total, n.Mul(n, new(big.Rat).SetInt64(u.unit)))
+		}
+	}
+	// the fractions of nanoseconds are rounded
+	nanoseconds := new(big.Int).Quo(new(big.Int).Add(new(big.Int).Lsh(dayTime.Num(), 1), dayTime.Denom()), new(big.Int).Lsh(dayTime.Denom(), 1))
+	if !months.Num().IsInt64() || !nanoseconds.IsInt64() {
+		return nil, errors.New("Error : " + lexical + " is out of the range of the supported xsd:duration")
+	}
+	d := Duration{months.Num().Int64(), time.Duration(nanoseconds.Int64())}
+	if parts[1] == "-" {
+		d = Duration{-d.Months, -d.DayTime}
+	}
+	return d, nil
+}
+
+// formatDuration writes the canonical form of a xsd:duration, e.g. P1Y2M3DT4H5M6.5S.
+func formatDuration(d Duration) string {
+	sign := ""
+	if d.Months < 0 || d.DayTime < 0 {
+		sign, d = "-", Duration{-d.Months, -d.DayTime}
+	}
+	value := ""
+	if years := d.Months / 12; years > 0 {
+		value += strconv.FormatInt(years, 10) + "Y"
+	}
+	if months := d.Months % 12; months > 0 {
+		value += strconv.FormatInt(months, 10) + "M"
+	}
+	if days := d.DayTime / (24 * time.Hour); days > 0 {
+		value += strconv.FormatInt(int64(days), 10) + "D"
+	}
+	dayTime := d.DayTime % (24 * time.Hour)
+	if dayTime > 0 {
+		value += "T"
+		if hours := dayTime / time.Hour; hours > 0 {
+			value += strconv.FormatInt(int64(hours), 10) + "H"
+		}
+		if minutes := (dayTime % time.Hour) / time.Minute; minutes > 0 {
+			value += strconv.FormatInt(int64(minutes), 10) + "M"
+		}
+		if seconds := dayTime % time.Minute; seconds > 0 {
+			value += formatDecimal(new(big.Rat).SetFrac64(int64(seconds), int64(time.Second))) + "S"
+		}
+	}
+	if value == "" {
+		return "PT0S"
+	}
+	return sign + "P" + value
+}
+
+// NativeValue returns the value of a literal whose datatype is supported by the package, as a Go value.
+//
+// The values of integers are *big.Int, those of decimals *big.Rat, those of doubles & floats float64,
+// those of booleans bool, those of dates & times time.Time, and those of durations Duration.
+// Dates & times without timezone have a location without name nor offset.
+// An error is returned if the datatype isn't supported, or if the value isn't a valid lexical form of the datatype.
+func (l Literal) NativeValue() (interface{}, error) {
+	dt, supported := datatypes[l.Datatype()]
+	if !supported {
+		return nil, errors.New("Error : unsupported datatype " + l.Datatype())
+	}
+	return dt.parse(l.Value)
+}
+
+// Valid returns False if the literal has a supported datatype but its value isn't a valid lexical form of the datatype.
+func (l Literal) Valid() bool {
+	dt, supported := datatypes[l.Datatype()]
+	if !supported {
+		return true
+	}
+	_, err := dt.parse(l.Value)
+	return err == nil
+}
+
+// Canonical returns the literal with the canonical lexical form of its value, e.g. "01"^^xsd:integer becomes "1"^^xsd:integer.
+// The literals whose datatype isn't supported are returned unchanged.
+func (l Literal) Canonical() (Literal, error) {
+	dt, supported := datatypes[l.Datatype()]
+	if !supported {
+		return l, nil
+	}
+	value, err := dt.parse(l.Value)
+	if err != nil {
+		return l, err
+	}
+	return Literal{dt.canonical(value), l.Type, l.Lang, l.Dir}, nil
+}
+
+// compareNumbers compares two numeric values, promoting integers & decimals to doubles when needed.
+func compareNumbers(a, b interface{}) (int, error) {
+	toRat := func(value interface{}) *big.Rat {
+		switch v := value.(type) {
+		case *big.Int:
+			return new(big.Rat).SetInt(v)
+		case *big.Rat:
+			return v
+		}
+		return nil
+	}
+	ratA, ratB := toRat(a), toRat(b)
+	if ratA != nil && ratB != nil {
+		return ratA.Cmp(ratB), nil
+	}
+	toFloat := func(value interface{}) float64 {
+		if rat := toRat(value); rat != nil {
+			f, _ := rat.Float64()
+			return f
+		}
+		return value.(float64)
+	}
+	floatA, floatB := toFloat(a), toFloat(b)
+	switch {
+	case math.IsNaN(floatA) || math.IsNaN(floatB):
+		return 0, errors.New("Error : NaN cannot be compared")
+	case floatA < floatB:
+		return -1, nil
+	case floatA > floatB:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// compareTimes compares two dates or times. The values without timezone are compared as if they were in UTC.
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// compareDurations compares two durations. Some durations cannot be compared, e.g. P1M & P30D.
+func compareDurations(a, b Duration) (int, error) {
+	sign := func(n int64) int {
+		switch {
+		case n < 0:
+			return -1
+		case n > 0:
+			return 1
+		}
+		return 0
+	}
+	months, dayTime := sign(a.Months-b.Months), sign(int64(a.DayTime-b.DayTime))
+	switch {
+	case months == 0:
+		return dayTime, nil
+	case dayTime == 0 || dayTime == months:
+		return months, nil
+	}
+	return 0, errors.New("Error : the durations cannot be compared")
+}
+
+// CompareValues compares the values of two literals, and returns -1, 0 or 1 if the first is lower than, equal to or greater than the second one.
+//
+// Numbers are compared with each other whatever their datatypes, as strings, booleans, dates, times & durations.
+// An error is returned if the literals have unsupported datatypes, invalid values or values which cannot be compared.
+func CompareValues(a, b Literal) (int, error) {
+	dtA, supportedA := datatypes[a.Datatype()]
+	dtB, supportedB := datatypes[b.Datatype()]
+	if !supportedA || !supportedB {
+		return 0, errors.New("Error : cannot compare literals with unsupported datatypes")
+	} else if dtA.kind != dtB.kind {
+		return 0, errors.New("Error : cannot compare a literal of type " + a.Datatype() + " with a literal of type " + b.Datatype())
+	}
+	valueA, err := dtA.parse(a.Value)
+	if err != nil {
+		return 0, err
+	}
+	valueB, err := dtB.parse(b.Value)
+	if err != nil {
+		return 0, err
+	}
+	switch dtA.kind {
+	case "numeric":
+		return compareNumbers(valueA, valueB)
+	case "string":
+		return strings.Compare(valueA.(string), valueB.(string)), nil
+	case "boolean":
+		return compareBools(valueA.(bool), valueB.(bool)), nil
+	case "duration":
+		return compareDurations(valueA.(Duration), valueB.(Duration))
+	}
+	return compareTimes(valueA.(time.Time), valueB.(time.Time)), nil
+}
+
+// EqualsValue compares a literal with another RDF node by value, and returns True if they are equals, False otherwise.
+//
+// Unlike Equals, the literals with a supported datatype are equal if they have the same value, e.g. "1"^^xsd:integer & "01"^^xsd:integer,
+// or "1"^^xsd:integer & "1.0"^^xsd:double. Literals with a language are equal if their languages are equal, ignoring case,
+// and if they have the same base direction.
+// The other literals are compared by their lexical forms.
+func (l Literal) EqualsValue(n Node) (bool, error) {
+	other, ok := n.(Literal)
+	if !ok {
+		return l.Equals(n)
+	}
+	if l.Lang != "" || other.Lang != "" {
+		return l.Value == other.Value && strings.EqualFold(l.Lang, other.Lang) && l.Dir == other.Dir, nil
+	}
+	if cmp, err := CompareValues(l, other); err == nil {
+		return cmp == 0, nil
+	}
+	return l.Value == other.Value && l.Datatype() == other.Datatype(), nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLiteralCanonical(t *testing.T) {
+	datas := [][]string{
+		{XSDInteger, "+0012", "12"},
+		{XSDInteger, "-0", "0"},
+		{XSDDecimal, "01.500", "1.5"},
+		{XSDDecimal, "+3.0", "3"},
+		{XSDDecimal, "-.125", "-0.125"},
+		{XSDDouble, "100", "1.0E2"},
+		{XSDDouble, "1.5e-3", "1.5E-3"},
+		{XSDDouble, "-0", "-0.0E0"},
+		{XSDDouble, "+INF", "INF"},
+		{XSDFloat, "0.1", "1.0E-1"},
+		{XSDBoolean, "1", "true"},
+		{XSDBoolean, "false", "false"},
+		{XSDDateTime, "2016-04-05T10:20:30.500+02:00", "2016-04-05T08:20:30.5Z"},
+		{XSDDateTime, "2016-12-31T24:00:00", "2017-01-01T00:00:00"},
+		{XSDDate, "2016-02-29-05:00", "2016-02-29-05:00"},
+		{XSDTime, "23:30:00+00:00", "23:30:00Z"},
+		{XSDDuration, "P0Y14M1DT25H", "P1Y2M2DT1H"},
+		{XSDDuration, "-PT90.50S", "-PT1M30.5S"},
+		{XSDDuration, "P0D", "PT0S"},
+		{XSDDuration, "P106751DT23H47M16.854775807S", "P106751DT23H47M16.854775807S"},
+		{"<" + XSDShort + ">", "0042", "42"},
+		{"http://example.org/unknown", "0042", "0042"},
+	}
+	for _, data := range datas {
+		literal, err := NewTypedLiteral(data[1], data[0]).Canonical()
+		if err != nil {
+			t.Error("unexpected error for", data, ":", err)
+		} else if literal.Value != data[2] || literal.Type != strings.Trim(data[0], "<>") {
+			t.Error("expected the canonical form of", data[1], "to be", data[2], "but instead got", literal.Value)
+		}
+	}
+}
+
+func TestLiteralValid(t *testing.T) {
+	invalids := [][]string{
+		{XSDInteger, "1.0"},
+		{XSDInteger, ""},
+		{XSDDecimal, "1e5"},
+		{XSDDouble, "inf"},
+		{XSDBoolean, "TRUE"},
+		{XSDByte, "128"},
+		{XSDNonNegativeInteger, "-1"},
+		{XSDUnsignedLong, "18446744073709551616"},
+		{XSDDateTime, "2016-02-30T00:00:00"},
+		{XSDDateTime, "2023-02-30T24:00:00"},
+		{XSDDateTime, "99999999999999999999-01-01T00:00:00"},
+		{XSDDateTime, "2016-04-05 10:20:30"},
+		{XSDDate, "2016-13-01"},
+		{XSDTime, "25:00:00"},
+		{XSDDuration, "P"},
+		{XSDDuration, "P1DT"},
+		{XSDDuration, "P1S"},
+		{XSDDuration, "P200000D"},
+		{XSDDuration, "P999999999999999999999Y"},
+		{XSDDuration, "-PT9223372036.854775808S"},
+	}
+	for _, data := range invalids {
+		literal := NewTypedLiteral(data[1], data[0])
+		if literal.Valid() {
+			t.Error("expected", literal, "to be invalid")
+		}
+		if _, err := literal.Canonical(); err == nil {
+			t.Error("expected an error when computing the canonical form of", literal)
+		}
+	}
+	expectedMsg := "Error : 99999999999999999999 is not a valid year"
+	if _, err := NewTypedLiteral("99999999999999999999-01-01", XSDDate).NativeValue(); err == nil || err.Error() != expectedMsg {
+		t.Error("expected the error", expectedMsg, "but instead got", err)
+	}
+	if !NewTypedLiteral("whatever", "http://example.org/unknown").Valid() || !NewLiteral("whatever").Valid() {
+		t.Error("expected the literals without a supported datatype to be valid")
+	}
+}
+
+func TestLiteralNativeValue(t *testing.T) {
+	if value, err := NewTypedLiteral("-0042", XSDInteger).NativeValue(); err != nil || value.(*big.Int).Int64() != -42 {
+		t.Error("expected the value of \"-0042\"^^xsd:integer to be -42 but instead got", value, err)
+	}
+	if value, err := NewTypedLiteral("2.25", XSDDecimal).NativeValue(); err != nil || value.(*big.Rat).Cmp(big.NewRat(9, 4)) != 0 {
+		t.Error("expected the value of \"2.25\"^^xsd:decimal to be 9/4 but instead got", value, err)
+	}
+	if value, err := NewTypedLiteral("true", XSDBoolean).NativeValue(); err != nil || !value.(bool) {
+		t.Error("expected the value of \"true\"^^xsd:boolean to be true but instead got", value, err)
+	}
+	expected := time.Date(2016, time.April, 5, 8, 20, 30, 0, time.UTC)
+	if value, err := NewTypedLiteral("2016-04-05T10:20:30+02:00", XSDDateTime).NativeValue(); err != nil || !value.(time.Time).Equal(expected) {
+		t.Error("expected the value of the xsd:dateTime to be", expected, "but instead got", value, err)
+	}
+	if value, err := NewTypedLiteral("P1Y2DT3S", XSDDuration).NativeValue(); err != nil || value.(Duration) != (Duration{12, 48*time.Hour + 3*time.Second}) {
+		t.Error("expected the value of the xsd:duration to be 12 months, 2 days & 3 seconds but instead got", value, err)
+	}
+	if value, err := NewLiteral("hello").NativeValue(); err != nil || value.(string) != "hello" {
+		t.Error("expected the value of a simple literal to be its string but instead got", value, err)
+	}
+	if _, err := NewTypedLiteral("1", "http://example.org/unknown").NativeValue(); err == nil {
+		t.Error("expected an error when reading the value of a literal with an unsupported datatype")
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	datas := []struct {
+		a, b     Literal
+		expected int
+	}{
+		{NewTypedLiteral("2", XSDInteger), NewTypedLiteral("10", XSDInteger), -1},
+		{NewTypedLiteral("1", XSDInteger), NewTypedLiteral("1.0", XSDDecimal), 0},
+		{NewTypedLiteral("1.5", XSDDecimal), NewTypedLiteral("1.0E0", XSDDouble), 1},
+		{NewTypedLiteral("-INF", XSDDouble), NewTypedLiteral("-100", XSDByte), -1},
+		{NewLiteral("abc"), NewTypedLiteral("abd", XSDString), -1},
+		{NewTypedLiteral("true", XSDBoolean), NewTypedLiteral("0", XSDBoolean), 1},
+		{NewTypedLiteral("2016-04-05T10:00:00+02:00", XSDDateTime), NewTypedLiteral("2016-04-05T08:00:00Z", XSDDateTime), 0},
+		{NewTypedLiteral("2016-04-05", XSDDate), NewTypedLiteral("2016-04-06", XSDDate), -1},
+		{NewTypedLiteral("PT36H", XSDDuration), NewTypedLiteral("P1D", XSDDuration), 1},
+		{NewTypedLiteral("P1Y", XSDDuration), NewTypedLiteral("P12M", XSDDuration), 0},
+	}
+	for _, data := range datas {
+		if cmp, err := CompareValues(data.a, data.b); err != nil || cmp != data.expected {
+			t.Error("expected the comparison of", data.a, "and", data.b, "to be", data.expected, "but instead got", cmp, err)
+		}
+		if cmp, err := CompareValues(data.b, data.a); err != nil || cmp != -data.expected {
+			t.Error("expected the comparison of", data.b, "and", data.a, "to be", -data.expected, "but instead got", cmp, err)
+		}
+	}
+
+	incomparables := [][]Literal{
+		{NewTypedLiteral("1", XSDInteger), NewLiteral("1")},
+		{NewTypedLiteral("2016-04-05", XSDDate), NewTypedLiteral("2016-04-05T00:00:00", XSDDateTime)},
+		{NewTypedLiteral("P1M", XSDDuration), NewTypedLiteral("P30D", XSDDuration)},
+		{NewTypedLiteral("NaN", XSDDouble), NewTypedLiteral("NaN", XSDDouble)},
+		{NewTypedLiteral("a", XSDInteger), NewTypedLiteral("1", XSDInteger)},
+		{NewTypedLiteral("1", "http://example.org/unknown"), NewTypedLiteral("1", "http://example.org/unknown")},
+	}
+	for _, data := range incomparables {
+		if _, err := CompareValues(data[0], data[1]); err == nil {
+			t.Error("expected an error when comparing", data[0], "and", data[1])
+		}
+	}
+}
+
+func TestLiteralEqualsValue(t *testing.T) {
+	equals := [][]Node{
+		{NewTypedLiteral("1", XSDInteger), NewTypedLiteral("01", XSDInteger)},
+		{NewTypedLiteral("1", XSDInteger), NewTypedLiteral("1.0E0", XSDDouble)},
+		{NewTypedLiteral("1", "<"+XSDBoolean+">"), NewTypedLiteral("true", XSDBoolean)},
+		{NewLiteral("Thomas"), NewTypedLiteral("Thomas", XSDString)},
+		{NewLangLiteral("chat", "fr-FR"), NewLangLiteral("chat", "fr-fr")},
+		{NewTypedLiteral("x", "http://example.org/unknown"), NewTypedLiteral("x", "http://example.org/unknown")},
+		{NewTypedLiteral("1", XSDInteger), NewVariable("x")},
+	}
+	for _, data := range equals {
+		if test, err := data[0].(Literal).EqualsValue(data[1]); !test || err != nil {
+			t.Error("expected", data[0], "to be equal to", data[1])
+		}
+	}
+
+	differents := [][]Node{
+		{NewTypedLiteral("1", XSDInteger), NewTypedLiteral("2", XSDInteger)},
+		{NewTypedLiteral("1", XSDInteger), NewLiteral("1")},
+		{NewLangLiteral("chat", "fr"), NewLangLiteral("chat", "en")},
+		{NewLangLiteral("chat", "fr"), NewLiteral("chat")},
+		{NewTypedLiteral("x", "http://example.org/a"), NewTypedLiteral("x", "http://example.org/b")},
+		{NewTypedLiteral("1", XSDInteger), NewURI("http://example.org/1")},
+	}
+	for _, data := range differents {
+		if test, _ := data[0].(Literal).EqualsValue(data[1]); test {
+			t.Error("expected", data[0], "to be different from", data[1])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"encoding/json"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/jsonld"
+	"io"
+)
+
+// JSONLDSerializer is a serializer for writing RDF Graphs in JSON-LD format.
+//
+// The triples of the graph are converted into JSON-LD, then compacted using a context,
+// and optionally framed to shape the nodes as a tree.
+//
+// JSON-LD reference : https://www.w3.org/TR/json-ld11/
+type JSONLDSerializer struct {
+	context interface{}
+	frame   map[string]interface{}
+	loader  jsonld.DocumentLoader
+}
+
+// NewJSONLDSerializer creates a new JSONLDSerializer
+func NewJSONLDSerializer() *JSONLDSerializer {
+	return &JSONLDSerializer{}
+}
+
+// SetContext sets the context used to compact the documents, either as a map, an array or the IRI of a remote context.
+// By default, the prefixes of the graph are used as the context.
+func (s *JSONLDSerializer) SetContext(context interface{}) {
+	s.context = context
+}
+
+// SetFrame sets the frame used to shape the documents. The context of the frame is then used to compact them.
+func (s *JSONLDSerializer) SetFrame(frame map[string]interface{}) {
+	s.frame = frame
+}
+
+// SetDocumentLoader sets the loader used to retrieve the remote contexts.
+// By default, only contexts stored in local files can be loaded.
+func (s *JSONLDSerializer) SetDocumentLoader(loader jsonld.DocumentLoader) {
+	s.loader = loader
+}
+
+// graphContext builds a context using the prefixes of a graph.
+func graphContext(g graph.Graph) map[string]interface{} {
+	context := make(map[string]interface{})
+	for name, value := range graphPrefixes(g) {
+		if name != "" {
+			context[name] = value
+		}
+	}
+	return context
+}
+
+// Write serializes a RDF Graph in JSON-LD format
+func (s *JSONLDSerializer) Write(out io.Writer, g graph.Graph) error {
+	expanded, err := jsonld.FromRDF(collectTriples(g))
+	if err != nil {
+		return err
+	}
+	options := &jsonld.Options{DocumentLoader: s.loader}
+	var doc map[string]interface{}
+	if s.frame != nil {
+		doc, err = jsonld.Frame(expanded, s.frame, options)
+	} else if s.context != nil {
+		doc, err = jsonld.Compact(expanded, s.context, options)
+	} else {
+		doc, err = jsonld.Compact(expanded, graphContext(g), options)
+	}
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/parser"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// libraryGraph creates a small graph describing a library, for testing purpose
+func libraryGraph() graph.Graph {
+	g := graph.NewListGraph()
+	library := rdf.NewURI("http://example.org/library")
+	book := rdf.NewURI("http://example.org/library/the-republic")
+	chapter := rdf.NewBlankNode("chapter")
+	g.Add(rdf.NewTriple(library, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI("http://example.org/vocab#Library")))
+	g.Add(rdf.NewTriple(library, rdf.NewURI("http://example.org/vocab#contains"), book))
+	g.Add(rdf.NewTriple(book, rdf.NewURI(rdfNamespace+"type"), rdf.NewURI("http://example.org/vocab#Book")))
+	g.Add(rdf.NewTriple(book, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLangLiteral("The Republic", "en")))
+	g.Add(rdf.NewTriple(book, rdf.NewURI("http://example.org/vocab#contains"), chapter))
+	g.Add(rdf.NewTriple(chapter, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("The Introduction")))
+	return g
+}
+
+// decodeJSON parses a JSON string, for testing purpose
+func decodeJSON(input string, t *testing.T) interface{} {
+	var doc interface{}
+	if err := json.NewDecoder(strings.NewReader(input)).Decode(&doc); err != nil {
+		t.Fatal("cannot parse the JSON document", input, ":", err)
+	}
+	return doc
+}
+
+func TestWriteJSONLDSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	serializer := NewJSONLDSerializer()
+	serializer.SetContext(decodeJSON(`{
+		"dc": "http://purl.org/dc/terms/",
+		"ex": "http://example.org/vocab#",
+		"contains": {"@id": "ex:contains", "@type": "@id"},
+		"title": {"@id": "dc:title", "@language": "en"}
+	}`, t))
+	expected := `{
+  "@context": {
+    "contains": {
+      "@id": "ex:contains",
+      "@type": "@id"
+    },
+    "dc": "http://purl.org/dc/terms/",
+    "ex": "http://example.org/vocab#",
+    "title": {
+      "@id": "dc:title",
+      "@language": "en"
+    }
+  },
+  "@graph": [
+    {
+      "@id": "_:chapter",
+      "dc:title": "The Introduction"
+    },
+    {
+      "@id": "http://example.org/library",
+      "@type": "ex:Library",
+      "contains": "http://example.org/library/the-republic"
+    },
+    {
+      "@id": "http://example.org/library/the-republic",
+      "@type": "ex:Book",
+      "contains": "_:chapter",
+      "title": "The Republic"
+    }
+  ]
+}
+`
+
+	if err := serializer.Write(&buf, libraryGraph()); err != nil {
+		t.Error("serializing a valid graph in JSON-LD shouldn't produce the error :", err)
+	}
+	if buf.String() != expected {
+		t.Error("expected the JSON-LD document\n", expected, "\nbut instead got\n", buf.String())
+	}
+}
+
+func TestWriteFrameJSONLDSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	serializer := NewJSONLDSerializer()
+	serializer.SetFrame(decodeJSON(`{
+		"@context": {"dc": "http://purl.org/dc/terms/", "ex": "http://example.org/vocab#"},
+		"@type": "ex:Library",
+		"ex:contains": {"@type": "ex:Book", "ex:contains": {}}
+	}`, t).(map[string]interface{}))
+	expected := `{
+		"@context": {"dc": "http://purl.org/dc/terms/", "ex": "http://example.org/vocab#"},
+		"@id": "http://example.org/library",
+		"@type": "ex:Library",
+		"ex:contains": {
+			"@id": "http://example.org/library/the-republic",
+			"@type": "ex:Book",
+			"dc:title": {"@language": "en", "@value": "The Republic"},
+			"ex:contains": {"dc:title": "The Introduction"}
+		}
+	}`
+
+	if err := serializer.Write(&buf, libraryGraph()); err != nil {
+		t.Error("serializing a valid graph in JSON-LD shouldn't produce the error :", err)
+	}
+	if result := decodeJSON(buf.String(), t); !reflect.DeepEqual(result, decodeJSON(expected, t)) {
+		t.Error("expected the framed JSON-LD document\n", expected, "\nbut instead got\n", buf.String())
+	}
+}
+
+func TestReadWriteJSONLDSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := libraryGraph()
+	if err := NewJSONLDSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in JSON-LD shouldn't produce the error :", err)
+	}
+
+	// read back the document and check that the triples are the same
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "graph.jsonld")
+	ioutil.WriteFile(filename, buf.Bytes(), 0644)
+	expected, cpt := 0, 0
+	for range g.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		expected++
+	}
+	for triple := range parser.NewJSONLDParser().Read(filename) {
+		cpt++
+		// blank nodes are relabelled when reading the document
+		_, blankSubject := triple.Subject.(rdf.BlankNode)
+		_, blankObject := triple.Object.(rdf.BlankNode)
+		if blankSubject || blankObject {
+			continue
+		}
+		found := false
+		for result := range g.Filter(triple.Subject, triple.Predicate, triple.Object) {
+			if test, err := result.Equals(triple); test && err == nil {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("the triple", triple, "read in the serialized graph isn't in the original graph")
+		}
+	}
+	if cpt != expected {
+		t.Error("expected", expected, "triples in the serialized graph but instead got", cpt)
+	}
+}
+
+func TestWriteErrorsJSONLDSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.Add(rdf.NewTriple(rdf.NewLiteral("22"), rdf.NewURI("http://example.org/age"), rdf.NewLiteral("22")))
+	if err := NewJSONLDSerializer().Write(&buf, g); err == nil {
+		t.Error("serializing a triple with a literal as subject in JSON-LD should produce an error")
+	}
+
+	serializer := NewJSONLDSerializer()
+	serializer.SetContext("http://example.org/remote/context.jsonld")
+	if err := serializer.Write(&buf, libraryGraph()); err == nil {
+		t.Error("serializing with a context which cannot be loaded should produce an error")
+	}
+}
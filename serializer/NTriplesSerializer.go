@@ -0,0 +1,96 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bufio"
+	"errors"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"strings"
+)
+
+// literalEscaper escapes the characters which cannot appear in the strings of N-Triples & Turtle documents
+var literalEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r")
+
+// NTriplesSerializer is a serializer for writing RDF Graphs in N-Triples format.
+// The quoted triples are written using the N-Triples-star syntax, e.g. << <a> <b> <c> >> <source> <d> .
+//
+// N-Triples reference : https://www.w3.org/TR/n-triples/
+type NTriplesSerializer struct{}
+
+// NewNTriplesSerializer creates a new NTriplesSerializer
+func NewNTriplesSerializer() *NTriplesSerializer {
+	return &NTriplesSerializer{}
+}
+
+// termWriter converts a RDF node into a term of a document.
+type termWriter func(node rdf.Node) (string, error)
+
+// writeTerm converts a RDF node into a term of a N-Triples or Turtle document,
+// using a function to write the IRIs, which are also used for the nodes of the quoted triples.
+func writeTerm(node rdf.Node, iri func(value string) string) (string, error) {
+	switch n := node.(type) {
+	case rdf.URI:
+		return iri(n.Value), nil
+	case rdf.BlankNode:
+		return "_:" + n.Value, nil
+	case rdf.Literal:
+		term := "\"" + literalEscaper.Replace(n.Value) + "\""
+		if n.Lang != "" && n.Dir != "" {
+			term += "@" + n.Lang + "--" + n.Dir
+		} else if n.Lang != "" {
+			term += "@" + n.Lang
+		} else if n.Datatype() != rdf.XSDString {
+			term += "^^<" + n.Datatype() + ">"
+		}
+		return term, nil
+	case rdf.QuotedTriple:
+		term := "<<"
+		for _, nested := range []rdf.Node{n.Subject, n.Predicate, n.Object} {
+			value, err := writeTerm(nested, iri)
+			if err != nil {
+				return "", err
+			}
+			term += " " + value
+		}
+		return term + " >>", nil
+	}
+	return "", errors.New("Error : cannot serialize the node " + node.String())
+}
+
+// ntriplesTerm converts a RDF node into a term of a N-Triples document.
+func ntriplesTerm(node rdf.Node) (string, error) {
+	return writeTerm(node, func(value string) string {
+		return "<" + value + ">"
+	})
+}
+
+// writeTriple converts a triple into a list of terms.
+func writeTriple(triple rdf.Triple, term termWriter) ([]string, error) {
+	terms := make([]string, 0, 3)
+	for _, node := range []rdf.Node{triple.Subject, triple.Predicate, triple.Object} {
+		value, err := term(node)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, value)
+	}
+	return terms, nil
+}
+
+// Write serializes a RDF Graph in N-Triples format, with one triple per line.
+func (s NTriplesSerializer) Write(out io.Writer, g graph.Graph) error {
+	w := bufio.NewWriter(out)
+	for _, triple := range collectTriples(g) {
+		terms, err := writeTriple(triple, ntriplesTerm)
+		if err != nil {
+			return err
+		}
+		w.WriteString(strings.Join(terms, " ") + " .\n")
+	}
+	return w.Flush()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bytes"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/parser"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readBack writes a serialized graph into a file, then reads it with a parser & checks that the triples are the same
+func readBack(t *testing.T, g graph.Graph, p parser.Parser, document []byte, expected int) {
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "graph")
+	ioutil.WriteFile(filename, document, 0644)
+	cpt := 0
+	for triple := range p.Read(filename) {
+		found := false
+		for result := range g.Filter(triple.Subject, triple.Predicate, triple.Object) {
+			if test, err := result.Equals(triple); test && err == nil {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("the triple", triple, "read in the serialized graph isn't in the original graph")
+		}
+		cpt++
+	}
+	if cpt != expected {
+		t.Error("expected", expected, "triples in the serialized graph but instead got", cpt)
+	}
+}
+
+// escapedGraph creates a graph whose literals contain characters escaped by the serializers.
+func escapedGraph() graph.Graph {
+	g := graph.NewListGraph()
+	alice := rdf.NewURI("http://example.org/alice")
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://example.org/bio"), rdf.NewLiteral("line1\nline2\r\n")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://xmlns.com/foaf/0.1/name"), rdf.NewLangLiteral("Alice \"Al\"", "en")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://example.org/path"), rdf.NewLiteral("C:\\Users\\alice\\")))
+	return g
+}
+
+// roundTrip serializes a graph, then loads the document in a new graph & checks that both graphs are isomorphic
+func roundTrip(t *testing.T, g graph.Graph, s Serializer, format string) {
+	var buf bytes.Buffer
+	if err := s.Write(&buf, g); err != nil {
+		t.Fatal("serializing a valid graph shouldn't produce the error :", err)
+	}
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "graph")
+	ioutil.WriteFile(filename, buf.Bytes(), 0644)
+	result := graph.NewListGraph()
+	if err := result.LoadFromFile(filename, format); err != nil {
+		t.Fatal("reading back the serialized graph shouldn't produce the error :", err)
+	}
+	if !graph.Isomorphic(g, result) {
+		t.Error("the graph read back isn't isomorphic to the serialized graph :\n", buf.String())
+	}
+}
+
+func TestWriteNTriplesSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	alice, knows := rdf.NewURI("http://example.org/alice"), rdf.NewURI("http://xmlns.com/foaf/0.1/knows")
+	g.Add(rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(alice, knows, rdf.NewBlankNode("b0"))), rdf.NewURI("http://example.org/source"), rdf.NewLiteral("facebook")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://xmlns.com/foaf/0.1/name"), rdf.NewLangLiteral("Alice \"Al\"", "en")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://xmlns.com/foaf/0.1/age"), rdf.NewTypedLiteral("22", "<http://www.w3.org/2001/XMLSchema#integer>")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://xmlns.com/foaf/0.1/nick"), rdf.NewDirLangLiteral("Al", "ar", "rtl")))
+	g.Add(rdf.NewTriple(alice, rdf.NewURI("http://xmlns.com/foaf/0.1/title"), rdf.Literal{Value: "Dr", Type: rdf.XSDString}))
+	expected := `<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "22"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice \"Al\""@en .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/nick> "Al"@ar--rtl .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/title> "Dr" .
+<< <http://example.org/alice> <http://xmlns.com/foaf/0.1/knows> _:b0 >> <http://example.org/source> "facebook" .
+`
+	if err := NewNTriplesSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in N-Triples shouldn't produce the error :", err)
+	}
+	if buf.String() != expected {
+		t.Error("expected the N-Triples document\n", expected, "\nbut instead got\n", buf.String())
+	}
+}
+
+func TestReadWriteNTriplesSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.LoadFromFile("../parser/datas/quoted.nt", "nt")
+	if err := NewNTriplesSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in N-Triples shouldn't produce the error :", err)
+	}
+	readBack(t, g, parser.NewNTParser(), buf.Bytes(), 3)
+	roundTrip(t, escapedGraph(), NewNTriplesSerializer(), "nt")
+}
+
+func TestWriteErrorsNTriplesSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.Add(rdf.NewTriple(rdf.NewURI("http://example.org/foo"), rdf.NewURI("http://example.org/bar"), rdf.NewVariable("v")))
+	if err := NewNTriplesSerializer().Write(&buf, g); err == nil {
+		t.Error("serializing a variable in N-Triples should produce an error")
+	}
+}
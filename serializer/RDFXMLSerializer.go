@@ -0,0 +1,227 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Namespace of the RDF vocabulary
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	// Namespace of the its:dir attribute, used to write the base direction of the literals
+	itsNamespace = "http://www.w3.org/2005/11/its"
+)
+
+// RDFXMLSerializer is a serializer for writing RDF Graphs in RDF/XML format.
+//
+// RDF/XML reference : https://www.w3.org/TR/rdf-syntax-grammar/
+type RDFXMLSerializer struct{}
+
+// NewRDFXMLSerializer creates a new RDFXMLSerializer
+func NewRDFXMLSerializer() *RDFXMLSerializer {
+	return &RDFXMLSerializer{}
+}
+
+// namespaces associates XML namespaces to the prefixes used to write QNames.
+type namespaces struct {
+	prefixes *rdf.PrefixMap
+	nextID   int
+}
+
+// newNamespaces creates a new set of namespaces, with the rdf prefix & the prefixes given in parameters.
+func newNamespaces(prefixes map[string]string) *namespaces {
+	ns := &namespaces{rdf.NewPrefixMap(), 0}
+	ns.prefixes.Set("rdf", rdfNamespace)
+	names := make([]string, 0, len(prefixes))
+	for name := range prefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := prefixes[name]
+		// the default namespace can't be used to prefix attributes, so it's ignored
+		if name == "" || !isNCName(name) || strings.HasPrefix(strings.ToLower(name), "xml") {
+			continue
+		}
+		// a namespace is only declared once, and the prefixes which conflict with the rdf prefix are ignored
+		if _, declared := ns.prefixes.Prefix(value); !declared {
+			ns.prefixes.Bind(name, value)
+		}
+	}
+	return ns
+}
+
+// prefix returns the prefix of a namespace, generating a new prefix if the namespace is unknown.
+func (ns *namespaces) prefix(namespace string) string {
+	prefix, declared := ns.prefixes.Prefix(namespace)
+	if !declared {
+		for {
+			prefix = "ns" + strconv.Itoa(ns.nextID)
+			ns.nextID++
+			if _, used := ns.prefixes.Namespace(prefix); !used {
+				break
+			}
+		}
+		ns.prefixes.Set(prefix, namespace)
+	}
+	return prefix
+}
+
+// qname converts an URI into a QName, generating a new prefix if its namespace is unknown.
+func (ns *namespaces) qname(uri string) (string, error) {
+	namespace, local := splitURI(uri)
+	if local == "" {
+		return "", errors.New("Error : cannot convert <" + uri + "> into a XML QName")
+	}
+	return ns.prefix(namespace) + ":" + local, nil
+}
+
+// isNCNameStart returns True if a rune can start a XML NCName.
+func isNCNameStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isNCNameChar returns True if a rune can be part of a XML NCName.
+func isNCNameChar(r rune) bool {
+	return isNCNameStart(r) || r == '-' || r == '.' || unicode.IsDigit(r)
+}
+
+// isNCName returns True if a string is a valid XML NCName.
+func isNCName(value string) bool {
+	for i, r := range value {
+		if (i == 0 && !isNCNameStart(r)) || !isNCNameChar(r) {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// splitURI splits an URI into a namespace and the longest local name allowed in a XML QName.
+func splitURI(uri string) (namespace, local string) {
+	runes := []rune(uri)
+	start := len(runes)
+	for start > 0 && isNCNameChar(runes[start-1]) {
+		start--
+	}
+	// the local name must start with a letter or an underscore
+	for start < len(runes) && !isNCNameStart(runes[start]) {
+		start++
+	}
+	return string(runes[:start]), string(runes[start:])
+}
+
+// escape returns a string with the XML special characters escaped.
+func escape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// nodeAttr returns the RDF/XML attribute used to reference a resource, as the subject or the object of a triple.
+func nodeAttr(node rdf.Node, attr string) (string, error) {
+	switch n := node.(type) {
+	case rdf.URI:
+		return "rdf:" + attr + "=\"" + escape(n.Value) + "\"", nil
+	case rdf.BlankNode:
+		return "rdf:nodeID=\"" + escape(n.Value) + "\"", nil
+	}
+	return "", errors.New("Error : cannot serialize " + node.String() + " in RDF/XML")
+}
+
+// propertyElement returns the RDF/XML property element for the predicate & the object of a triple.
+func propertyElement(ns *namespaces, triple rdf.Triple) (string, error) {
+	predicate, isURI := triple.Predicate.(rdf.URI)
+	if !isURI {
+		return "", errors.New("Error : the predicate " + triple.Predicate.String() + " must be a URI to be serialized in RDF/XML")
+	}
+	name, err := ns.qname(predicate.Value)
+	if err != nil {
+		return "", err
+	}
+	literal, isLiteral := triple.Object.(rdf.Literal)
+	if !isLiteral {
+		attr, err := nodeAttr(triple.Object, "resource")
+		if err != nil {
+			return "", err
+		}
+		return "<" + name + " " + attr + "/>", nil
+	}
+	switch {
+	case literal.Lang != "" && literal.Dir != "":
+		// the its:version attribute is required by RDF/XML 1.2 to use the its:dir attribute
+		its := ns.prefix(itsNamespace)
+		return "<" + name + " xml:lang=\"" + escape(literal.Lang) + "\" " + its + ":dir=\"" + literal.Dir + "\" " + its + ":version=\"2.0\">" +
+			escape(literal.Value) + "</" + name + ">", nil
+	case literal.Lang != "":
+		return "<" + name + " xml:lang=\"" + escape(literal.Lang) + "\">" + escape(literal.Value) + "</" + name + ">", nil
+	case literal.Datatype() == rdfNamespace+"XMLLiteral":
+		return "<" + name + " rdf:parseType=\"Literal\">" + literal.Value + "</" + name + ">", nil
+	case literal.Datatype() != rdf.XSDString:
+		return "<" + name + " rdf:datatype=\"" + escape(literal.Datatype()) + "\">" + escape(literal.Value) + "</" + name + ">", nil
+	}
+	return "<" + name + ">" + escape(literal.Value) + "</" + name + ">", nil
+}
+
+// Write serializes a RDF Graph in RDF/XML format.
+//
+// The prefixes captured by the graph are used to declare the XML namespaces of the document,
+// and new prefixes are generated for the predicates whose namespace isn't known.
+func (s RDFXMLSerializer) Write(out io.Writer, g graph.Graph) error {
+	ns := newNamespaces(graphPrefixes(g))
+	body := make([]string, 0)
+	var subject rdf.Node
+
+	// generate the body first, to know all the namespaces needed by the document
+	for _, triple := range collectTriples(g) {
+		if subject == nil || subject.String() != triple.Subject.String() {
+			attr, err := nodeAttr(triple.Subject, "about")
+			if err != nil {
+				return err
+			}
+			if subject != nil {
+				body = append(body, "  </rdf:Description>")
+			}
+			body = append(body, "  <rdf:Description "+attr+">")
+			subject = triple.Subject
+		}
+		elt, err := propertyElement(ns, triple)
+		if err != nil {
+			return err
+		}
+		body = append(body, "    "+elt)
+	}
+	if subject != nil {
+		body = append(body, "  </rdf:Description>")
+	}
+
+	declared := ns.prefixes.Map()
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(out)
+	w.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<rdf:RDF")
+	for _, name := range names {
+		w.WriteString("\n  xmlns:" + name + "=\"" + escape(declared[name]) + "\"")
+	}
+	w.WriteString(">\n")
+	for _, line := range body {
+		w.WriteString(line + "\n")
+	}
+	w.WriteString("</rdf:RDF>\n")
+	return w.Flush()
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bytes"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/parser"
+	"github.com/Callidon/joseki/rdf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteRDFXMLSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.LoadWithOptions("../parser/datas/test.ttl", graph.LoadOptions{Format: "turtle", BlankNodes: graph.KeepBlankNodes})
+	g.Add(rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/turtle"),
+		rdf.NewURI("http://example.org/vocab#knows"), rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples")))
+	expected := `<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF
+  xmlns:dc="http://purl.org/dc/terms/"
+  xmlns:foaf="http://xmlns.com/foaf/0.1/"
+  xmlns:ns0="http://example.org/vocab#"
+  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+  xmlns:sw="http://www.w3.org/2001/sw/RDFCore/">
+  <rdf:Description rdf:nodeID="v0">
+    <dc:title>My Title &lt;3</dc:title>
+  </rdf:Description>
+  <rdf:Description rdf:about="http://www.w3.org/2001/sw/RDFCore/ntriples">
+    <dc:title rdf:nodeID="a"/>
+    <dc:title>N-Triples</dc:title>
+    <dc:title>Turtle</dc:title>
+    <dc:title xml:lang="en">N-Triples</dc:title>
+    <rdf:type rdf:resource="http://xmlns.com/foaf/0.1/Document"/>
+    <foaf:maker rdf:nodeID="v0"/>
+  </rdf:Description>
+  <rdf:Description rdf:about="http://www.w3.org/2001/sw/RDFCore/turtle">
+    <ns0:knows rdf:resource="http://www.w3.org/2001/sw/RDFCore/ntriples"/>
+  </rdf:Description>
+</rdf:RDF>
+`
+	g.Add(rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+		rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("N-Triples")))
+	// replace the blank node generated by the parser, to check the escaping of the literals
+	g.Delete(rdf.NewVariable("v"), rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("My Title"))
+	g.Delete(rdf.NewVariable("v"), rdf.NewURI("http://xmlns.com/foaf/0.1/maker"), rdf.NewVariable("w"))
+	g.Add(rdf.NewTriple(rdf.NewURI("http://www.w3.org/2001/sw/RDFCore/ntriples"),
+		rdf.NewURI("http://xmlns.com/foaf/0.1/maker"), rdf.NewBlankNode("v0")))
+	g.Add(rdf.NewTriple(rdf.NewBlankNode("v0"), rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLiteral("My Title <3")))
+
+	if err := NewRDFXMLSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in RDF/XML shouldn't produce the error :", err)
+	}
+	if buf.String() != expected {
+		t.Error("expected the RDF/XML document\n", expected, "\nbut instead got\n", buf.String())
+	}
+}
+
+func TestReadWriteRDFXMLSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.LoadFromFile("../parser/datas/test.rdf", "rdfxml")
+	if err := NewRDFXMLSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in RDF/XML shouldn't produce the error :", err)
+	}
+
+	// read back the document and check that the triples are the same
+	dir, err := ioutil.TempDir("", "joseki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "graph.rdf")
+	ioutil.WriteFile(filename, buf.Bytes(), 0644)
+	cpt := 0
+	for triple := range parser.NewRDFXMLParser().Read(filename) {
+		found := false
+		for result := range g.Filter(triple.Subject, triple.Predicate, triple.Object) {
+			if test, err := result.Equals(triple); test && err == nil {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("the triple", triple, "read in the serialized graph isn't in the original graph")
+		}
+		cpt++
+	}
+	if cpt != 20 {
+		t.Error("expected 20 triples in the serialized graph but instead got", cpt)
+	}
+}
+
+func TestWriteErrorsRDFXMLSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	triples := []rdf.Triple{
+		rdf.NewTriple(rdf.NewLiteral("22"), rdf.NewURI("http://example.org/age"), rdf.NewLiteral("22")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/foo"), rdf.NewURI("http://example.org/22"), rdf.NewLiteral("22")),
+		rdf.NewTriple(rdf.NewURI("http://example.org/foo"), rdf.NewBlankNode("v"), rdf.NewLiteral("22")),
+		rdf.NewTriple(rdf.NewQuotedTriple(rdf.NewTriple(rdf.NewURI("http://example.org/foo"), rdf.NewURI("http://example.org/age"), rdf.NewLiteral("22"))),
+			rdf.NewURI("http://example.org/source"), rdf.NewURI("http://example.org/bar")),
+	}
+
+	for _, triple := range triples {
+		g := graph.NewListGraph()
+		g.Add(triple)
+		if err := NewRDFXMLSerializer().Write(&buf, g); err == nil {
+			t.Error("serializing the triple", triple, "in RDF/XML should produce an error")
+		}
+	}
+}
+
+func TestDirLangRDFXMLSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	book := rdf.NewURI("http://example.org/book")
+	g.Add(rdf.NewTriple(book, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewDirLangLiteral("Joseki", "ar", "rtl")))
+	g.Add(rdf.NewTriple(book, rdf.NewURI("http://purl.org/dc/terms/title"), rdf.NewLangLiteral("Joseki", "en")))
+	expected := `<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF
+  xmlns:ns0="http://purl.org/dc/terms/"
+  xmlns:ns1="http://www.w3.org/2005/11/its"
+  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="http://example.org/book">
+    <ns0:title xml:lang="ar" ns1:dir="rtl" ns1:version="2.0">Joseki</ns0:title>
+    <ns0:title xml:lang="en">Joseki</ns0:title>
+  </rdf:Description>
+</rdf:RDF>
+`
+	if err := NewRDFXMLSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in RDF/XML shouldn't produce the error :", err)
+	}
+	if buf.String() != expected {
+		t.Error("expected the RDF/XML document\n", expected, "\nbut instead got\n", buf.String())
+	}
+	readBack(t, g, parser.NewRDFXMLParser(), buf.Bytes(), 2)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bufio"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"sort"
+	"strings"
+)
+
+// TurtleSerializer is a serializer for writing RDF Graphs in Turtle format.
+// The quoted triples are written using the Turtle-star syntax, e.g. << :alice :knows :bob >> :source :facebook .
+//
+// Turtle reference : https://www.w3.org/TR/turtle/
+type TurtleSerializer struct{}
+
+// NewTurtleSerializer creates a new TurtleSerializer
+func NewTurtleSerializer() *TurtleSerializer {
+	return &TurtleSerializer{}
+}
+
+// Write serializes a RDF Graph in Turtle format.
+//
+// The prefixes captured by the graph are used to write the IRIs as prefixed names, and only the prefixes used are declared.
+// The triples are grouped by subject & predicate.
+func (s TurtleSerializer) Write(out io.Writer, g graph.Graph) error {
+	prefixes := rdf.NewPrefixMap()
+	for name, value := range graphPrefixes(g) {
+		// the default prefix is ignored, since the Turtle parser cannot read it
+		if name != "" {
+			prefixes.Set(name, value)
+		}
+	}
+	used := make(map[string]bool)
+	term := func(node rdf.Node) (string, error) {
+		return writeTerm(node, func(value string) string {
+			if curie, compacted := prefixes.Compact(value); compacted {
+				used[curie[:strings.Index(curie, ":")]] = true
+				return curie
+			}
+			return "<" + value + ">"
+		})
+	}
+
+	// generate the body first, to know all the prefixes needed by the document
+	body := make([]string, 0)
+	var subject, predicate rdf.Node
+	for _, triple := range collectTriples(g) {
+		terms, err := writeTriple(triple, term)
+		if err != nil {
+			return err
+		}
+		switch {
+		case subject == nil || rdf.Compare(subject, triple.Subject) != 0:
+			if subject != nil {
+				body[len(body)-1] += " ."
+				body = append(body, "")
+			}
+			body = append(body, strings.Join(terms, " "))
+		case rdf.Compare(predicate, triple.Predicate) != 0:
+			body[len(body)-1] += " ;"
+			body = append(body, "    "+terms[1]+" "+terms[2])
+		default:
+			body[len(body)-1] += " ,"
+			body = append(body, "        "+terms[2])
+		}
+		subject, predicate = triple.Subject, triple.Predicate
+	}
+	if subject != nil {
+		body[len(body)-1] += " ."
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := bufio.NewWriter(out)
+	for _, name := range names {
+		namespace, _ := prefixes.Namespace(name)
+		w.WriteString("@prefix " + name + ": <" + namespace + "> .\n")
+	}
+	if len(names) > 0 && len(body) > 0 {
+		w.WriteString("\n")
+	}
+	for _, line := range body {
+		w.WriteString(line + "\n")
+	}
+	return w.Flush()
+}
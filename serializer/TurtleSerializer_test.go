@@ -0,0 +1,52 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+package serializer
+
+import (
+	"bytes"
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/parser"
+	"github.com/Callidon/joseki/rdf"
+	"testing"
+)
+
+func TestWriteTurtleSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.LoadWithOptions("../parser/datas/quoted.ttl", graph.LoadOptions{Format: "turtle", BlankNodes: graph.KeepBlankNodes})
+	g.Add(rdf.NewTriple(rdf.NewURI("http://example.org/carol"), rdf.NewURI("http://example.org/says"), rdf.NewURI("http://example.org/hello")))
+	g.Add(rdf.NewTriple(rdf.NewURI("http://other.org/dave"), rdf.NewURI("http://example.org/says"), rdf.NewTypedLiteral("1", "http://www.w3.org/2001/XMLSchema#integer")))
+	expected := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+ex:carol ex:says ex:hello ,
+        << ex:bob foaf:age "23" >> .
+
+<http://other.org/dave> ex:says "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+
+<< ex:alice foaf:knows ex:bob >> ex:certainty "0.9" ;
+    ex:source "facebook" .
+
+<< << _:b0 foaf:knows ex:bob >> ex:certainty "0.5" >> ex:source ex:carol .
+`
+	if err := NewTurtleSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in Turtle shouldn't produce the error :", err)
+	}
+	if buf.String() != expected {
+		t.Error("expected the Turtle document\n", expected, "\nbut instead got\n", buf.String())
+	}
+}
+
+func TestReadWriteTurtleSerializer(t *testing.T) {
+	var buf bytes.Buffer
+	g := graph.NewListGraph()
+	g.LoadFromFile("../parser/datas/test.ttl", "turtle")
+	g.LoadFromFile("../parser/datas/quoted.ttl", "turtle")
+	if err := NewTurtleSerializer().Write(&buf, g); err != nil {
+		t.Error("serializing a valid graph in Turtle shouldn't produce the error :", err)
+	}
+	readBack(t, g, parser.NewTurtleParser(), buf.Bytes(), 10)
+	roundTrip(t, escapedGraph(), NewTurtleSerializer(), "turtle")
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Package serializer provides serializers to write RDF Graphs in several RDF formats (RDF/XML, JSON-LD, ...)
+package serializer
+
+import (
+	"github.com/Callidon/joseki/graph"
+	"github.com/Callidon/joseki/rdf"
+	"io"
+	"sort"
+)
+
+// Serializer represent a generic interface for writing a RDF Graph in every RDF format.
+//
+// Package serializer provides several implementations for this interface.
+type Serializer interface {
+	Write(out io.Writer, g graph.Graph) error
+}
+
+// prefixedGraph is a graph which keeps the prefixes read when loading data into it.
+type prefixedGraph interface {
+	Prefixes() map[string]string
+}
+
+// graphPrefixes returns the prefixes captured by a graph, or nil if it doesn't keep them.
+func graphPrefixes(g graph.Graph) map[string]string {
+	if pg, ok := g.(prefixedGraph); ok {
+		return pg.Prefixes()
+	}
+	return nil
+}
+
+// collectTriples fetches all the triples of a graph, sorted with rdf.CompareTriples
+// so the serialization of a graph is always the same.
+func collectTriples(g graph.Graph) []rdf.Triple {
+	triples := make([]rdf.Triple, 0)
+	for triple := range g.Filter(rdf.NewVariable("s"), rdf.NewVariable("p"), rdf.NewVariable("o")) {
+		triples = append(triples, triple)
+	}
+	sort.Slice(triples, func(i, j int) bool {
+		return rdf.CompareTriples(triples[i], triples[j]) < 0
+	})
+	return triples
+}
@@ -1,5 +1,5 @@
 #!/bin/bash
-PACKAGES="graph parser rdf"
+PACKAGES="graph jsonld parser rdf serializer"
 for pkg in $PACKAGES; do
   go test -coverprofile=$pkg.cover.out -coverpkg=./... ./$pkg
 done
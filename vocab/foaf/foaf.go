@@ -0,0 +1,222 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from foaf.ttl. DO NOT EDIT.
+
+// Package foaf provides the IRIs of the terms of the Friend of a Friend (FOAF) vocabulary.
+//
+// Namespace : http://xmlns.com/foaf/0.1/
+package foaf
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://xmlns.com/foaf/0.1/"
+
+var (
+	// Agent is the IRI of foaf:Agent. An agent (eg. person, group, software or physical artifact).
+	Agent = rdf.NewURI(Namespace + "Agent")
+
+	// Document is the IRI of foaf:Document. A document.
+	Document = rdf.NewURI(Namespace + "Document")
+
+	// Group is the IRI of foaf:Group. A class of Agents.
+	Group = rdf.NewURI(Namespace + "Group")
+
+	// Image is the IRI of foaf:Image. An image.
+	Image = rdf.NewURI(Namespace + "Image")
+
+	// LabelProperty is the IRI of foaf:LabelProperty (Label Property). A foaf:LabelProperty is any RDF
+	// property with textual values that serve as labels.
+	LabelProperty = rdf.NewURI(Namespace + "LabelProperty")
+
+	// OnlineAccount is the IRI of foaf:OnlineAccount (Online Account). An online account.
+	OnlineAccount = rdf.NewURI(Namespace + "OnlineAccount")
+
+	// OnlineChatAccount is the IRI of foaf:OnlineChatAccount (Online Chat Account). An online chat
+	// account.
+	OnlineChatAccount = rdf.NewURI(Namespace + "OnlineChatAccount")
+
+	// OnlineEcommerceAccount is the IRI of foaf:OnlineEcommerceAccount (Online E-commerce Account). An
+	// online e-commerce account.
+	OnlineEcommerceAccount = rdf.NewURI(Namespace + "OnlineEcommerceAccount")
+
+	// OnlineGamingAccount is the IRI of foaf:OnlineGamingAccount (Online Gaming Account). An online
+	// gaming account.
+	OnlineGamingAccount = rdf.NewURI(Namespace + "OnlineGamingAccount")
+
+	// Organization is the IRI of foaf:Organization. An organization.
+	Organization = rdf.NewURI(Namespace + "Organization")
+
+	// Person is the IRI of foaf:Person. A person.
+	Person = rdf.NewURI(Namespace + "Person")
+
+	// PersonalProfileDocument is the IRI of foaf:PersonalProfileDocument. A personal profile RDF
+	// document.
+	PersonalProfileDocument = rdf.NewURI(Namespace + "PersonalProfileDocument")
+
+	// Project is the IRI of foaf:Project. A project (a collective endeavour of some kind).
+	Project = rdf.NewURI(Namespace + "Project")
+
+	// Account is the IRI of foaf:account. Indicates an account held by this agent.
+	Account = rdf.NewURI(Namespace + "account")
+
+	// AccountName is the IRI of foaf:accountName (account name). Indicates the name (identifier)
+	// associated with this online account.
+	AccountName = rdf.NewURI(Namespace + "accountName")
+
+	// AccountServiceHomepage is the IRI of foaf:accountServiceHomepage (account service homepage).
+	// Indicates a homepage of the service provide for this online account.
+	AccountServiceHomepage = rdf.NewURI(Namespace + "accountServiceHomepage")
+
+	// Age is the IRI of foaf:age. The age in years of some agent.
+	Age = rdf.NewURI(Namespace + "age")
+
+	// BasedNear is the IRI of foaf:based_near (based near). A location that something is based near,
+	// for some broadly human notion of near.
+	BasedNear = rdf.NewURI(Namespace + "based_near")
+
+	// Birthday is the IRI of foaf:birthday. The birthday of this Agent, represented in mm-dd string
+	// form, eg. 12-31.
+	Birthday = rdf.NewURI(Namespace + "birthday")
+
+	// CurrentProject is the IRI of foaf:currentProject (current project). A current project this
+	// person works on.
+	CurrentProject = rdf.NewURI(Namespace + "currentProject")
+
+	// Depiction is the IRI of foaf:depiction. A depiction of some thing.
+	Depiction = rdf.NewURI(Namespace + "depiction")
+
+	// Depicts is the IRI of foaf:depicts. A thing depicted in this representation.
+	Depicts = rdf.NewURI(Namespace + "depicts")
+
+	// FamilyName is the IRI of foaf:familyName. The family name of some person.
+	FamilyName = rdf.NewURI(Namespace + "familyName")
+
+	// FirstName is the IRI of foaf:firstName. The first name of a person.
+	FirstName = rdf.NewURI(Namespace + "firstName")
+
+	// Focus is the IRI of foaf:focus. The underlying or 'focal' entity associated with some
+	// SKOS-described concept.
+	Focus = rdf.NewURI(Namespace + "focus")
+
+	// FundedBy is the IRI of foaf:fundedBy (funded by). An organization funding a project or person.
+	FundedBy = rdf.NewURI(Namespace + "fundedBy")
+
+	// Gender is the IRI of foaf:gender. The gender of this Agent (typically but not necessarily male
+	// or female).
+	Gender = rdf.NewURI(Namespace + "gender")
+
+	// GivenName is the IRI of foaf:givenName (Given name). The given name of some person.
+	GivenName = rdf.NewURI(Namespace + "givenName")
+
+	// Homepage is the IRI of foaf:homepage. A homepage for some thing.
+	Homepage = rdf.NewURI(Namespace + "homepage")
+
+	// Img is the IRI of foaf:img (image). An image that can be used to represent some thing (ie. those
+	// depictions which are particularly representative of something, eg. one's photo on a homepage).
+	Img = rdf.NewURI(Namespace + "img")
+
+	// Interest is the IRI of foaf:interest. A page about a topic of interest to this person.
+	Interest = rdf.NewURI(Namespace + "interest")
+
+	// IsPrimaryTopicOf is the IRI of foaf:isPrimaryTopicOf (is primary topic of). A document that this
+	// thing is the primary topic of.
+	IsPrimaryTopicOf = rdf.NewURI(Namespace + "isPrimaryTopicOf")
+
+	// Knows is the IRI of foaf:knows. A person known by this person (indicating some level of
+	// reciprocated interaction between the parties).
+	Knows = rdf.NewURI(Namespace + "knows")
+
+	// LastName is the IRI of foaf:lastName. The last name of a person.
+	LastName = rdf.NewURI(Namespace + "lastName")
+
+	// Logo is the IRI of foaf:logo. A logo representing some thing.
+	Logo = rdf.NewURI(Namespace + "logo")
+
+	// Made is the IRI of foaf:made. Something that was made by this agent.
+	Made = rdf.NewURI(Namespace + "made")
+
+	// Maker is the IRI of foaf:maker. An agent that made this thing.
+	Maker = rdf.NewURI(Namespace + "maker")
+
+	// Mbox is the IRI of foaf:mbox (personal mailbox). A personal mailbox, ie. an Internet mailbox
+	// associated with exactly one owner, the first owner of this mailbox.
+	Mbox = rdf.NewURI(Namespace + "mbox")
+
+	// MboxSha1sum is the IRI of foaf:mbox_sha1sum (sha1sum of a personal mailbox URI name). The
+	// sha1sum of the URI of an Internet mailbox associated with exactly one owner, the first owner of
+	// the mailbox.
+	MboxSha1sum = rdf.NewURI(Namespace + "mbox_sha1sum")
+
+	// Member is the IRI of foaf:member. Indicates a member of a Group.
+	Member = rdf.NewURI(Namespace + "member")
+
+	// Name is the IRI of foaf:name. A name for some thing.
+	Name = rdf.NewURI(Namespace + "name")
+
+	// Nick is the IRI of foaf:nick (nickname). A short informal nickname characterising an agent
+	// (includes login identifiers, IRC and other chat nicknames).
+	Nick = rdf.NewURI(Namespace + "nick")
+
+	// Openid is the IRI of foaf:openid. An OpenID for an Agent.
+	Openid = rdf.NewURI(Namespace + "openid")
+
+	// Page is the IRI of foaf:page. A page or document about this thing.
+	Page = rdf.NewURI(Namespace + "page")
+
+	// PastProject is the IRI of foaf:pastProject (past project). A project this person has previously
+	// worked on.
+	PastProject = rdf.NewURI(Namespace + "pastProject")
+
+	// Phone is the IRI of foaf:phone. A phone, specified using fully qualified tel: URI scheme (refs:
+	// http://www.w3.org/Addressing/schemes.html#tel).
+	Phone = rdf.NewURI(Namespace + "phone")
+
+	// PrimaryTopic is the IRI of foaf:primaryTopic (primary topic). The primary topic of some page or
+	// document.
+	PrimaryTopic = rdf.NewURI(Namespace + "primaryTopic")
+
+	// Publications is the IRI of foaf:publications. A link to the publications of this person.
+	Publications = rdf.NewURI(Namespace + "publications")
+
+	// SchoolHomepage is the IRI of foaf:schoolHomepage. A homepage of a school attended by the person.
+	SchoolHomepage = rdf.NewURI(Namespace + "schoolHomepage")
+
+	// Sha1 is the IRI of foaf:sha1 (sha1sum (hex)). A sha1sum hash, in hex.
+	Sha1 = rdf.NewURI(Namespace + "sha1")
+
+	// SkypeID is the IRI of foaf:skypeID (Skype ID). A Skype ID.
+	SkypeID = rdf.NewURI(Namespace + "skypeID")
+
+	// Status is the IRI of foaf:status. A string expressing what the user is happy for the general
+	// public (normally) to know about their current activity.
+	Status = rdf.NewURI(Namespace + "status")
+
+	// Theme is the IRI of foaf:theme. A theme.
+	Theme = rdf.NewURI(Namespace + "theme")
+
+	// Thumbnail is the IRI of foaf:thumbnail. A derived thumbnail image.
+	Thumbnail = rdf.NewURI(Namespace + "thumbnail")
+
+	// Title is the IRI of foaf:title. Title (Mr, Mrs, Ms, Dr. etc).
+	Title = rdf.NewURI(Namespace + "title")
+
+	// Topic is the IRI of foaf:topic. A topic of some page or document.
+	Topic = rdf.NewURI(Namespace + "topic")
+
+	// TopicInterest is the IRI of foaf:topic_interest. A thing of interest to this person.
+	TopicInterest = rdf.NewURI(Namespace + "topic_interest")
+
+	// Weblog is the IRI of foaf:weblog. A weblog of some thing (whether person, group, company etc.).
+	Weblog = rdf.NewURI(Namespace + "weblog")
+
+	// WorkInfoHomepage is the IRI of foaf:workInfoHomepage (work info homepage). A work info homepage
+	// of some person; a page about their work for some organization.
+	WorkInfoHomepage = rdf.NewURI(Namespace + "workInfoHomepage")
+
+	// WorkplaceHomepage is the IRI of foaf:workplaceHomepage (workplace homepage). A workplace
+	// homepage of some person; the homepage of an organization they work for.
+	WorkplaceHomepage = rdf.NewURI(Namespace + "workplaceHomepage")
+)
@@ -0,0 +1,7 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+//go:generate go run ../../cmd/joseki-vocab -namespace http://xmlns.com/foaf/0.1/ -package foaf -out foaf.go foaf.ttl
+
+package foaf
@@ -0,0 +1,7 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+//go:generate go run ../../cmd/joseki-vocab -namespace http://www.w3.org/2002/07/owl# -package owl -out owl.go owl.ttl
+
+package owl
@@ -0,0 +1,299 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from owl.ttl. DO NOT EDIT.
+
+// Package owl provides the IRIs of the terms of the OWL 2 Schema vocabulary.
+//
+// Namespace : http://www.w3.org/2002/07/owl#
+package owl
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://www.w3.org/2002/07/owl#"
+
+var (
+	// AllDifferent is the IRI of owl:AllDifferent. The class of collections of pairwise different
+	// individuals.
+	AllDifferent = rdf.NewURI(Namespace + "AllDifferent")
+
+	// AllDisjointClasses is the IRI of owl:AllDisjointClasses. The class of collections of pairwise
+	// disjoint classes.
+	AllDisjointClasses = rdf.NewURI(Namespace + "AllDisjointClasses")
+
+	// AllDisjointProperties is the IRI of owl:AllDisjointProperties. The class of collections of
+	// pairwise disjoint properties.
+	AllDisjointProperties = rdf.NewURI(Namespace + "AllDisjointProperties")
+
+	// Annotation is the IRI of owl:Annotation. The class of annotated annotations.
+	Annotation = rdf.NewURI(Namespace + "Annotation")
+
+	// AnnotationProperty is the IRI of owl:AnnotationProperty. The class of annotation properties.
+	AnnotationProperty = rdf.NewURI(Namespace + "AnnotationProperty")
+
+	// AsymmetricProperty is the IRI of owl:AsymmetricProperty. The class of asymmetric properties.
+	AsymmetricProperty = rdf.NewURI(Namespace + "AsymmetricProperty")
+
+	// Axiom is the IRI of owl:Axiom. The class of annotated axioms.
+	Axiom = rdf.NewURI(Namespace + "Axiom")
+
+	// Class is the IRI of owl:Class. The class of OWL classes.
+	Class = rdf.NewURI(Namespace + "Class")
+
+	// DataRange is the IRI of owl:DataRange. The class of OWL data ranges, which is a special kind of
+	// datatype. Note: The use of the IRI owl:DataRange has been deprecated as of OWL 2.
+	DataRange = rdf.NewURI(Namespace + "DataRange")
+
+	// DatatypeProperty is the IRI of owl:DatatypeProperty. The class of data properties.
+	DatatypeProperty = rdf.NewURI(Namespace + "DatatypeProperty")
+
+	// DeprecatedClass is the IRI of owl:DeprecatedClass. The class of deprecated classes.
+	DeprecatedClass = rdf.NewURI(Namespace + "DeprecatedClass")
+
+	// DeprecatedProperty is the IRI of owl:DeprecatedProperty. The class of deprecated properties.
+	DeprecatedProperty = rdf.NewURI(Namespace + "DeprecatedProperty")
+
+	// FunctionalProperty is the IRI of owl:FunctionalProperty. The class of functional properties.
+	FunctionalProperty = rdf.NewURI(Namespace + "FunctionalProperty")
+
+	// InverseFunctionalProperty is the IRI of owl:InverseFunctionalProperty. The class of
+	// inverse-functional properties.
+	InverseFunctionalProperty = rdf.NewURI(Namespace + "InverseFunctionalProperty")
+
+	// IrreflexiveProperty is the IRI of owl:IrreflexiveProperty. The class of irreflexive properties.
+	IrreflexiveProperty = rdf.NewURI(Namespace + "IrreflexiveProperty")
+
+	// NamedIndividual is the IRI of owl:NamedIndividual. The class of named individuals.
+	NamedIndividual = rdf.NewURI(Namespace + "NamedIndividual")
+
+	// NegativePropertyAssertion is the IRI of owl:NegativePropertyAssertion. The class of negative
+	// property assertions.
+	NegativePropertyAssertion = rdf.NewURI(Namespace + "NegativePropertyAssertion")
+
+	// Nothing is the IRI of owl:Nothing. This is the empty class.
+	Nothing = rdf.NewURI(Namespace + "Nothing")
+
+	// ObjectProperty is the IRI of owl:ObjectProperty. The class of object properties.
+	ObjectProperty = rdf.NewURI(Namespace + "ObjectProperty")
+
+	// Ontology is the IRI of owl:Ontology. The class of ontologies.
+	Ontology = rdf.NewURI(Namespace + "Ontology")
+
+	// OntologyProperty is the IRI of owl:OntologyProperty. The class of ontology properties.
+	OntologyProperty = rdf.NewURI(Namespace + "OntologyProperty")
+
+	// ReflexiveProperty is the IRI of owl:ReflexiveProperty. The class of reflexive properties.
+	ReflexiveProperty = rdf.NewURI(Namespace + "ReflexiveProperty")
+
+	// Restriction is the IRI of owl:Restriction. The class of property restrictions.
+	Restriction = rdf.NewURI(Namespace + "Restriction")
+
+	// SymmetricProperty is the IRI of owl:SymmetricProperty. The class of symmetric properties.
+	SymmetricProperty = rdf.NewURI(Namespace + "SymmetricProperty")
+
+	// Thing is the IRI of owl:Thing. The class of OWL individuals.
+	Thing = rdf.NewURI(Namespace + "Thing")
+
+	// TransitiveProperty is the IRI of owl:TransitiveProperty. The class of transitive properties.
+	TransitiveProperty = rdf.NewURI(Namespace + "TransitiveProperty")
+
+	// AllValuesFrom is the IRI of owl:allValuesFrom. The property that determines the class that a
+	// universal property restriction refers to.
+	AllValuesFrom = rdf.NewURI(Namespace + "allValuesFrom")
+
+	// AnnotatedProperty is the IRI of owl:annotatedProperty. The property that determines the
+	// predicate of an annotated axiom or annotated annotation.
+	AnnotatedProperty = rdf.NewURI(Namespace + "annotatedProperty")
+
+	// AnnotatedSource is the IRI of owl:annotatedSource. The property that determines the subject of
+	// an annotated axiom or annotated annotation.
+	AnnotatedSource = rdf.NewURI(Namespace + "annotatedSource")
+
+	// AnnotatedTarget is the IRI of owl:annotatedTarget. The property that determines the object of an
+	// annotated axiom or annotated annotation.
+	AnnotatedTarget = rdf.NewURI(Namespace + "annotatedTarget")
+
+	// AssertionProperty is the IRI of owl:assertionProperty. The property that determines the
+	// predicate of a negative property assertion.
+	AssertionProperty = rdf.NewURI(Namespace + "assertionProperty")
+
+	// BackwardCompatibleWith is the IRI of owl:backwardCompatibleWith. The annotation property that
+	// indicates that a given ontology is backward compatible with another ontology.
+	BackwardCompatibleWith = rdf.NewURI(Namespace + "backwardCompatibleWith")
+
+	// BottomDataProperty is the IRI of owl:bottomDataProperty. The data property that does not relate
+	// any individual to any data value.
+	BottomDataProperty = rdf.NewURI(Namespace + "bottomDataProperty")
+
+	// BottomObjectProperty is the IRI of owl:bottomObjectProperty. The object property that does not
+	// relate any two individuals.
+	BottomObjectProperty = rdf.NewURI(Namespace + "bottomObjectProperty")
+
+	// Cardinality is the IRI of owl:cardinality. The property that determines the cardinality of an
+	// exact cardinality restriction.
+	Cardinality = rdf.NewURI(Namespace + "cardinality")
+
+	// ComplementOf is the IRI of owl:complementOf. The property that determines that a given class is
+	// the complement of another class.
+	ComplementOf = rdf.NewURI(Namespace + "complementOf")
+
+	// Deprecated is the IRI of owl:deprecated. The annotation property that indicates that a given
+	// entity has been deprecated.
+	Deprecated = rdf.NewURI(Namespace + "deprecated")
+
+	// DifferentFrom is the IRI of owl:differentFrom. The property that determines that two given
+	// individuals are different.
+	DifferentFrom = rdf.NewURI(Namespace + "differentFrom")
+
+	// DisjointUnionOf is the IRI of owl:disjointUnionOf. The property that determines that a given
+	// class is equivalent to the disjoint union of a collection of other classes.
+	DisjointUnionOf = rdf.NewURI(Namespace + "disjointUnionOf")
+
+	// DisjointWith is the IRI of owl:disjointWith. The property that determines that two given classes
+	// are disjoint.
+	DisjointWith = rdf.NewURI(Namespace + "disjointWith")
+
+	// DistinctMembers is the IRI of owl:distinctMembers. The property that determines the collection
+	// of pairwise different individuals in a owl:AllDifferent axiom.
+	DistinctMembers = rdf.NewURI(Namespace + "distinctMembers")
+
+	// EquivalentClass is the IRI of owl:equivalentClass. The property that determines that two given
+	// classes are equivalent, and that is used to specify datatype definitions.
+	EquivalentClass = rdf.NewURI(Namespace + "equivalentClass")
+
+	// EquivalentProperty is the IRI of owl:equivalentProperty. The property that determines that two
+	// given properties are equivalent.
+	EquivalentProperty = rdf.NewURI(Namespace + "equivalentProperty")
+
+	// HasKey is the IRI of owl:hasKey. The property that determines the collection of properties that
+	// jointly build a key.
+	HasKey = rdf.NewURI(Namespace + "hasKey")
+
+	// HasSelf is the IRI of owl:hasSelf. The property that determines the property that a self
+	// restriction refers to.
+	HasSelf = rdf.NewURI(Namespace + "hasSelf")
+
+	// HasValue is the IRI of owl:hasValue. The property that determines the individual that a
+	// has-value restriction refers to.
+	HasValue = rdf.NewURI(Namespace + "hasValue")
+
+	// Imports is the IRI of owl:imports. The property that is used for importing other ontologies into
+	// a given ontology.
+	Imports = rdf.NewURI(Namespace + "imports")
+
+	// IncompatibleWith is the IRI of owl:incompatibleWith. The annotation property that indicates that
+	// a given ontology is incompatible with another ontology.
+	IncompatibleWith = rdf.NewURI(Namespace + "incompatibleWith")
+
+	// IntersectionOf is the IRI of owl:intersectionOf. The property that determines the collection of
+	// classes or data ranges that build an intersection.
+	IntersectionOf = rdf.NewURI(Namespace + "intersectionOf")
+
+	// InverseOf is the IRI of owl:inverseOf. The property that determines that two given properties
+	// are inverse.
+	InverseOf = rdf.NewURI(Namespace + "inverseOf")
+
+	// MaxCardinality is the IRI of owl:maxCardinality. The property that determines the cardinality of
+	// a maximum cardinality restriction.
+	MaxCardinality = rdf.NewURI(Namespace + "maxCardinality")
+
+	// MaxQualifiedCardinality is the IRI of owl:maxQualifiedCardinality. The property that determines
+	// the cardinality of a maximum qualified cardinality restriction.
+	MaxQualifiedCardinality = rdf.NewURI(Namespace + "maxQualifiedCardinality")
+
+	// Members is the IRI of owl:members. The property that determines the collection of members in
+	// either a owl:AllDifferent, owl:AllDisjointClasses or owl:AllDisjointProperties axiom.
+	Members = rdf.NewURI(Namespace + "members")
+
+	// MinCardinality is the IRI of owl:minCardinality. The property that determines the cardinality of
+	// a minimum cardinality restriction.
+	MinCardinality = rdf.NewURI(Namespace + "minCardinality")
+
+	// MinQualifiedCardinality is the IRI of owl:minQualifiedCardinality. The property that determines
+	// the cardinality of a minimum qualified cardinality restriction.
+	MinQualifiedCardinality = rdf.NewURI(Namespace + "minQualifiedCardinality")
+
+	// OnClass is the IRI of owl:onClass. The property that determines the class that a qualified
+	// object cardinality restriction refers to.
+	OnClass = rdf.NewURI(Namespace + "onClass")
+
+	// OnDatatype is the IRI of owl:onDatatype. The property that determines the datatype that a
+	// datatype restriction refers to.
+	OnDatatype = rdf.NewURI(Namespace + "onDatatype")
+
+	// OnProperty is the IRI of owl:onProperty. The property that determines the property that a
+	// property restriction refers to.
+	OnProperty = rdf.NewURI(Namespace + "onProperty")
+
+	// OneOf is the IRI of owl:oneOf. The property that determines the collection of individuals or
+	// data values that build an enumeration.
+	OneOf = rdf.NewURI(Namespace + "oneOf")
+
+	// PriorVersion is the IRI of owl:priorVersion. The annotation property that indicates the
+	// predecessor ontology of a given ontology.
+	PriorVersion = rdf.NewURI(Namespace + "priorVersion")
+
+	// PropertyChainAxiom is the IRI of owl:propertyChainAxiom. The property that determines the
+	// n-tuple of properties that build a sub property chain of a given property.
+	PropertyChainAxiom = rdf.NewURI(Namespace + "propertyChainAxiom")
+
+	// PropertyDisjointWith is the IRI of owl:propertyDisjointWith. The property that determines that
+	// two given properties are disjoint.
+	PropertyDisjointWith = rdf.NewURI(Namespace + "propertyDisjointWith")
+
+	// QualifiedCardinality is the IRI of owl:qualifiedCardinality. The property that determines the
+	// cardinality of an exact qualified cardinality restriction.
+	QualifiedCardinality = rdf.NewURI(Namespace + "qualifiedCardinality")
+
+	// Rational is the IRI of owl:rational. The datatype of the rational numbers.
+	Rational = rdf.NewURI(Namespace + "rational")
+
+	// Real is the IRI of owl:real. The datatype of the real numbers.
+	Real = rdf.NewURI(Namespace + "real")
+
+	// SameAs is the IRI of owl:sameAs. The property that determines that two given individuals are
+	// equal.
+	SameAs = rdf.NewURI(Namespace + "sameAs")
+
+	// SomeValuesFrom is the IRI of owl:someValuesFrom. The property that determines the class that an
+	// existential property restriction refers to.
+	SomeValuesFrom = rdf.NewURI(Namespace + "someValuesFrom")
+
+	// SourceIndividual is the IRI of owl:sourceIndividual. The property that determines the subject of
+	// a negative property assertion.
+	SourceIndividual = rdf.NewURI(Namespace + "sourceIndividual")
+
+	// TargetIndividual is the IRI of owl:targetIndividual. The property that determines the object of
+	// a negative object property assertion.
+	TargetIndividual = rdf.NewURI(Namespace + "targetIndividual")
+
+	// TargetValue is the IRI of owl:targetValue. The property that determines the value of a negative
+	// data property assertion.
+	TargetValue = rdf.NewURI(Namespace + "targetValue")
+
+	// TopDataProperty is the IRI of owl:topDataProperty. The data property that relates every
+	// individual to every data value.
+	TopDataProperty = rdf.NewURI(Namespace + "topDataProperty")
+
+	// TopObjectProperty is the IRI of owl:topObjectProperty. The object property that relates every
+	// two individuals.
+	TopObjectProperty = rdf.NewURI(Namespace + "topObjectProperty")
+
+	// UnionOf is the IRI of owl:unionOf. The property that determines the collection of classes or
+	// data ranges that build a union.
+	UnionOf = rdf.NewURI(Namespace + "unionOf")
+
+	// VersionIRI is the IRI of owl:versionIRI. The property that identifies the version IRI of an
+	// ontology.
+	VersionIRI = rdf.NewURI(Namespace + "versionIRI")
+
+	// VersionInfo is the IRI of owl:versionInfo. The annotation property that provides version
+	// information for an ontology or another OWL construct.
+	VersionInfo = rdf.NewURI(Namespace + "versionInfo")
+
+	// WithRestrictions is the IRI of owl:withRestrictions. The property that determines the collection
+	// of facet-value pairs that define a datatype restriction.
+	WithRestrictions = rdf.NewURI(Namespace + "withRestrictions")
+)
@@ -0,0 +1,92 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from rdf.ttl. DO NOT EDIT.
+
+// Package rdf provides the IRIs of the terms of the RDF Concepts vocabulary.
+//
+// Namespace : http://www.w3.org/1999/02/22-rdf-syntax-ns#
+package rdf
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+var (
+	// Alt is the IRI of rdf:Alt. The class of containers of alternatives.
+	Alt = rdf.NewURI(Namespace + "Alt")
+
+	// Bag is the IRI of rdf:Bag. The class of unordered containers.
+	Bag = rdf.NewURI(Namespace + "Bag")
+
+	// CompoundLiteral is the IRI of rdf:CompoundLiteral. A class representing a compound literal.
+	CompoundLiteral = rdf.NewURI(Namespace + "CompoundLiteral")
+
+	// HTML is the IRI of rdf:HTML. The datatype of RDF literals storing fragments of HTML content.
+	HTML = rdf.NewURI(Namespace + "HTML")
+
+	// JSON is the IRI of rdf:JSON. The datatype of RDF literals storing JSON content.
+	JSON = rdf.NewURI(Namespace + "JSON")
+
+	// List is the IRI of rdf:List. The class of RDF Lists.
+	List = rdf.NewURI(Namespace + "List")
+
+	// PlainLiteral is the IRI of rdf:PlainLiteral. The class of plain literals.
+	PlainLiteral = rdf.NewURI(Namespace + "PlainLiteral")
+
+	// Property is the IRI of rdf:Property. The class of RDF properties.
+	Property = rdf.NewURI(Namespace + "Property")
+
+	// Seq is the IRI of rdf:Seq. The class of ordered containers.
+	Seq = rdf.NewURI(Namespace + "Seq")
+
+	// Statement is the IRI of rdf:Statement. The class of RDF statements.
+	Statement = rdf.NewURI(Namespace + "Statement")
+
+	// XMLLiteral is the IRI of rdf:XMLLiteral. The datatype of XML literal values.
+	XMLLiteral = rdf.NewURI(Namespace + "XMLLiteral")
+
+	// DirLangString is the IRI of rdf:dirLangString. The datatype of directional language-tagged
+	// string values.
+	DirLangString = rdf.NewURI(Namespace + "dirLangString")
+
+	// Direction is the IRI of rdf:direction. The base direction component of a CompoundLiteral.
+	Direction = rdf.NewURI(Namespace + "direction")
+
+	// First is the IRI of rdf:first. The first item in the subject RDF list.
+	First = rdf.NewURI(Namespace + "first")
+
+	// LangString is the IRI of rdf:langString. The datatype of language-tagged string values.
+	LangString = rdf.NewURI(Namespace + "langString")
+
+	// Language is the IRI of rdf:language. The language component of a CompoundLiteral.
+	Language = rdf.NewURI(Namespace + "language")
+
+	// Nil is the IRI of rdf:nil. The empty list, with no items in it. If the rest of a list is nil
+	// then the list has no more items in it.
+	Nil = rdf.NewURI(Namespace + "nil")
+
+	// Object is the IRI of rdf:object. The object of the subject RDF statement.
+	Object = rdf.NewURI(Namespace + "object")
+
+	// Predicate is the IRI of rdf:predicate. The predicate of the subject RDF statement.
+	Predicate = rdf.NewURI(Namespace + "predicate")
+
+	// Reifies is the IRI of rdf:reifies. The property used to relate a reifier to the triple term it
+	// reifies.
+	Reifies = rdf.NewURI(Namespace + "reifies")
+
+	// Rest is the IRI of rdf:rest. The rest of the subject RDF list after the first item.
+	Rest = rdf.NewURI(Namespace + "rest")
+
+	// Subject is the IRI of rdf:subject. The subject of the subject RDF statement.
+	Subject = rdf.NewURI(Namespace + "subject")
+
+	// Type is the IRI of rdf:type. The subject is an instance of a class.
+	Type = rdf.NewURI(Namespace + "type")
+
+	// Value is the IRI of rdf:value. Idiomatic property used for structured values.
+	Value = rdf.NewURI(Namespace + "value")
+)
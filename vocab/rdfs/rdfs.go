@@ -0,0 +1,64 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from rdfs.ttl. DO NOT EDIT.
+
+// Package rdfs provides the IRIs of the terms of the RDF Schema vocabulary.
+//
+// Namespace : http://www.w3.org/2000/01/rdf-schema#
+package rdfs
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://www.w3.org/2000/01/rdf-schema#"
+
+var (
+	// Class is the IRI of rdfs:Class. The class of classes.
+	Class = rdf.NewURI(Namespace + "Class")
+
+	// Container is the IRI of rdfs:Container. The class of RDF containers.
+	Container = rdf.NewURI(Namespace + "Container")
+
+	// ContainerMembershipProperty is the IRI of rdfs:ContainerMembershipProperty. The class of
+	// container membership properties, rdf:_1, rdf:_2, ..., all of which are sub-properties of member.
+	ContainerMembershipProperty = rdf.NewURI(Namespace + "ContainerMembershipProperty")
+
+	// Datatype is the IRI of rdfs:Datatype. The class of RDF datatypes.
+	Datatype = rdf.NewURI(Namespace + "Datatype")
+
+	// Literal is the IRI of rdfs:Literal. The class of literal values, eg. textual strings and
+	// integers.
+	Literal = rdf.NewURI(Namespace + "Literal")
+
+	// Resource is the IRI of rdfs:Resource. The class resource, everything.
+	Resource = rdf.NewURI(Namespace + "Resource")
+
+	// Comment is the IRI of rdfs:comment. A description of the subject resource.
+	Comment = rdf.NewURI(Namespace + "comment")
+
+	// Domain is the IRI of rdfs:domain. A domain of the subject property.
+	Domain = rdf.NewURI(Namespace + "domain")
+
+	// IsDefinedBy is the IRI of rdfs:isDefinedBy. The definition of the subject resource.
+	IsDefinedBy = rdf.NewURI(Namespace + "isDefinedBy")
+
+	// Label is the IRI of rdfs:label. A human-readable name for the subject.
+	Label = rdf.NewURI(Namespace + "label")
+
+	// Member is the IRI of rdfs:member. A member of the subject resource.
+	Member = rdf.NewURI(Namespace + "member")
+
+	// Range is the IRI of rdfs:range. A range of the subject property.
+	Range = rdf.NewURI(Namespace + "range")
+
+	// SeeAlso is the IRI of rdfs:seeAlso. Further information about the subject resource.
+	SeeAlso = rdf.NewURI(Namespace + "seeAlso")
+
+	// SubClassOf is the IRI of rdfs:subClassOf. The subject is a subclass of a class.
+	SubClassOf = rdf.NewURI(Namespace + "subClassOf")
+
+	// SubPropertyOf is the IRI of rdfs:subPropertyOf. The subject is a subproperty of a property.
+	SubPropertyOf = rdf.NewURI(Namespace + "subPropertyOf")
+)
@@ -0,0 +1,144 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from skos.ttl. DO NOT EDIT.
+
+// Package skos provides the IRIs of the terms of the SKOS Vocabulary vocabulary.
+//
+// Namespace : http://www.w3.org/2004/02/skos/core#
+package skos
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://www.w3.org/2004/02/skos/core#"
+
+var (
+	// Collection is the IRI of skos:Collection. A meaningful collection of concepts.
+	Collection = rdf.NewURI(Namespace + "Collection")
+
+	// Concept is the IRI of skos:Concept. An idea or notion; a unit of thought.
+	Concept = rdf.NewURI(Namespace + "Concept")
+
+	// ConceptScheme is the IRI of skos:ConceptScheme (Concept Scheme). A set of concepts, optionally
+	// including statements about semantic relationships between those concepts.
+	ConceptScheme = rdf.NewURI(Namespace + "ConceptScheme")
+
+	// OrderedCollection is the IRI of skos:OrderedCollection (Ordered Collection). An ordered
+	// collection of concepts, where both the grouping and the ordering are meaningful.
+	OrderedCollection = rdf.NewURI(Namespace + "OrderedCollection")
+
+	// AltLabel is the IRI of skos:altLabel (alternative label). An alternative lexical label for a
+	// resource.
+	AltLabel = rdf.NewURI(Namespace + "altLabel")
+
+	// BroadMatch is the IRI of skos:broadMatch (has broader match). Used to state a hierarchical
+	// mapping link between two conceptual resources in different concept schemes.
+	BroadMatch = rdf.NewURI(Namespace + "broadMatch")
+
+	// Broader is the IRI of skos:broader (has broader). Relates a concept to a concept that is more
+	// general in meaning.
+	Broader = rdf.NewURI(Namespace + "broader")
+
+	// BroaderTransitive is the IRI of skos:broaderTransitive (has broader transitive). The transitive
+	// closure of the broader relation.
+	BroaderTransitive = rdf.NewURI(Namespace + "broaderTransitive")
+
+	// ChangeNote is the IRI of skos:changeNote (change note). A note about a modification to a
+	// concept.
+	ChangeNote = rdf.NewURI(Namespace + "changeNote")
+
+	// CloseMatch is the IRI of skos:closeMatch (has close match). Used to link two concepts that are
+	// sufficiently similar that they can be used interchangeably in some information retrieval
+	// applications.
+	CloseMatch = rdf.NewURI(Namespace + "closeMatch")
+
+	// Definition is the IRI of skos:definition. A statement or formal explanation of the meaning of a
+	// concept.
+	Definition = rdf.NewURI(Namespace + "definition")
+
+	// EditorialNote is the IRI of skos:editorialNote (editorial note). A note for an editor,
+	// translator or maintainer of the vocabulary.
+	EditorialNote = rdf.NewURI(Namespace + "editorialNote")
+
+	// ExactMatch is the IRI of skos:exactMatch (has exact match). Used to link two concepts,
+	// indicating a high degree of confidence that the concepts can be used interchangeably across a
+	// wide range of information retrieval applications.
+	ExactMatch = rdf.NewURI(Namespace + "exactMatch")
+
+	// Example is the IRI of skos:example. An example of the use of a concept.
+	Example = rdf.NewURI(Namespace + "example")
+
+	// HasTopConcept is the IRI of skos:hasTopConcept (has top concept). Relates, by convention, a
+	// concept scheme to a concept which is topmost in the broader/narrower concept hierarchies for
+	// that scheme.
+	HasTopConcept = rdf.NewURI(Namespace + "hasTopConcept")
+
+	// HiddenLabel is the IRI of skos:hiddenLabel (hidden label). A lexical label for a resource that
+	// should be hidden when generating visual displays of the resource, but should still be accessible
+	// to free text search operations.
+	HiddenLabel = rdf.NewURI(Namespace + "hiddenLabel")
+
+	// HistoryNote is the IRI of skos:historyNote (history note). A note about the past
+	// state/use/meaning of a concept.
+	HistoryNote = rdf.NewURI(Namespace + "historyNote")
+
+	// InScheme is the IRI of skos:inScheme (is in scheme). Relates a resource (for example a concept)
+	// to a concept scheme in which it is included.
+	InScheme = rdf.NewURI(Namespace + "inScheme")
+
+	// MappingRelation is the IRI of skos:mappingRelation (is in mapping relation with). Relates two
+	// concepts coming, by convention, from different schemes, and that have comparable meanings.
+	MappingRelation = rdf.NewURI(Namespace + "mappingRelation")
+
+	// Member is the IRI of skos:member (has member). Relates a collection to one of its members.
+	Member = rdf.NewURI(Namespace + "member")
+
+	// MemberList is the IRI of skos:memberList (has member list). Relates an ordered collection to the
+	// RDF list containing its members.
+	MemberList = rdf.NewURI(Namespace + "memberList")
+
+	// NarrowMatch is the IRI of skos:narrowMatch (has narrower match). Used to state a hierarchical
+	// mapping link between two conceptual resources in different concept schemes.
+	NarrowMatch = rdf.NewURI(Namespace + "narrowMatch")
+
+	// Narrower is the IRI of skos:narrower (has narrower). Relates a concept to a concept that is more
+	// specific in meaning.
+	Narrower = rdf.NewURI(Namespace + "narrower")
+
+	// NarrowerTransitive is the IRI of skos:narrowerTransitive (has narrower transitive). The
+	// transitive closure of the narrower relation.
+	NarrowerTransitive = rdf.NewURI(Namespace + "narrowerTransitive")
+
+	// Notation is the IRI of skos:notation. A notation, also known as classification code, is a string
+	// of characters used to uniquely identify a concept within the scope of a given concept scheme.
+	Notation = rdf.NewURI(Namespace + "notation")
+
+	// Note is the IRI of skos:note. A general note, for any purpose.
+	Note = rdf.NewURI(Namespace + "note")
+
+	// PrefLabel is the IRI of skos:prefLabel (preferred label). The preferred and lexically-labeled
+	// label for a resource, in a given language.
+	PrefLabel = rdf.NewURI(Namespace + "prefLabel")
+
+	// Related is the IRI of skos:related (has related). Relates a concept to a concept with which
+	// there is an associative semantic relationship.
+	Related = rdf.NewURI(Namespace + "related")
+
+	// RelatedMatch is the IRI of skos:relatedMatch (has related match). Used to state an associative
+	// mapping link between two conceptual resources in different concept schemes.
+	RelatedMatch = rdf.NewURI(Namespace + "relatedMatch")
+
+	// ScopeNote is the IRI of skos:scopeNote (scope note). A note that helps to clarify the meaning
+	// and/or the use of a concept.
+	ScopeNote = rdf.NewURI(Namespace + "scopeNote")
+
+	// SemanticRelation is the IRI of skos:semanticRelation (is in semantic relation with). Links a
+	// concept to a concept related by meaning.
+	SemanticRelation = rdf.NewURI(Namespace + "semanticRelation")
+
+	// TopConceptOf is the IRI of skos:topConceptOf (is top concept in scheme). Relates a concept to
+	// the concept scheme that it is a top level concept of.
+	TopConceptOf = rdf.NewURI(Namespace + "topConceptOf")
+)
@@ -0,0 +1,178 @@
+// Copyright (c) 2016 Thomas Minier. All rights reserved.
+// Use of this source code is governed by a MIT License
+// license that can be found in the LICENSE file.
+
+// Code generated by joseki-vocab from xsd.ttl. DO NOT EDIT.
+
+// Package xsd provides the IRIs of the terms of the XML Schema Datatypes vocabulary.
+//
+// Namespace : http://www.w3.org/2001/XMLSchema#
+package xsd
+
+import "github.com/Callidon/joseki/rdf"
+
+// Namespace is the namespace of the vocabulary
+const Namespace = "http://www.w3.org/2001/XMLSchema#"
+
+var (
+	// ENTITIES is the IRI of xsd:ENTITIES. The datatype of lists of XML unparsed entities.
+	ENTITIES = rdf.NewURI(Namespace + "ENTITIES")
+
+	// ENTITY is the IRI of xsd:ENTITY. The datatype of XML unparsed entities.
+	ENTITY = rdf.NewURI(Namespace + "ENTITY")
+
+	// ID is the IRI of xsd:ID. The datatype of XML identifiers.
+	ID = rdf.NewURI(Namespace + "ID")
+
+	// IDREF is the IRI of xsd:IDREF. The datatype of references to XML identifiers.
+	IDREF = rdf.NewURI(Namespace + "IDREF")
+
+	// IDREFS is the IRI of xsd:IDREFS. The datatype of lists of references to XML identifiers.
+	IDREFS = rdf.NewURI(Namespace + "IDREFS")
+
+	// NCName is the IRI of xsd:NCName. The datatype of XML non-colonized names.
+	NCName = rdf.NewURI(Namespace + "NCName")
+
+	// NMTOKEN is the IRI of xsd:NMTOKEN. The datatype of XML name tokens.
+	NMTOKEN = rdf.NewURI(Namespace + "NMTOKEN")
+
+	// NMTOKENS is the IRI of xsd:NMTOKENS. The datatype of lists of XML name tokens.
+	NMTOKENS = rdf.NewURI(Namespace + "NMTOKENS")
+
+	// NOTATION is the IRI of xsd:NOTATION. The datatype of XML notations.
+	NOTATION = rdf.NewURI(Namespace + "NOTATION")
+
+	// Name is the IRI of xsd:Name. The datatype of XML Names.
+	Name = rdf.NewURI(Namespace + "Name")
+
+	// QName is the IRI of xsd:QName. The datatype of XML qualified names.
+	QName = rdf.NewURI(Namespace + "QName")
+
+	// AnyAtomicType is the IRI of xsd:anyAtomicType. The datatype of the base type of all the
+	// primitive atomic types.
+	AnyAtomicType = rdf.NewURI(Namespace + "anyAtomicType")
+
+	// AnySimpleType is the IRI of xsd:anySimpleType. The datatype of the base type of all the simple
+	// types.
+	AnySimpleType = rdf.NewURI(Namespace + "anySimpleType")
+
+	// AnyType is the IRI of xsd:anyType. The datatype of the root of the XSD type hierarchy.
+	AnyType = rdf.NewURI(Namespace + "anyType")
+
+	// AnyURI is the IRI of xsd:anyURI. The datatype of absolute or relative URIs and IRIs.
+	AnyURI = rdf.NewURI(Namespace + "anyURI")
+
+	// Base64Binary is the IRI of xsd:base64Binary. The datatype of base64-encoded binary data.
+	Base64Binary = rdf.NewURI(Namespace + "base64Binary")
+
+	// Boolean is the IRI of xsd:boolean. The datatype of the values true and false.
+	Boolean = rdf.NewURI(Namespace + "boolean")
+
+	// Byte is the IRI of xsd:byte. The datatype of 8-bit signed integers.
+	Byte = rdf.NewURI(Namespace + "byte")
+
+	// Date is the IRI of xsd:date. The datatype of dates, with or without timezone.
+	Date = rdf.NewURI(Namespace + "date")
+
+	// DateTime is the IRI of xsd:dateTime. The datatype of dates and times, with or without timezone.
+	DateTime = rdf.NewURI(Namespace + "dateTime")
+
+	// DateTimeStamp is the IRI of xsd:dateTimeStamp. The datatype of dates and times with a required
+	// timezone.
+	DateTimeStamp = rdf.NewURI(Namespace + "dateTimeStamp")
+
+	// DayTimeDuration is the IRI of xsd:dayTimeDuration. The datatype of durations of time, in days,
+	// hours, minutes and seconds.
+	DayTimeDuration = rdf.NewURI(Namespace + "dayTimeDuration")
+
+	// Decimal is the IRI of xsd:decimal. The datatype of arbitrary-precision decimal numbers.
+	Decimal = rdf.NewURI(Namespace + "decimal")
+
+	// Double is the IRI of xsd:double. The datatype of 64-bit floating-point numbers incl. infinities
+	// and NaN.
+	Double = rdf.NewURI(Namespace + "double")
+
+	// Duration is the IRI of xsd:duration. The datatype of durations of time.
+	Duration = rdf.NewURI(Namespace + "duration")
+
+	// Float is the IRI of xsd:float. The datatype of 32-bit floating-point numbers incl. infinities
+	// and NaN.
+	Float = rdf.NewURI(Namespace + "float")
+
+	// GDay is the IRI of xsd:gDay. The datatype of Gregorian calendar days of the month.
+	GDay = rdf.NewURI(Namespace + "gDay")
+
+	// GMonth is the IRI of xsd:gMonth. The datatype of Gregorian calendar months.
+	GMonth = rdf.NewURI(Namespace + "gMonth")
+
+	// GMonthDay is the IRI of xsd:gMonthDay. The datatype of Gregorian calendar days of a month.
+	GMonthDay = rdf.NewURI(Namespace + "gMonthDay")
+
+	// GYear is the IRI of xsd:gYear. The datatype of Gregorian calendar years.
+	GYear = rdf.NewURI(Namespace + "gYear")
+
+	// GYearMonth is the IRI of xsd:gYearMonth. The datatype of Gregorian calendar months of a year.
+	GYearMonth = rdf.NewURI(Namespace + "gYearMonth")
+
+	// HexBinary is the IRI of xsd:hexBinary. The datatype of hex-encoded binary data.
+	HexBinary = rdf.NewURI(Namespace + "hexBinary")
+
+	// Int is the IRI of xsd:int. The datatype of 32-bit signed integers.
+	Int = rdf.NewURI(Namespace + "int")
+
+	// Integer is the IRI of xsd:integer. The datatype of arbitrary-size integer numbers.
+	Integer = rdf.NewURI(Namespace + "integer")
+
+	// Language is the IRI of xsd:language. The datatype of language tags, as defined by BCP 47.
+	Language = rdf.NewURI(Namespace + "language")
+
+	// Long is the IRI of xsd:long. The datatype of 64-bit signed integers.
+	Long = rdf.NewURI(Namespace + "long")
+
+	// NegativeInteger is the IRI of xsd:negativeInteger. The datatype of integer numbers lower than 0.
+	NegativeInteger = rdf.NewURI(Namespace + "negativeInteger")
+
+	// NonNegativeInteger is the IRI of xsd:nonNegativeInteger. The datatype of integer numbers greater
+	// than or equal to 0.
+	NonNegativeInteger = rdf.NewURI(Namespace + "nonNegativeInteger")
+
+	// NonPositiveInteger is the IRI of xsd:nonPositiveInteger. The datatype of integer numbers lower
+	// than or equal to 0.
+	NonPositiveInteger = rdf.NewURI(Namespace + "nonPositiveInteger")
+
+	// NormalizedString is the IRI of xsd:normalizedString. The datatype of whitespace-replaced
+	// strings.
+	NormalizedString = rdf.NewURI(Namespace + "normalizedString")
+
+	// PositiveInteger is the IRI of xsd:positiveInteger. The datatype of integer numbers greater than
+	// 0.
+	PositiveInteger = rdf.NewURI(Namespace + "positiveInteger")
+
+	// Short is the IRI of xsd:short. The datatype of 16-bit signed integers.
+	Short = rdf.NewURI(Namespace + "short")
+
+	// String is the IRI of xsd:string. The datatype of character strings.
+	String = rdf.NewURI(Namespace + "string")
+
+	// Time is the IRI of xsd:time. The datatype of times, with or without timezone.
+	Time = rdf.NewURI(Namespace + "time")
+
+	// Token is the IRI of xsd:token. The datatype of tokenized strings.
+	Token = rdf.NewURI(Namespace + "token")
+
+	// UnsignedByte is the IRI of xsd:unsignedByte. The datatype of 8-bit unsigned integers.
+	UnsignedByte = rdf.NewURI(Namespace + "unsignedByte")
+
+	// UnsignedInt is the IRI of xsd:unsignedInt. The datatype of 32-bit unsigned integers.
+	UnsignedInt = rdf.NewURI(Namespace + "unsignedInt")
+
+	// UnsignedLong is the IRI of xsd:unsignedLong. The datatype of 64-bit unsigned integers.
+	UnsignedLong = rdf.NewURI(Namespace + "unsignedLong")
+
+	// UnsignedShort is the IRI of xsd:unsignedShort. The datatype of 16-bit unsigned integers.
+	UnsignedShort = rdf.NewURI(Namespace + "unsignedShort")
+
+	// YearMonthDuration is the IRI of xsd:yearMonthDuration. The datatype of durations of time, in
+	// years and months.
+	YearMonthDuration = rdf.NewURI(Namespace + "yearMonthDuration")
+)